@@ -0,0 +1,120 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
+)
+
+// V1alpha2SpecAnnotation is the annotation storing the fields of a v1alpha2 CLIPlugin
+// that v1alpha1 does not have, so that they are not lost when a v1alpha2 CLIPlugin is
+// converted to v1alpha1 and back.
+const V1alpha2SpecAnnotation = "cli.tanzu.vmware.com/v1alpha2-spec"
+
+// v1alpha2OnlySpec contains the fields of the v1alpha2 CLIPluginSpec that v1alpha1 does not have
+type v1alpha2OnlySpec struct {
+	Deprecated         bool              `json:"deprecated,omitempty"`
+	DeprecationMessage string            `json:"deprecationMessage,omitempty"`
+	ReplacedBy         string            `json:"replacedBy,omitempty"`
+	MinCLIVersion      string            `json:"minCLIVersion,omitempty"`
+	ReleaseNotes       map[string]string `json:"releaseNotes,omitempty"`
+}
+
+func (s *v1alpha2OnlySpec) isEmpty() bool {
+	return !s.Deprecated && s.DeprecationMessage == "" && s.ReplacedBy == "" && s.MinCLIVersion == "" && len(s.ReleaseNotes) == 0
+}
+
+// ConvertTo converts this CLIPlugin to the Hub version (v1alpha2).
+func (src *CLIPlugin) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha2.CLIPlugin)
+	if !ok {
+		return errors.Errorf("cannot convert CLIPlugin %q to %T", src.Name, dstRaw)
+	}
+
+	var v1alpha2Only v1alpha2OnlySpec
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if value, exists := dst.Annotations[V1alpha2SpecAnnotation]; exists {
+		if err := json.Unmarshal([]byte(value), &v1alpha2Only); err != nil {
+			return errors.Wrapf(err, "invalid %s annotation for CLIPlugin %q", V1alpha2SpecAnnotation, src.Name)
+		}
+		delete(dst.Annotations, V1alpha2SpecAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	dst.Spec = v1alpha2.CLIPluginSpec{
+		Description:        src.Spec.Description,
+		RecommendedVersion: src.Spec.RecommendedVersion,
+		Optional:           src.Spec.Optional,
+		Target:             src.Spec.Target,
+		Deprecated:         v1alpha2Only.Deprecated,
+		DeprecationMessage: v1alpha2Only.DeprecationMessage,
+		ReplacedBy:         v1alpha2Only.ReplacedBy,
+		MinCLIVersion:      v1alpha2Only.MinCLIVersion,
+		ReleaseNotes:       v1alpha2Only.ReleaseNotes,
+	}
+	if src.Spec.Artifacts != nil {
+		dst.Spec.Artifacts = make(map[string]v1alpha2.ArtifactList, len(src.Spec.Artifacts))
+		for version, artifacts := range src.Spec.Artifacts {
+			dstArtifacts := make(v1alpha2.ArtifactList, 0, len(artifacts))
+			for _, a := range artifacts {
+				dstArtifacts = append(dstArtifacts, v1alpha2.Artifact(a))
+			}
+			dst.Spec.Artifacts[version] = dstArtifacts
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha2) to this version.
+func (dst *CLIPlugin) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha2.CLIPlugin)
+	if !ok {
+		return errors.Errorf("cannot convert %T to CLIPlugin", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = CLIPluginSpec{
+		Description:        src.Spec.Description,
+		RecommendedVersion: src.Spec.RecommendedVersion,
+		Optional:           src.Spec.Optional,
+		Target:             src.Spec.Target,
+	}
+	if src.Spec.Artifacts != nil {
+		dst.Spec.Artifacts = make(map[string]ArtifactList, len(src.Spec.Artifacts))
+		for version, artifacts := range src.Spec.Artifacts {
+			dstArtifacts := make(ArtifactList, 0, len(artifacts))
+			for _, a := range artifacts {
+				dstArtifacts = append(dstArtifacts, Artifact(a))
+			}
+			dst.Spec.Artifacts[version] = dstArtifacts
+		}
+	}
+
+	// Keep the fields v1alpha1 does not have in an annotation
+	v1alpha2Only := v1alpha2OnlySpec{
+		Deprecated:         src.Spec.Deprecated,
+		DeprecationMessage: src.Spec.DeprecationMessage,
+		ReplacedBy:         src.Spec.ReplacedBy,
+		MinCLIVersion:      src.Spec.MinCLIVersion,
+		ReleaseNotes:       src.Spec.ReleaseNotes,
+	}
+	if !v1alpha2Only.isEmpty() {
+		b, err := json.Marshal(v1alpha2Only)
+		if err != nil {
+			return err
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string)
+		}
+		dst.Annotations[V1alpha2SpecAnnotation] = string(b)
+	}
+	return nil
+}
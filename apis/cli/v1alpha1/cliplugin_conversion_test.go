@@ -0,0 +1,55 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
+)
+
+func TestCLIPluginConversion(t *testing.T) {
+	assert := assert.New(t)
+
+	hub := &v1alpha2.CLIPlugin{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Annotations: map[string]string{"owner": "tkg"}},
+		Spec: v1alpha2.CLIPluginSpec{
+			Description:        "cluster plugin",
+			RecommendedVersion: "v1.1.0",
+			Target:             "kubernetes",
+			Artifacts: map[string]v1alpha2.ArtifactList{
+				"v1.0.0": {{Image: "example.com/cluster:v1.0.0", OS: "linux", Arch: "amd64"}},
+				"v1.1.0": {{Image: "example.com/cluster:v1.1.0", OS: "linux", Arch: "amd64"}},
+			},
+			Deprecated:         true,
+			DeprecationMessage: "use the workload plugin instead",
+			ReplacedBy:         "workload",
+			MinCLIVersion:      "v1.2.0",
+			ReleaseNotes:       map[string]string{"v1.1.0": "Adds the upgrade command"},
+		},
+	}
+
+	var spoke CLIPlugin
+	assert.Nil(spoke.ConvertFrom(hub))
+	assert.Equal("cluster plugin", spoke.Spec.Description)
+	assert.Equal("example.com/cluster:v1.1.0", spoke.Spec.Artifacts["v1.1.0"][0].Image)
+	assert.Equal("tkg", spoke.Annotations["owner"])
+	assert.Contains(spoke.Annotations, V1alpha2SpecAnnotation)
+
+	var roundTrip v1alpha2.CLIPlugin
+	assert.Nil(spoke.ConvertTo(&roundTrip))
+	assert.Equal(hub, &roundTrip)
+
+	// A v1alpha1 CLIPlugin without the annotation has none of the v1alpha2 fields
+	spoke = CLIPlugin{ObjectMeta: metav1.ObjectMeta{Name: "login"}, Spec: CLIPluginSpec{Description: "login plugin"}}
+	assert.Nil(spoke.ConvertTo(&roundTrip))
+	assert.Equal(v1alpha2.CLIPluginSpec{Description: "login plugin"}, roundTrip.Spec)
+	assert.Nil(roundTrip.Annotations)
+
+	spoke.Annotations = map[string]string{V1alpha2SpecAnnotation: "invalid"}
+	assert.ErrorContains(spoke.ConvertTo(&roundTrip), "invalid cli.tanzu.vmware.com/v1alpha2-spec annotation")
+}
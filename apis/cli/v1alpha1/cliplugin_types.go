@@ -29,6 +29,9 @@ type Artifact struct {
 }
 
 // CLIPluginSpec defines the desired state of CLIPlugin.
+// v1alpha1 remains the storage version of the CLIPlugin API, which is served without a
+// conversion webhook, so the fields of a v1alpha2 CLIPlugin must not be pruned from it.
+// +kubebuilder:pruning:PreserveUnknownFields
 type CLIPluginSpec struct {
 	// Description is the plugin's description.
 	Description string `json:"description"`
@@ -49,6 +52,7 @@ type CLIPluginSpec struct {
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 
 // CLIPlugin denotes a Tanzu cli plugin.
 type CLIPlugin struct {
@@ -0,0 +1,7 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+// Hub marks v1alpha2 as the version the other versions of CLIPlugin are converted to and from.
+func (*CLIPlugin) Hub() {}
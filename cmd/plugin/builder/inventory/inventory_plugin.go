@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
@@ -34,6 +35,11 @@ type InventoryPluginUpdateOptions struct {
 	InventoryDBFile   string
 	DeactivatePlugins bool
 	ValidateOnly      bool
+	// DeprecationStatus is the deprecation status given to the plugin versions by
+	// UpdatePluginDeprecation. The plugin versions are no longer deprecated if it is empty.
+	DeprecationStatus plugininventory.PluginDeprecationStatus
+	// DeprecationMessage explains why the plugin versions are deprecated
+	DeprecationMessage string
 
 	ImageOperationsImpl carvelhelpers.ImageOperationsImpl
 }
@@ -67,6 +73,33 @@ func (ipuo *InventoryPluginUpdateOptions) UpdatePluginActivationState() error {
 	return ipuo.genericInventoryUpdater(activateDeactivateFunc)
 }
 
+// UpdatePluginDeprecation deprecates or undeprecates the plugin versions in the inventory database by
+// downloading the database from the repository, updating it locally and publishing the inventory database
+// as OCI image on the remote repository
+func (ipuo *InventoryPluginUpdateOptions) UpdatePluginDeprecation() error {
+	switch ipuo.DeprecationStatus {
+	case "", plugininventory.PluginDeprecationStatusDeprecated, plugininventory.PluginDeprecationStatusEndOfLife:
+	default:
+		return errors.Errorf("invalid deprecation status %q", ipuo.DeprecationStatus)
+	}
+
+	deprecateFunc := func(dbFile string, entry *plugininventory.PluginInventoryEntry) error {
+		if ipuo.DeprecationStatus != "" {
+			entry.Deprecations = make(map[string]plugininventory.PluginDeprecation, len(entry.Artifacts))
+			for version := range entry.Artifacts {
+				entry.Deprecations[version] = plugininventory.PluginDeprecation{Status: ipuo.DeprecationStatus, Message: ipuo.DeprecationMessage}
+			}
+		}
+		db := plugininventory.NewSQLiteInventory(dbFile, "")
+		err := db.UpdatePluginDeprecation(entry)
+		if err != nil {
+			return errors.Wrapf(err, "error while updating plugin '%s_%s'", entry.Name, entry.Target)
+		}
+		return nil
+	}
+	return ipuo.genericInventoryUpdater(deprecateFunc)
+}
+
 func (ipuo *InventoryPluginUpdateOptions) genericInventoryUpdater(inventoryUpdater func(string, *plugininventory.PluginInventoryEntry) error) error {
 	// Get inventory database file
 	dbFile, err := ipuo.getInventoryDBFile()
@@ -230,6 +263,16 @@ func (ipuo *InventoryPluginUpdateOptions) updatePluginInventoryEntry(pluginInven
 	_, exists = pluginInventoryEntry.Artifacts[version]
 	if !exists {
 		pluginInventoryEntry.Artifacts[version] = make([]distribution.Artifact, 0)
+		requirements, err := getPluginRequirements(plugin)
+		if err != nil {
+			return nil, err
+		}
+		if requirements != nil {
+			if pluginInventoryEntry.Requirements == nil {
+				pluginInventoryEntry.Requirements = make(map[string]plugininventory.PluginRequirements)
+			}
+			pluginInventoryEntry.Requirements[version] = *requirements
+		}
 	}
 
 	artifact := distribution.Artifact{
@@ -242,6 +285,35 @@ func (ipuo *InventoryPluginUpdateOptions) updatePluginInventoryEntry(pluginInven
 	return pluginInventoryEntry, nil
 }
 
+// getPluginRequirements returns the requirements specified in the manifest for the plugin, if any
+func getPluginRequirements(plugin cli.Plugin) (*plugininventory.PluginRequirements, error) {
+	if plugin.MinCLIVersion == "" && len(plugin.Dependencies) == 0 {
+		return nil, nil
+	}
+	if plugin.MinCLIVersion != "" {
+		if _, err := semver.NewVersion(plugin.MinCLIVersion); err != nil {
+			return nil, errors.Wrapf(err, "invalid minimum CLI version %q for plugin '%s_%s'", plugin.MinCLIVersion, plugin.Name, plugin.Target)
+		}
+	}
+	requirements := &plugininventory.PluginRequirements{MinCLIVersion: plugin.MinCLIVersion}
+	for _, dep := range plugin.Dependencies {
+		if dep.Name == "" || !configtypes.IsValidTarget(dep.Target, true, false) {
+			return nil, errors.Errorf("invalid dependency '%s_%s' for plugin '%s_%s'", dep.Name, dep.Target, plugin.Name, plugin.Target)
+		}
+		if dep.Version != "" {
+			if _, err := utils.ParseVersionConstraint(dep.Version); err != nil {
+				return nil, errors.Wrapf(err, "invalid version %q for the dependency '%s_%s' of plugin '%s_%s'", dep.Version, dep.Name, dep.Target, plugin.Name, plugin.Target)
+			}
+		}
+		requirements.Dependencies = append(requirements.Dependencies, plugininventory.PluginDependency{
+			Name:              dep.Name,
+			Target:            configtypes.StringToTarget(dep.Target),
+			VersionConstraint: dep.Version,
+		})
+	}
+	return requirements, nil
+}
+
 func (ipuo *InventoryPluginUpdateOptions) getPluginInventoryDBImagePath() string {
 	return fmt.Sprintf("%s/%s:%s", ipuo.Repository, helpers.PluginInventoryDBImageName, ipuo.InventoryImageTag)
 }
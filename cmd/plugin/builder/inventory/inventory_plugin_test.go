@@ -13,6 +13,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
@@ -230,6 +231,112 @@ var _ = Describe("Unit tests for inventory plugin add", func() {
 			Expect(pluginInventoryEntries[0].Artifacts["v0.0.2"]).NotTo(BeNil())
 		})
 	})
+
+	var _ = Context("tests for the inventory plugin UpdatePluginDeprecation function", func() {
+		AfterEach(func() {
+			iip.DeprecationStatus = ""
+			iip.DeprecationMessage = ""
+		})
+
+		var _ = It("when specified pluginInventoryEntry doesn't exist in database", func() {
+			fakeImgpkgWrapper.ResolveImageReturns(nil)
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStub)
+			fakeImgpkgWrapper.GetFileDigestFromImageReturns("fake-digest", nil)
+
+			iip.DeprecationStatus = plugininventory.PluginDeprecationStatusDeprecated
+			err := iip.UpdatePluginDeprecation()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error while updating plugin"))
+		})
+
+		var _ = It("when the deprecation status is invalid", func() {
+			iip.DeprecationStatus = "obsolete"
+			err := iip.UpdatePluginDeprecation()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid deprecation status "obsolete"`))
+		})
+
+		var _ = It("when all configuration are correct the plugin versions are deprecated and undeprecated", func() {
+			fakeImgpkgWrapper.ResolveImageReturns(nil)
+			fakeImgpkgWrapper.PushImageReturns(nil)
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(pullDBImageStubWithPlugins)
+			fakeImgpkgWrapper.GetFileDigestFromImageReturns("fake-digest", nil)
+
+			iip.DeprecationStatus = plugininventory.PluginDeprecationStatusEndOfLife
+			iip.DeprecationMessage = "This version is no longer supported"
+			err := iip.UpdatePluginDeprecation()
+			Expect(err).NotTo(HaveOccurred())
+
+			// verify that the local db file was updated before publishing the database to remote repository
+			db := plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			pluginInventoryEntries, err := db.GetAllPlugins()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(pluginInventoryEntries)).To(Equal(1))
+			Expect(pluginInventoryEntries[0].Deprecations).To(Equal(map[string]plugininventory.PluginDeprecation{
+				"v0.0.2": {Status: plugininventory.PluginDeprecationStatusEndOfLife, Message: "This version is no longer supported"},
+			}))
+
+			// Updating the same database file again removes the deprecation
+			fakeImgpkgWrapper.DownloadImageAndSaveFilesToDirCalls(func(_, path string) error {
+				dbFile := filepath.Join(path, plugininventory.SQliteDBFileName)
+				err := utils.CopyFile(referencedDBFile, dbFile)
+				referencedDBFile = dbFile
+				return err
+			})
+			iip.DeprecationStatus = ""
+			err = iip.UpdatePluginDeprecation()
+			Expect(err).NotTo(HaveOccurred())
+
+			db = plugininventory.NewSQLiteInventory(referencedDBFile, "")
+			pluginInventoryEntries, err = db.GetAllPlugins()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(pluginInventoryEntries)).To(Equal(1))
+			Expect(pluginInventoryEntries[0].Deprecations).To(BeNil())
+		})
+	})
+
+	var _ = Context("tests for the plugin requirements specified in the manifest", func() {
+		var _ = It("when the plugin has no requirements", func() {
+			requirements, err := getPluginRequirements(cli.Plugin{Name: "foo", Target: "global"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirements).To(BeNil())
+		})
+
+		var _ = It("when the plugin has a minimum CLI version and dependencies", func() {
+			requirements, err := getPluginRequirements(cli.Plugin{
+				Name:          "foo",
+				Target:        "global",
+				MinCLIVersion: "v1.2.0",
+				Dependencies: []cli.PluginDependency{
+					{Name: "bar", Target: "k8s", Version: ">=v0.1.0 <v0.3.0"},
+					{Name: "baz", Target: "global"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*requirements).To(Equal(plugininventory.PluginRequirements{
+				MinCLIVersion: "v1.2.0",
+				Dependencies: []plugininventory.PluginDependency{
+					{Name: "bar", Target: types.TargetK8s, VersionConstraint: ">=v0.1.0 <v0.3.0"},
+					{Name: "baz", Target: types.TargetGlobal},
+				},
+			}))
+		})
+
+		var _ = It("when the requirements are invalid", func() {
+			_, err := getPluginRequirements(cli.Plugin{Name: "foo", Target: "global", MinCLIVersion: "latest"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid minimum CLI version \"latest\" for plugin 'foo_global'"))
+
+			_, err = getPluginRequirements(cli.Plugin{Name: "foo", Target: "global", Dependencies: []cli.PluginDependency{{Name: "bar", Target: "unknown"}}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid dependency 'bar_unknown' for plugin 'foo_global'"))
+
+			_, err = getPluginRequirements(cli.Plugin{Name: "foo", Target: "global", Dependencies: []cli.PluginDependency{{Name: "bar", Target: "global", Version: ">>1"}}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid version \">>1\" for the dependency 'bar_global' of plugin 'foo_global'"))
+		})
+	})
 })
 
 func createTestManifestFile() (string, error) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/vmware-tanzu/tanzu-cli/cmd/plugin/builder/inventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 )
 
 // newInventoryPluginCmd creates a new command for plugin inventory operations.
@@ -21,6 +22,8 @@ func newInventoryPluginCmd() *cobra.Command {
 		newInventoryPluginAddCmd(),
 		newInventoryPluginActivateCmd(),
 		newInventoryPluginDeactivateCmd(),
+		newInventoryPluginDeprecateCmd(),
+		newInventoryPluginUndeprecateCmd(),
 	)
 
 	return inventoryPluginCmd
@@ -129,6 +132,56 @@ func newInventoryPluginDeactivateCmd() *cobra.Command { //nolint:dupl
 	return pluginDeactivateCmd
 }
 
+func newInventoryPluginDeprecateCmd() *cobra.Command {
+	var endOfLife bool
+	var message string
+	pluginDeprecateCmd, flags := getActivateDeactivateBaseCmd()
+	pluginDeprecateCmd.Use = "deprecate"
+	pluginDeprecateCmd.Short = "Deprecate the existing plugin versions in the inventory database available on the remote repository"
+	pluginDeprecateCmd.Example = ""
+	pluginDeprecateCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		status := plugininventory.PluginDeprecationStatusDeprecated
+		if endOfLife {
+			status = plugininventory.PluginDeprecationStatusEndOfLife
+		}
+		piOptions := inventory.InventoryPluginUpdateOptions{
+			Repository:          flags.Repository,
+			InventoryImageTag:   flags.InventoryImageTag,
+			ManifestFile:        flags.ManifestFile,
+			Vendor:              flags.Vendor,
+			Publisher:           flags.Publisher,
+			InventoryDBFile:     flags.InventoryDBFile,
+			DeprecationStatus:   status,
+			DeprecationMessage:  message,
+			ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
+		}
+		return piOptions.UpdatePluginDeprecation()
+	}
+	pluginDeprecateCmd.Flags().StringVarP(&message, "message", "", "", "message explaining why the plugin versions are deprecated")
+	pluginDeprecateCmd.Flags().BoolVarP(&endOfLife, "end-of-life", "", false, "mark the plugin versions as no longer supported instead of deprecated")
+	return pluginDeprecateCmd
+}
+
+func newInventoryPluginUndeprecateCmd() *cobra.Command {
+	pluginUndeprecateCmd, flags := getActivateDeactivateBaseCmd()
+	pluginUndeprecateCmd.Use = "undeprecate"
+	pluginUndeprecateCmd.Short = "Remove the deprecation of the existing plugin versions in the inventory database available on the remote repository"
+	pluginUndeprecateCmd.Example = ""
+	pluginUndeprecateCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		piOptions := inventory.InventoryPluginUpdateOptions{
+			Repository:          flags.Repository,
+			InventoryImageTag:   flags.InventoryImageTag,
+			ManifestFile:        flags.ManifestFile,
+			Vendor:              flags.Vendor,
+			Publisher:           flags.Publisher,
+			InventoryDBFile:     flags.InventoryDBFile,
+			ImageOperationsImpl: carvelhelpers.NewImageOperationsImpl(),
+		}
+		return piOptions.UpdatePluginDeprecation()
+	}
+	return pluginUndeprecateCmd
+}
+
 func getActivateDeactivateBaseCmd() (*cobra.Command, *inventoryPluginActivateDeactivateFlags) {
 	var flags = &inventoryPluginActivateDeactivateFlags{}
 
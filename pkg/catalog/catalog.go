@@ -23,6 +23,9 @@ import (
 const (
 	// catalogCacheFileName is the name of the file which holds Catalog cache
 	catalogCacheFileName = "catalog.yaml"
+
+	// maxInstallHistory is the number of installations kept in the history of each plugin
+	maxInstallHistory = 5
 )
 
 var (
@@ -80,6 +83,16 @@ func newContextCatalog(context string, lockCatalog bool) (*ContextCatalog, error
 
 // Upsert inserts/updates the given plugin.
 func (c *ContextCatalog) Upsert(plugin *cli.PluginInfo) error {
+	return c.upsert(plugin, true)
+}
+
+// UpsertWithoutHistory inserts/updates the given plugin without recording
+// it as the latest installation of the plugin.
+func (c *ContextCatalog) UpsertWithoutHistory(plugin *cli.PluginInfo) error {
+	return c.upsert(plugin, false)
+}
+
+func (c *ContextCatalog) upsert(plugin *cli.PluginInfo, recordHistory bool) error {
 	if c.lockedFile == nil {
 		return errors.Errorf("cannot complete the upsert plugin operation for plugin %q. catalog is not locked", plugin.Name)
 	}
@@ -92,6 +105,11 @@ func (c *ContextCatalog) Upsert(plugin *cli.PluginInfo) error {
 	if !utils.ContainsString(c.sharedCatalog.IndexByName[pluginNameTarget], plugin.InstallationPath) {
 		c.sharedCatalog.IndexByName[pluginNameTarget] = append(c.sharedCatalog.IndexByName[pluginNameTarget], plugin.InstallationPath)
 	}
+	if recordHistory {
+		c.sharedCatalog.addToInstallHistory(pluginNameTarget, plugin.InstallationPath)
+	}
+	// The default version of a plugin is not also installed side by side
+	c.sharedCatalog.removeSideBySideVersion(pluginNameTarget, plugin.Version)
 
 	// The "unknown" target was previously used in two scenarios:
 	// 1- to represent the global target (>= v0.28 and < v0.90)
@@ -127,6 +145,86 @@ func (c *ContextCatalog) Get(plugin string) (cli.PluginInfo, bool) {
 	return pd, true
 }
 
+// GetHistory returns the previously installed versions of a plugin given its name,
+// ordered from the most recent to the oldest installation.
+func (c *ContextCatalog) GetHistory(plugin string) []cli.PluginInfo {
+	paths, ok := c.sharedCatalog.InstallHistory[plugin]
+	if !ok {
+		// Catalogs written by older CLI versions do not have an installation history
+		// but the IndexByName index still references the previous installations.
+		paths = c.sharedCatalog.IndexByName[plugin]
+	}
+
+	pds := make([]cli.PluginInfo, 0, len(paths))
+	for i := len(paths) - 1; i >= 0; i-- {
+		if pd, exists := c.sharedCatalog.IndexByPath[paths[i]]; exists {
+			pds = append(pds, pd)
+		}
+	}
+	return pds
+}
+
+// GetSideBySideVersions returns the versions of a plugin given its name that are installed
+// alongside its default version, in the order they were installed.
+func (c *ContextCatalog) GetSideBySideVersions(plugin string) []cli.PluginInfo {
+	paths := c.sharedCatalog.SideBySideVersions[plugin]
+	pds := make([]cli.PluginInfo, 0, len(paths))
+	for _, path := range paths {
+		if pd, exists := c.sharedCatalog.IndexByPath[path]; exists {
+			pds = append(pds, pd)
+		}
+	}
+	return pds
+}
+
+// UpsertSideBySideVersion inserts/updates the given plugin as a version installed alongside
+// the default version of the plugin, which is not modified.
+func (c *ContextCatalog) UpsertSideBySideVersion(plugin *cli.PluginInfo) error {
+	if c.lockedFile == nil {
+		return errors.Errorf("cannot complete the upsert plugin operation for plugin %q. catalog is not locked", plugin.Name)
+	}
+
+	pluginNameTarget := PluginNameTarget(plugin.Name, plugin.Target)
+	c.sharedCatalog.IndexByPath[plugin.InstallationPath] = *plugin
+	if !utils.ContainsString(c.sharedCatalog.IndexByName[pluginNameTarget], plugin.InstallationPath) {
+		c.sharedCatalog.IndexByName[pluginNameTarget] = append(c.sharedCatalog.IndexByName[pluginNameTarget], plugin.InstallationPath)
+	}
+	c.sharedCatalog.removeSideBySideVersion(pluginNameTarget, plugin.Version)
+	c.sharedCatalog.SideBySideVersions[pluginNameTarget] = append(c.sharedCatalog.SideBySideVersions[pluginNameTarget], plugin.InstallationPath)
+	return saveCatalogCache(c.sharedCatalog, c.lockedFile)
+}
+
+// DeleteSideBySideVersion deletes the given version installed alongside the default version
+// of a plugin from the catalog, but it does not delete the installation.
+func (c *ContextCatalog) DeleteSideBySideVersion(plugin, version string) error {
+	if c.lockedFile == nil {
+		return errors.Errorf("cannot complete the delete plugin operation for plugin %q. catalog is not locked", plugin)
+	}
+	c.sharedCatalog.removeSideBySideVersion(plugin, version)
+	return saveCatalogCache(c.sharedCatalog, c.lockedFile)
+}
+
+// DeleteFromHistory deletes the given installations from the installation history of a plugin,
+// but it does not delete the installations.
+func (c *ContextCatalog) DeleteFromHistory(plugin string, installationPaths []string) error {
+	if c.lockedFile == nil {
+		return errors.Errorf("cannot complete the delete history operation for plugin %q. catalog is not locked", plugin)
+	}
+	history, ok := c.sharedCatalog.InstallHistory[plugin]
+	if !ok {
+		// Catalogs written by older CLI versions do not have an installation history
+		history = c.sharedCatalog.IndexByName[plugin]
+	}
+	remaining := make([]string, 0, len(history))
+	for _, path := range history {
+		if !utils.ContainsString(installationPaths, path) {
+			remaining = append(remaining, path)
+		}
+	}
+	c.sharedCatalog.InstallHistory[plugin] = remaining
+	return saveCatalogCache(c.sharedCatalog, c.lockedFile)
+}
+
 // List returns the list of active plugins.
 // Active plugin means the plugin that are available to the user
 // based on the current logged-in server.
@@ -149,6 +247,10 @@ func (c *ContextCatalog) Delete(plugin string) error {
 	if ok {
 		delete(c.plugins, plugin)
 	}
+	if _, isStandalone := c.sharedCatalog.StandAlonePlugins[plugin]; !isStandalone {
+		// The versions installed side by side are only kept for an installed plugin
+		delete(c.sharedCatalog.SideBySideVersions, plugin)
+	}
 	return saveCatalogCache(c.sharedCatalog, c.lockedFile)
 }
 
@@ -162,6 +264,36 @@ func (c *ContextCatalog) Unlock() {
 	}
 }
 
+// addToInstallHistory records the installation path as the latest installation of the plugin
+func (c *Catalog) addToInstallHistory(pluginNameTarget, installationPath string) {
+	history := make([]string, 0, maxInstallHistory)
+	for _, path := range c.InstallHistory[pluginNameTarget] {
+		if path != installationPath {
+			history = append(history, path)
+		}
+	}
+	history = append(history, installationPath)
+	if len(history) > maxInstallHistory {
+		history = history[len(history)-maxInstallHistory:]
+	}
+	c.InstallHistory[pluginNameTarget] = history
+}
+
+// removeSideBySideVersion removes the given version from the versions of the plugin installed side by side
+func (c *Catalog) removeSideBySideVersion(pluginNameTarget, version string) {
+	var remaining []string
+	for _, path := range c.SideBySideVersions[pluginNameTarget] {
+		if pd, exists := c.IndexByPath[path]; exists && pd.Version != version {
+			remaining = append(remaining, path)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(c.SideBySideVersions, pluginNameTarget)
+	} else {
+		c.SideBySideVersions[pluginNameTarget] = remaining
+	}
+}
+
 // getCatalogCacheDir returns the local directory in which tanzu state is stored.
 func getCatalogCacheDir() (path string) {
 	// NOTE: TEST_CUSTOM_CATALOG_CACHE_DIR is only for test purpose
@@ -175,10 +307,12 @@ func getCatalogCacheDir() (path string) {
 // newSharedCatalog creates an instance of the shared catalog file.
 func newSharedCatalog() (*Catalog, error) {
 	c := &Catalog{
-		IndexByPath:       map[string]cli.PluginInfo{},
-		IndexByName:       map[string][]string{},
-		StandAlonePlugins: map[string]string{},
-		ServerPlugins:     map[string]PluginAssociation{},
+		IndexByPath:        map[string]cli.PluginInfo{},
+		IndexByName:        map[string][]string{},
+		InstallHistory:     map[string][]string{},
+		SideBySideVersions: map[string][]string{},
+		StandAlonePlugins:  map[string]string{},
+		ServerPlugins:      map[string]PluginAssociation{},
 	}
 
 	err := ensureRoot()
@@ -218,6 +352,12 @@ func getCatalogCache(setWriteLock bool) (*Catalog, *lockedfile.File, error) {
 	if c.IndexByName == nil {
 		c.IndexByName = map[string][]string{}
 	}
+	if c.InstallHistory == nil {
+		c.InstallHistory = map[string][]string{}
+	}
+	if c.SideBySideVersions == nil {
+		c.SideBySideVersions = map[string][]string{}
+	}
 	if c.StandAlonePlugins == nil {
 		c.StandAlonePlugins = map[string]string{}
 	}
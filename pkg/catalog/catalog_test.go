@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 )
@@ -198,3 +200,259 @@ func Test_ContextCatalog_With_Context(t *testing.T) {
 	pd, exists = cc3.Get("fakeplugin1")
 	assert.False(exists)
 }
+
+func Test_ContextCatalog_GetHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+
+	cc, err := NewContextCatalogUpdater("")
+	assert.Nil(err)
+	defer cc.Unlock()
+
+	// No history for a plugin that was never installed
+	assert.Empty(cc.GetHistory("fakeplugin"))
+
+	for _, v := range []string{"1.0.0", "2.0.0", "3.0.0"} {
+		err = cc.Upsert(&cli.PluginInfo{
+			Name:             "fakeplugin",
+			InstallationPath: "/path/to/plugin/fakeplugin/" + v,
+			Version:          v,
+		})
+		assert.Nil(err)
+	}
+
+	history := cc.GetHistory("fakeplugin")
+	assert.Equal(3, len(history))
+	assert.Equal("3.0.0", history[0].Version)
+	assert.Equal("2.0.0", history[1].Version)
+	assert.Equal("1.0.0", history[2].Version)
+
+	// Re-installing an older version moves it to the top of the history
+	err = cc.Upsert(&cli.PluginInfo{
+		Name:             "fakeplugin",
+		InstallationPath: "/path/to/plugin/fakeplugin/1.0.0",
+		Version:          "1.0.0",
+	})
+	assert.Nil(err)
+
+	history = cc.GetHistory("fakeplugin")
+	assert.Equal(3, len(history))
+	assert.Equal("1.0.0", history[0].Version)
+	assert.Equal("3.0.0", history[1].Version)
+	assert.Equal("2.0.0", history[2].Version)
+
+	// The history is capped
+	for _, v := range []string{"4.0.0", "5.0.0", "6.0.0", "7.0.0"} {
+		err = cc.Upsert(&cli.PluginInfo{
+			Name:             "fakeplugin",
+			InstallationPath: "/path/to/plugin/fakeplugin/" + v,
+			Version:          v,
+		})
+		assert.Nil(err)
+	}
+	history = cc.GetHistory("fakeplugin")
+	assert.Equal(maxInstallHistory, len(history))
+	assert.Equal("7.0.0", history[0].Version)
+	assert.Equal("1.0.0", history[maxInstallHistory-1].Version)
+}
+
+func Test_ContextCatalog_UpsertWithoutHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+
+	cc, err := NewContextCatalogUpdater("")
+	assert.Nil(err)
+	defer cc.Unlock()
+	for _, v := range []string{"1.0.0", "2.0.0"} {
+		err = cc.Upsert(&cli.PluginInfo{
+			Name:             "fakeplugin",
+			InstallationPath: "/path/to/plugin/fakeplugin/" + v,
+			Version:          v,
+		})
+		assert.Nil(err)
+	}
+
+	err = cc.UpsertWithoutHistory(&cli.PluginInfo{
+		Name:             "fakeplugin",
+		InstallationPath: "/path/to/plugin/fakeplugin/1.0.0",
+		Version:          "1.0.0",
+	})
+	assert.Nil(err)
+
+	// The plugin is updated but the history is unchanged
+	pd, exists := cc.Get("fakeplugin")
+	assert.True(exists)
+	assert.Equal("1.0.0", pd.Version)
+	history := cc.GetHistory("fakeplugin")
+	assert.Equal(2, len(history))
+	assert.Equal("2.0.0", history[0].Version)
+	assert.Equal("1.0.0", history[1].Version)
+}
+
+func Test_ContextCatalog_DeleteFromHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+
+	cc, err := NewContextCatalogUpdater("")
+	assert.Nil(err)
+	for _, v := range []string{"1.0.0", "2.0.0", "3.0.0"} {
+		err = cc.Upsert(&cli.PluginInfo{
+			Name:             "fakeplugin",
+			InstallationPath: "/path/to/plugin/fakeplugin/" + v,
+			Version:          v,
+		})
+		assert.Nil(err)
+	}
+
+	err = cc.DeleteFromHistory("fakeplugin", []string{"/path/to/plugin/fakeplugin/3.0.0", "/path/to/plugin/fakeplugin/unknown"})
+	assert.Nil(err)
+	cc.Unlock()
+
+	// The change is persisted but the installation is still known
+	cc, err = NewContextCatalogUpdater("")
+	assert.Nil(err)
+	defer cc.Unlock()
+	history := cc.GetHistory("fakeplugin")
+	assert.Equal(2, len(history))
+	assert.Equal("2.0.0", history[0].Version)
+	assert.Equal("1.0.0", history[1].Version)
+	pd, exists := cc.Get("fakeplugin")
+	assert.True(exists)
+	assert.Equal("3.0.0", pd.Version)
+
+	// The catalog must be locked
+	cc.Unlock()
+	assert.NotNil(cc.DeleteFromHistory("fakeplugin", nil))
+}
+
+func Test_GetReferencedInstallationPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+
+	cc, err := NewContextCatalogUpdater("")
+	assert.Nil(err)
+	for _, v := range []string{"1.0.0", "2.0.0"} {
+		err = cc.Upsert(&cli.PluginInfo{
+			Name:             "fakeplugin",
+			InstallationPath: "/path/to/plugin/fakeplugin/" + v,
+			Version:          v,
+		})
+		assert.Nil(err)
+	}
+	err = cc.Upsert(&cli.PluginInfo{
+		Name:             "uninstalled",
+		InstallationPath: "/path/to/plugin/uninstalled/1.0.0",
+		Version:          "1.0.0",
+	})
+	assert.Nil(err)
+	assert.Nil(cc.Delete("uninstalled"))
+	cc.Unlock()
+
+	cc, err = NewContextCatalogUpdater("server")
+	assert.Nil(err)
+	err = cc.Upsert(&cli.PluginInfo{
+		Name:             "serverplugin",
+		InstallationPath: "/path/to/plugin/serverplugin/1.0.0",
+		Version:          "1.0.0",
+	})
+	assert.Nil(err)
+	cc.Unlock()
+
+	// The previous installations of installed plugins are referenced but not uninstalled plugins
+	referenced, err := GetReferencedInstallationPaths()
+	assert.Nil(err)
+	assert.Equal(map[string]bool{
+		"/path/to/plugin/fakeplugin/1.0.0":   true,
+		"/path/to/plugin/fakeplugin/2.0.0":   true,
+		"/path/to/plugin/serverplugin/1.0.0": true,
+	}, referenced)
+
+	assert.Nil(RemoveInstallationPaths([]string{"/path/to/plugin/fakeplugin/1.0.0", "/path/to/plugin/uninstalled/1.0.0"}))
+
+	cc, err = NewContextCatalogUpdater("")
+	assert.Nil(err)
+	defer cc.Unlock()
+	history := cc.GetHistory("fakeplugin")
+	assert.Equal(1, len(history))
+	assert.Equal("2.0.0", history[0].Version)
+	assert.Empty(cc.GetHistory("uninstalled"))
+	pd, found := cc.Get("fakeplugin")
+	assert.True(found)
+	assert.Equal("2.0.0", pd.Version)
+}
+
+func Test_ContextCatalog_SideBySideVersions(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "test-catalog")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+	common.DefaultCacheDir = dir
+
+	newPlugin := func(version string) *cli.PluginInfo {
+		return &cli.PluginInfo{
+			Name:             "fakeplugin",
+			InstallationPath: "/path/to/plugin/fakeplugin/" + version,
+			Version:          version,
+			Target:           configtypes.TargetK8s,
+		}
+	}
+	pluginNameTarget := PluginNameTarget("fakeplugin", configtypes.TargetK8s)
+
+	cc, err := NewContextCatalogUpdater("")
+	assert.Nil(err)
+	assert.Nil(cc.Upsert(newPlugin("v2.0.0")))
+	assert.Nil(cc.UpsertSideBySideVersion(newPlugin("v1.0.0")))
+	assert.Nil(cc.UpsertSideBySideVersion(newPlugin("v1.5.0")))
+	cc.Unlock()
+
+	// The default version is not modified
+	cr, err := NewContextCatalog("")
+	assert.Nil(err)
+	pd, ok := cr.Get(pluginNameTarget)
+	assert.True(ok)
+	assert.Equal("v2.0.0", pd.Version)
+	assert.Len(cr.List(), 1)
+	versions := cr.GetSideBySideVersions(pluginNameTarget)
+	assert.Len(versions, 2)
+	assert.Equal("v1.0.0", versions[0].Version)
+	assert.Equal("v1.5.0", versions[1].Version)
+
+	referenced, err := GetReferencedInstallationPaths()
+	assert.Nil(err)
+	assert.True(referenced["/path/to/plugin/fakeplugin/v1.0.0"])
+	assert.True(referenced["/path/to/plugin/fakeplugin/v1.5.0"])
+
+	// A version that becomes the default version is no longer installed side by side
+	cc, err = NewContextCatalogUpdater("")
+	assert.Nil(err)
+	assert.Nil(cc.Upsert(newPlugin("v1.5.0")))
+	versions = cc.GetSideBySideVersions(pluginNameTarget)
+	assert.Len(versions, 1)
+	assert.Equal("v1.0.0", versions[0].Version)
+
+	assert.Nil(cc.DeleteSideBySideVersion(pluginNameTarget, "v1.0.0"))
+	assert.Empty(cc.GetSideBySideVersions(pluginNameTarget))
+
+	// Uninstalling the plugin removes the versions installed side by side
+	assert.Nil(cc.UpsertSideBySideVersion(newPlugin("v1.0.0")))
+	assert.Nil(cc.Delete(pluginNameTarget))
+	assert.Empty(cc.GetSideBySideVersions(pluginNameTarget))
+	cc.Unlock()
+}
@@ -43,6 +43,12 @@ type Catalog struct {
 	IndexByPath map[string]cli.PluginInfo `json:"indexByPath,omitempty" yaml:"indexByPath,omitempty"`
 	// IndeByName of all plugin installation paths by name.
 	IndexByName map[string][]string `json:"indexByName,omitempty" yaml:"indexByName,omitempty"`
+	// InstallHistory of the most recent plugin installation paths by name, ordered from
+	// the oldest to the latest installation.
+	InstallHistory map[string][]string `json:"installHistory,omitempty" yaml:"installHistory,omitempty"`
+	// SideBySideVersions of the stand-alone plugins by name: the installation paths of the versions
+	// installed alongside the default version of a plugin, which can be invoked explicitly.
+	SideBySideVersions map[string][]string `json:"sideBySideVersions,omitempty" yaml:"sideBySideVersions,omitempty"`
 	// StandAlonePlugins is a set of stand-alone plugin installations aggregated across all context types.
 	// Note: Shall be reduced to only those stand-alone plugins that are common to all context types.
 	StandAlonePlugins PluginAssociation `json:"standAlonePlugins,omitempty" yaml:"standAlonePlugins,omitempty"`
@@ -6,6 +6,8 @@ package catalog
 import (
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
 // DeleteIncorrectPluginEntriesFromCatalog deletes the old plugin entries associated with
@@ -71,3 +73,72 @@ func MigrateContextPluginsAsStandaloneIfNeeded() {
 	}
 	_ = saveCatalogCache(c, lockedFile)
 }
+
+// GetReferencedInstallationPaths returns the installation paths that are still in use:
+// the installed standalone and context-scoped plugins, the versions installed side by side
+// as well as the installations kept in the history of each plugin so that the plugin can be rolled back.
+func GetReferencedInstallationPaths() (map[string]bool, error) {
+	c, _, err := getCatalogCache(false)
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := map[string]bool{}
+	pluginAssociations := []PluginAssociation{c.StandAlonePlugins}
+	for _, spa := range c.ServerPlugins {
+		pluginAssociations = append(pluginAssociations, spa)
+	}
+	for i := range pluginAssociations {
+		for pluginNameTarget, path := range pluginAssociations[i] {
+			referenced[path] = true
+
+			history, ok := c.InstallHistory[pluginNameTarget]
+			if !ok {
+				// Catalogs written by older CLI versions do not have an installation history
+				// but the IndexByName index still references the previous installations.
+				history = c.IndexByName[pluginNameTarget]
+			}
+			for _, p := range history {
+				referenced[p] = true
+			}
+			for _, p := range c.SideBySideVersions[pluginNameTarget] {
+				referenced[p] = true
+			}
+		}
+	}
+	return referenced, nil
+}
+
+// RemoveInstallationPaths removes the given installation paths from the indexes of the catalog.
+// It is meant to be used once the corresponding plugin binaries have been removed.
+func RemoveInstallationPaths(paths []string) error {
+	c, lockedFile, err := getCatalogCache(true)
+	if err != nil {
+		return err
+	}
+	defer lockedFile.Close()
+
+	for _, path := range paths {
+		delete(c.IndexByPath, path)
+	}
+	removeFromIndex := func(index map[string][]string) {
+		for key, indexedPaths := range index {
+			var remaining []string
+			for _, p := range indexedPaths {
+				if !utils.ContainsString(paths, p) {
+					remaining = append(remaining, p)
+				}
+			}
+			if len(remaining) == 0 {
+				delete(index, key)
+			} else {
+				index[key] = remaining
+			}
+		}
+	}
+	removeFromIndex(c.IndexByName)
+	removeFromIndex(c.InstallHistory)
+	removeFromIndex(c.SideBySideVersions)
+
+	return saveCatalogCache(c, lockedFile)
+}
@@ -21,9 +21,25 @@ type PluginCatalogUpdater interface {
 	// Upsert inserts/updates the given plugin.
 	Upsert(plugin *cli.PluginInfo) error
 
+	// UpsertWithoutHistory inserts/updates the given plugin without recording
+	// it as the latest installation of the plugin.
+	UpsertWithoutHistory(plugin *cli.PluginInfo) error
+
 	// Delete deletes the given plugin from the catalog, but it does not delete the installation.
 	Delete(plugin string) error
 
+	// UpsertSideBySideVersion inserts/updates the given plugin as a version installed alongside
+	// the default version of the plugin, which is not modified.
+	UpsertSideBySideVersion(plugin *cli.PluginInfo) error
+
+	// DeleteSideBySideVersion deletes the given version installed alongside the default version
+	// of a plugin from the catalog, but it does not delete the installation.
+	DeleteSideBySideVersion(pluginName, version string) error
+
+	// DeleteFromHistory deletes the given installations from the installation history of a plugin,
+	// but it does not delete the installations.
+	DeleteFromHistory(pluginName string, installationPaths []string) error
+
 	// Unlock unlocks the catalog for other process to read/write
 	// After Unlock() is called, the ContextCatalog object can no longer be used,
 	// and a new one must be obtained for any further operation on the catalog
@@ -35,6 +51,14 @@ type PluginCatalogReader interface {
 	// Get looks up the info of a plugin given its name.
 	Get(pluginName string) (cli.PluginInfo, bool)
 
+	// GetHistory returns the previously installed versions of a plugin given its name,
+	// ordered from the most recent to the oldest installation.
+	GetHistory(pluginName string) []cli.PluginInfo
+
+	// GetSideBySideVersions returns the versions of a plugin given its name that are installed
+	// alongside its default version, in the order they were installed.
+	GetSideBySideVersions(pluginName string) []cli.PluginInfo
+
 	// List returns the list of active plugins.
 	// Active plugin means the plugin that are available to the user
 	// based on the current logged-in server.
@@ -25,8 +25,17 @@ type CentralConfig interface {
 // NewCentralConfigReader returns a CentralConfig reader that can
 // be used to read central configuration values.
 func NewCentralConfigReader(pd *types.PluginDiscovery) CentralConfig {
-	// The central config is stored in the cache
-	centralConfigFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, pd.OCI.Name, constants.CentralConfigFileName)
+	// The central config is stored in the cache.
+	// Only the OCI discovery sources provide a central config; for other
+	// sources, the file does not exist and no entry is found.
+	var discoveryName string
+	switch {
+	case pd.OCI != nil:
+		discoveryName = pd.OCI.Name
+	case pd.REST != nil:
+		discoveryName = pd.REST.Name
+	}
+	centralConfigFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, discoveryName, constants.CentralConfigFileName)
 
 	return &centralConfigYamlReader{configFile: centralConfigFile}
 }
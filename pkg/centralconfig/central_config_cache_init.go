@@ -11,6 +11,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/globalinit"
@@ -35,6 +36,10 @@ func init() {
 // triggerForInventoryCacheInvalidation returns true if the central_config.yaml file is missing
 // in the plugin inventory cache.
 func triggerForInventoryCacheInvalidation() bool {
+	if cliconfig.IsOfflineMode() {
+		// The cache cannot be refreshed without network access; it will be done once online
+		return false
+	}
 	sources, err := config.GetCLIDiscoverySources()
 	if err != nil {
 		// No discovery source
@@ -42,6 +47,10 @@ func triggerForInventoryCacheInvalidation() bool {
 	}
 
 	for _, source := range sources {
+		// Only the OCI discovery sources provide a central configuration
+		if source.OCI == nil {
+			continue
+		}
 		centralConfigFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, source.OCI.Name, constants.CentralConfigFileName)
 
 		if _, err := os.Stat(centralConfigFile); os.IsNotExist(err) {
@@ -64,6 +73,10 @@ func invalidateInventoryCache(_ io.Writer) error {
 
 	var errorList []error
 	for _, source := range sources {
+		// Only the OCI discovery sources provide a central configuration
+		if source.OCI == nil {
+			continue
+		}
 		centralConfigFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, source.OCI.Name, constants.CentralConfigFileName)
 
 		if _, err := os.Stat(centralConfigFile); os.IsNotExist(err) {
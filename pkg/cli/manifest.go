@@ -52,6 +52,25 @@ type Plugin struct {
 
 	// Versions available for plugin.
 	Versions []string `json:"versions" yaml:"versions"`
+
+	// MinCLIVersion is the minimum version of the Tanzu CLI the versions of the plugin work with.
+	MinCLIVersion string `json:"minCLIVersion,omitempty" yaml:"minCLIVersion,omitempty"`
+
+	// Dependencies are the other plugins the versions of the plugin need.
+	Dependencies []PluginDependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+}
+
+// PluginDependency is a plugin needed by another plugin.
+type PluginDependency struct {
+	// Name is the name of the plugin.
+	Name string `json:"name" yaml:"name"`
+
+	// Target is the target of the plugin.
+	Target string `json:"target" yaml:"target"`
+
+	// Version is the semver range the version of the plugin must satisfy, e.g. ">=v1.2.0".
+	// Any version of the plugin is accepted if empty.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
 }
 
 // PluginGroupManifest is used to parse metadata about Plugin Groups
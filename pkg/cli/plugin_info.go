@@ -68,9 +68,17 @@ type PluginInfo struct {
 	// Target specifies the target of the plugin
 	Target configtypes.Target `json:"target" yaml:"target"`
 
+	// VersionConstraint is the semver range, e.g., "~1.4", the plugin was installed with.
+	// Upgrades of the plugin stay within this range.
+	VersionConstraint string `json:"versionConstraint,omitempty" yaml:"versionConstraint,omitempty"`
+
 	// PostInstallHook is function to be run post install of a plugin.
 	PostInstallHook plugin.Hook `json:"-" yaml:"-"`
 
+	// SupportedHooks lists the lifecycle hooks, e.g., "pre-uninstall", "pre-upgrade" or "post-upgrade",
+	// that the plugin implements as commands and that the CLI should invoke.
+	SupportedHooks []string `json:"supportedHooks,omitempty" yaml:"supportedHooks,omitempty"`
+
 	// DefaultFeatureFlags is default featureflags to be configured if missing when invoking plugin
 	DefaultFeatureFlags map[string]bool `json:"defaultFeatureFlags" yaml:"defaultFeatureFlags"`
 
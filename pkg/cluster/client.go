@@ -12,8 +12,11 @@ import (
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
@@ -27,6 +30,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	cliv1alpha2 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 )
 
@@ -38,14 +42,18 @@ func init() {
 	_ = corev1.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
 	_ = cliv1alpha1.AddToScheme(scheme)
+	_ = cliv1alpha2.AddToScheme(scheme)
 }
 
 // Client provides various aspects of interaction with a Kubernetes cluster provisioned by TKG
 //
 //go:generate counterfeiter -o ../fakes/clusterclient_fake.go --fake-name ClusterClient . Client
 type Client interface {
-	// ListCLIPluginResources lists CLIPlugin resources across all namespaces
-	ListCLIPluginResources() ([]cliv1alpha1.CLIPlugin, error)
+	// ListCLIPluginResources lists CLIPlugin resources across all namespaces.
+	// v1alpha1 resources are converted to v1alpha2 if the cluster does not serve v1alpha2.
+	ListCLIPluginResources() ([]cliv1alpha2.CLIPlugin, error)
+	// ListCLIPluginResourcesWithOptions lists the CLIPlugin resources matching the options
+	ListCLIPluginResourcesWithOptions(options CLIPluginListOptions) ([]cliv1alpha2.CLIPlugin, error)
 	// VerifyCLIPluginCRD returns true if CRD exists else return false
 	VerifyCLIPluginCRD() (bool, error)
 	// GetCLIPluginImageRepositoryOverride returns map of image repository override
@@ -92,6 +100,8 @@ type client struct {
 	kubeConfigBytes []byte
 	kubeConfigPath  string
 	currentContext  string
+	// cliPluginVersion is the version of the CLIPlugin API served by the cluster, once known
+	cliPluginVersion schema.GroupVersion
 }
 
 // NewClient creates new clusterclient from kubeconfig file and poller
@@ -163,13 +173,104 @@ func (c *client) BuildClusterQuery() (*capdiscovery.ClusterQuery, error) {
 }
 
 // ListCLIPluginResources lists CLIPlugin resources across all namespaces
-func (c *client) ListCLIPluginResources() ([]cliv1alpha1.CLIPlugin, error) {
-	var cliPlugins cliv1alpha1.CLIPluginList
-	err := c.CrtClient.ListObjects(context.TODO(), &cliPlugins, &crtclient.ListOptions{Namespace: ""})
-	if err != nil {
+func (c *client) ListCLIPluginResources() ([]cliv1alpha2.CLIPlugin, error) {
+	return c.ListCLIPluginResourcesWithOptions(CLIPluginListOptions{})
+}
+
+// CLIPluginListOptions restricts the CLIPlugin resources listed by ListCLIPluginResourcesWithOptions
+type CLIPluginListOptions struct {
+	// Namespaces to list the CLIPlugin resources from. All namespaces are used if empty.
+	Namespaces []string
+	// LabelSelector the CLIPlugin resources must match, e.g., "env=prod"
+	LabelSelector string
+	// MetadataOnly only fetches the metadata of the CLIPlugin resources, leaving their spec empty.
+	// This is much cheaper than fetching the complete resources and is enough to detect changes
+	// through the resourceVersion of the resources.
+	MetadataOnly bool
+}
+
+// ListCLIPluginResourcesWithOptions lists the CLIPlugin resources matching the options
+func (c *client) ListCLIPluginResourcesWithOptions(options CLIPluginListOptions) ([]cliv1alpha2.CLIPlugin, error) {
+	var selector labels.Selector
+	if options.LabelSelector != "" {
+		var err error
+		if selector, err = labels.Parse(options.LabelSelector); err != nil {
+			return nil, errors.Wrapf(err, "invalid label selector %q", options.LabelSelector)
+		}
+	}
+
+	namespaces := options.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	var cliPlugins []cliv1alpha2.CLIPlugin
+	for _, namespace := range namespaces {
+		listOptions := &crtclient.ListOptions{Namespace: namespace, LabelSelector: selector}
+		items, err := c.listCLIPlugins(listOptions, options.MetadataOnly)
+		if err != nil {
+			return nil, err
+		}
+		cliPlugins = append(cliPlugins, items...)
+	}
+	return cliPlugins, nil
+}
+
+// listCLIPlugins lists the CLIPlugin resources using the latest version of the
+// CLIPlugin API served by the cluster
+func (c *client) listCLIPlugins(listOptions *crtclient.ListOptions, metadataOnly bool) ([]cliv1alpha2.CLIPlugin, error) {
+	if c.cliPluginVersion.Empty() {
+		cliPlugins, err := c.listCLIPluginsOfVersion(cliv1alpha2.GroupVersion, listOptions, metadataOnly)
+		if err == nil {
+			c.cliPluginVersion = cliv1alpha2.GroupVersion
+			return cliPlugins, nil
+		}
+		if !meta.IsNoMatchError(err) {
+			return nil, err
+		}
+		// Clusters with an older CLIPlugin CRD only serve v1alpha1
+		log.V(6).Infof("the cluster does not serve %s, using %s", cliv1alpha2.GroupVersion, cliv1alpha1.GroupVersion)
+		c.cliPluginVersion = cliv1alpha1.GroupVersion
+	}
+	return c.listCLIPluginsOfVersion(c.cliPluginVersion, listOptions, metadataOnly)
+}
+
+// listCLIPluginsOfVersion lists the CLIPlugin resources using the given version of the
+// CLIPlugin API and converts them to v1alpha2
+func (c *client) listCLIPluginsOfVersion(gv schema.GroupVersion, listOptions *crtclient.ListOptions, metadataOnly bool) ([]cliv1alpha2.CLIPlugin, error) {
+	var cliPlugins []cliv1alpha2.CLIPlugin
+	if metadataOnly {
+		metadataList := &metav1.PartialObjectMetadataList{}
+		metadataList.SetGroupVersionKind(gv.WithKind("CLIPluginList"))
+		if err := c.CrtClient.ListObjects(context.TODO(), metadataList, listOptions); err != nil {
+			return nil, err
+		}
+		for i := range metadataList.Items {
+			cliPlugins = append(cliPlugins, cliv1alpha2.CLIPlugin{ObjectMeta: metadataList.Items[i].ObjectMeta})
+		}
+		return cliPlugins, nil
+	}
+
+	if gv == cliv1alpha2.GroupVersion {
+		var cliPluginList cliv1alpha2.CLIPluginList
+		if err := c.CrtClient.ListObjects(context.TODO(), &cliPluginList, listOptions); err != nil {
+			return nil, err
+		}
+		return cliPluginList.Items, nil
+	}
+
+	var cliPluginList cliv1alpha1.CLIPluginList
+	if err := c.CrtClient.ListObjects(context.TODO(), &cliPluginList, listOptions); err != nil {
 		return nil, err
 	}
-	return cliPlugins.Items, nil
+	for i := range cliPluginList.Items {
+		var cliPlugin cliv1alpha2.CLIPlugin
+		if err := cliPluginList.Items[i].ConvertTo(&cliPlugin); err != nil {
+			return nil, err
+		}
+		cliPlugins = append(cliPlugins, cliPlugin)
+	}
+	return cliPlugins, nil
 }
 
 // GetCLIPluginImageRepositoryOverride returns map of image repository override
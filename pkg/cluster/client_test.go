@@ -5,15 +5,20 @@
 package cluster_test
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/client-go/discovery"
+	crtclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	cliv1alpha2 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
 	cluster "github.com/vmware-tanzu/tanzu-cli/pkg/cluster"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
 )
@@ -70,6 +75,86 @@ var _ = Describe("New Cluster Client Tests", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+		Context("when CLIPlugin resources are listed with options", func() {
+			BeforeEach(func() {
+				discoveryClientFactoryFake.NewDiscoveryClientForConfigReturns(&discovery.DiscoveryClient{}, nil)
+				discoveryClientFactoryFake.ServerVersionReturns(nil, nil)
+				clusterClient, _ = cluster.NewClient(kubeconfigFile, "foo-context", nil, options)
+				crtClientFake.ListObjectsCalls(func(_ context.Context, list crtclient.ObjectList, listOptions *crtclient.ListOptions) error {
+					meta := metav1.ObjectMeta{Name: "plugin-" + listOptions.Namespace, Namespace: listOptions.Namespace, ResourceVersion: "10"}
+					switch l := list.(type) {
+					case *cliv1alpha2.CLIPluginList:
+						l.Items = []cliv1alpha2.CLIPlugin{{ObjectMeta: meta, Spec: cliv1alpha2.CLIPluginSpec{Description: "full"}}}
+					case *metav1.PartialObjectMetadataList:
+						l.Items = []metav1.PartialObjectMetadata{{ObjectMeta: meta}}
+					}
+					return nil
+				})
+			})
+			It("lists the plugins of each namespace matching the label selector", func() {
+				plugins, err := clusterClient.ListCLIPluginResourcesWithOptions(cluster.CLIPluginListOptions{Namespaces: []string{"ns1", "ns2"}, LabelSelector: "env=prod"})
+				Expect(err).To(BeNil())
+				Expect(plugins).To(HaveLen(2))
+				Expect(plugins[0].Name).To(Equal("plugin-ns1"))
+				Expect(plugins[1].Name).To(Equal("plugin-ns2"))
+				Expect(plugins[1].Spec.Description).To(Equal("full"))
+				Expect(crtClientFake.ListObjectsCallCount()).To(Equal(2))
+				_, _, listOptions := crtClientFake.ListObjectsArgsForCall(0)
+				Expect(listOptions.LabelSelector.String()).To(Equal("env=prod"))
+			})
+			It("lists the metadata of the plugins of all namespaces", func() {
+				plugins, err := clusterClient.ListCLIPluginResourcesWithOptions(cluster.CLIPluginListOptions{MetadataOnly: true})
+				Expect(err).To(BeNil())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].ResourceVersion).To(Equal("10"))
+				Expect(plugins[0].Spec.Description).To(BeEmpty())
+				_, list, listOptions := crtClientFake.ListObjectsArgsForCall(0)
+				Expect(list.GetObjectKind().GroupVersionKind()).To(Equal(cliv1alpha2.GroupVersion.WithKind("CLIPluginList")))
+				Expect(listOptions.Namespace).To(BeEmpty())
+				Expect(listOptions.LabelSelector).To(BeNil())
+			})
+			It("returns an error for an invalid label selector", func() {
+				_, err := clusterClient.ListCLIPluginResourcesWithOptions(cluster.CLIPluginListOptions{LabelSelector: "env in prod"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid label selector"))
+			})
+		})
+		Context("when the cluster only serves the v1alpha1 CLIPlugin API", func() {
+			BeforeEach(func() {
+				discoveryClientFactoryFake.NewDiscoveryClientForConfigReturns(&discovery.DiscoveryClient{}, nil)
+				discoveryClientFactoryFake.ServerVersionReturns(nil, nil)
+				clusterClient, _ = cluster.NewClient(kubeconfigFile, "foo-context", nil, options)
+				crtClientFake.ListObjectsCalls(func(_ context.Context, list crtclient.ObjectList, _ *crtclient.ListOptions) error {
+					switch l := list.(type) {
+					case *cliv1alpha2.CLIPluginList:
+						return &apimeta.NoKindMatchError{GroupKind: cliv1alpha2.GroupVersion.WithKind("CLIPluginList").GroupKind(), SearchedVersions: []string{"v1alpha2"}}
+					case *cliv1alpha1.CLIPluginList:
+						l.Items = []cliv1alpha1.CLIPlugin{{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        "plugin1",
+								Annotations: map[string]string{cliv1alpha1.V1alpha2SpecAnnotation: `{"deprecated":true,"replacedBy":"plugin2"}`},
+							},
+							Spec: cliv1alpha1.CLIPluginSpec{Description: "plugin1 desc"},
+						}}
+					}
+					return nil
+				})
+			})
+			It("lists the v1alpha1 resources and converts them to v1alpha2", func() {
+				plugins, err := clusterClient.ListCLIPluginResources()
+				Expect(err).To(BeNil())
+				Expect(plugins).To(HaveLen(1))
+				Expect(plugins[0].Spec.Description).To(Equal("plugin1 desc"))
+				Expect(plugins[0].Spec.Deprecated).To(BeTrue())
+				Expect(plugins[0].Spec.ReplacedBy).To(Equal("plugin2"))
+				Expect(plugins[0].Annotations).To(BeEmpty())
+
+				// The served version is remembered
+				_, err = clusterClient.ListCLIPluginResources()
+				Expect(err).To(BeNil())
+				Expect(crtClientFake.ListObjectsCallCount()).To(Equal(3))
+			})
+		})
 		Context("when BuildClusterQuery() called", func() {
 			BeforeEach(func() {
 				discoveryClientFactoryFake.NewDiscoveryClientForConfigReturns(&discovery.DiscoveryClient{}, nil)
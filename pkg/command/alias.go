@@ -0,0 +1,247 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func newAliasCmd() *cobra.Command {
+	var aliasCmd = &cobra.Command{
+		Use:   "alias",
+		Short: "Manage command aliases",
+		Long: `Manage user-defined command aliases.
+An alias is a shortcut for a command of the CLI along with its arguments and flags.
+Any additional arguments given to the alias are appended to the command.`,
+		Annotations: map[string]string{
+			"group": string(plugin.SystemCmdGroup),
+		},
+	}
+	aliasCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	aliasCmd.AddCommand(
+		newSetAliasCmd(),
+		newListAliasCmd(),
+		newDeleteAliasCmd(),
+	)
+
+	return aliasCmd
+}
+
+func newSetAliasCmd() *cobra.Command {
+	var setCmd = &cobra.Command{
+		Use:   "set NAME COMMAND",
+		Short: "Create or update a command alias",
+		Long:  "Create or update a command alias. The command must be quoted if it contains spaces.",
+		Example: `
+    # Create an alias to list the clusters of all namespaces
+    tanzu alias set kcl "kubernetes cluster list --all-namespaces"
+
+    # Use the alias
+    tanzu kcl`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return activeHelpNoMoreArgs([]string{"You must provide a name for the alias"}), cobra.ShellCompDirectiveNoFileComp
+			}
+			if len(args) == 1 {
+				return cobra.AppendActiveHelp(nil, "You must provide the quoted command for the alias"), cobra.ShellCompDirectiveNoFileComp
+			}
+			return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, command := args[0], args[1]
+			if err := cliconfig.ValidateCommandAliasName(name); err != nil {
+				return err
+			}
+			if conflict := findConflictingCommand(cmd.Root(), name); conflict != nil {
+				return errors.Errorf("alias '%s' conflicts with the existing command '%s'", name, conflict.CommandPath())
+			}
+			if err := validateAliasCommand(cmd.Root(), name, command); err != nil {
+				return err
+			}
+			if err := cliconfig.SetCommandAlias(name, command); err != nil {
+				return err
+			}
+			log.Successf("Alias '%s' set to '%s'", name, command)
+			return nil
+		},
+	}
+	return setCmd
+}
+
+func newListAliasCmd() *cobra.Command {
+	var listCmd = &cobra.Command{
+		Use:               "list",
+		Short:             "List the command aliases",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			displayCommandAliases(cliconfig.GetCommandAliases(), cmd.OutOrStdout())
+			return nil
+		},
+	}
+
+	listCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(listCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return listCmd
+}
+
+func newDeleteAliasCmd() *cobra.Command {
+	var deleteCmd = &cobra.Command{
+		Use:               "delete NAME",
+		Short:             "Delete a command alias",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeCommandAliases,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cliconfig.DeleteCommandAlias(args[0]); err != nil {
+				return err
+			}
+			log.Successf("Alias '%s' deleted", args[0])
+			return nil
+		},
+	}
+	return deleteCmd
+}
+
+// displayCommandAliases displays the command aliases in the requested output format
+func displayCommandAliases(aliases []cliconfig.CommandAlias, writer io.Writer) {
+	output := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Command")
+	for i := range aliases {
+		output.AddRow(aliases[i].Name, aliases[i].Command)
+	}
+	output.Render()
+}
+
+// validateAliasCommand checks that the command of an alias starts with an existing
+// command of the CLI which is neither the alias itself nor another alias
+func validateAliasCommand(rootCmd *cobra.Command, name, command string) error {
+	args, err := cliconfig.SplitCommandLine(command)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("the command of an alias cannot be empty")
+	}
+	if args[0] == name {
+		return errors.Errorf("the command of alias '%s' cannot refer to the alias itself", name)
+	}
+	if cliconfig.GetCommandAlias(args[0]) != nil {
+		return errors.Errorf("the command of an alias cannot use the alias '%s'", args[0])
+	}
+	if findConflictingCommand(rootCmd, args[0]) == nil {
+		return errors.Errorf("unknown command '%s'", args[0])
+	}
+	return nil
+}
+
+// findConflictingCommand returns the top-level command, excluding aliases,
+// which name or one of its aliases is the given name, if any
+func findConflictingCommand(rootCmd *cobra.Command, name string) *cobra.Command {
+	for _, c := range rootCmd.Commands() {
+		if !isAliasCommand(c) && matchOnCommandNameAndAliases(c, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+func isAliasCommand(cmd *cobra.Command) bool {
+	t, exists := cmd.Annotations["type"]
+	return exists && t == common.CommandTypeAlias
+}
+
+// addAliasCommands adds a top-level command for each user-defined command alias
+// that does not conflict with an existing command or plugin.
+// The commands allow the aliases to be listed and completed by the shell, while
+// the aliases are expanded once, before the command line is parsed, by expandAliasArgs.
+func addAliasCommands(rootCmd *cobra.Command) {
+	var conflicts []string
+	for _, alias := range cliconfig.GetCommandAliases() {
+		if findConflictingCommand(rootCmd, alias.Name) != nil {
+			conflicts = append(conflicts, alias.Name)
+			continue
+		}
+
+		alias := alias
+		rootCmd.AddCommand(&cobra.Command{
+			Use:                alias.Name,
+			Short:              fmt.Sprintf("Alias for '%s'", alias.Command),
+			DisableFlagParsing: true,
+			Annotations: map[string]string{
+				"type":  common.CommandTypeAlias,
+				"group": common.AliasCmdGroup,
+			},
+			RunE: func(cmd *cobra.Command, args []string) error {
+				// The alias is only expanded when its name is the first argument of the command line
+				return errors.Errorf("alias '%s' must be the first argument of the command line", alias.Name)
+			},
+		})
+	}
+
+	if len(conflicts) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning, ignoring aliases %q because a command with that name already exists. Use 'tanzu alias delete' to remove them.\n", strings.Join(conflicts, ", "))
+	}
+}
+
+// expandAliasArgs replaces the name of a command alias with its command
+// in the arguments of the CLI, including when the shell requests completions.
+// The alias is expanded only once, so an error is returned if its command
+// refers to the alias itself or to another alias.
+func expandAliasArgs(rootCmd *cobra.Command, args []string) ([]string, error) {
+	pos := 0
+	if len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd) {
+		pos = 1
+	}
+	// Only expand the alias once the shell has completed its name
+	if len(args) <= pos || (pos == 1 && len(args) == pos+1) {
+		return args, nil
+	}
+
+	for _, c := range rootCmd.Commands() {
+		if !isAliasCommand(c) || c.Name() != args[pos] {
+			continue
+		}
+		alias := cliconfig.GetCommandAlias(c.Name())
+		if alias == nil {
+			return args, nil
+		}
+		aliasArgs := alias.Args()
+		if len(aliasArgs) > 0 && cliconfig.GetCommandAlias(aliasArgs[0]) != nil {
+			return nil, errors.Errorf("the command of alias '%s' refers to the alias '%s'. Use 'tanzu alias set %s' to change it", alias.Name, aliasArgs[0], alias.Name)
+		}
+		expanded := append([]string{}, args[:pos]...)
+		expanded = append(expanded, aliasArgs...)
+		return append(expanded, args[pos+1:]...), nil
+	}
+	return args, nil
+}
+
+func completeCommandAliases(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var comps []string
+	for _, alias := range cliconfig.GetCommandAliases() {
+		comps = append(comps, fmt.Sprintf("%s\t%s", alias.Name, alias.Command))
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
+)
+
+func TestAliasCommands(t *testing.T) {
+	env := setupTestCLIEnvironment(t)
+	defer tearDownTestCLIEnvironment(env)
+
+	assert := assert.New(t)
+
+	rootCmd, err := NewRootCmd()
+	assert.Nil(err)
+	rootCmd.SetArgs([]string{"alias", "set", "pl", "plugin list --output json"})
+	assert.Nil(rootCmd.Execute())
+
+	// An alias cannot mask a command or another alias
+	for _, args := range [][]string{
+		{"alias", "set", "plugin", "plugin list"},
+		{"alias", "set", "ctx", "unknown command"},
+		{"alias", "set", "pl2", "pl"},
+		{"alias", "set", "pl3", "pl3 --wide"},
+	} {
+		rootCmd, err = NewRootCmd()
+		assert.Nil(err)
+		rootCmd.SetArgs(args)
+		assert.NotNil(rootCmd.Execute(), args)
+	}
+	assert.Equal([]cliconfig.CommandAlias{{Name: "pl", Command: "plugin list --output json"}}, cliconfig.GetCommandAliases())
+
+	// The alias is expanded and the extra arguments are appended
+	rootCmd, err = NewRootCmd()
+	assert.Nil(err)
+	for _, tc := range []struct {
+		args     []string
+		expected []string
+	}{
+		{args: []string{"pl", "--wide"}, expected: []string{"plugin", "list", "--output", "json", "--wide"}},
+		{args: []string{"__complete", "pl", ""}, expected: []string{"__complete", "plugin", "list", "--output", "json", ""}},
+		// The alias name itself is being completed
+		{args: []string{"__complete", "pl"}, expected: []string{"__complete", "pl"}},
+		{args: []string{"plugin", "list"}, expected: []string{"plugin", "list"}},
+	} {
+		args, err := expandAliasArgs(rootCmd, tc.args)
+		assert.Nil(err)
+		assert.Equal(tc.expected, args)
+	}
+
+	out := new(bytes.Buffer)
+	rootCmd.SetOut(out)
+	args, err := expandAliasArgs(rootCmd, []string{"pl"})
+	assert.Nil(err)
+	rootCmd.SetArgs(args)
+	assert.Nil(rootCmd.Execute())
+	assert.Equal("[]", out.String())
+	outputFormat = ""
+
+	// An alias which is not expanded is not executed
+	rootCmd, err = NewRootCmd()
+	assert.Nil(err)
+	rootCmd.SetArgs([]string{"pl"})
+	err = rootCmd.Execute()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "alias 'pl' must be the first argument of the command line")
+
+	// Aliases referring to themselves or to another alias in the configuration are not expanded
+	assert.Nil(cliconfig.SetCommandAlias("loop", "loop --wide"))
+	assert.Nil(cliconfig.SetCommandAlias("chain", "pl --wide"))
+	rootCmd, err = NewRootCmd()
+	assert.Nil(err)
+	_, err = expandAliasArgs(rootCmd, []string{"loop"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "the command of alias 'loop' refers to the alias 'loop'")
+	_, err = expandAliasArgs(rootCmd, []string{"chain"})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "the command of alias 'chain' refers to the alias 'pl'")
+	assert.Nil(cliconfig.DeleteCommandAlias("loop"))
+	assert.Nil(cliconfig.DeleteCommandAlias("chain"))
+
+	// The alias is part of the top-level commands completed by the shell
+	rootCmd, err = NewRootCmd()
+	assert.Nil(err)
+	out.Reset()
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs([]string{"__complete", "p"})
+	assert.Nil(rootCmd.Execute())
+	assert.Contains(out.String(), "pl\tAlias for 'plugin list --output json'\n")
+
+	rootCmd, err = NewRootCmd()
+	assert.Nil(err)
+	out.Reset()
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs([]string{"__complete", "alias", "delete", ""})
+	assert.Nil(rootCmd.Execute())
+	assert.Contains(out.String(), "pl\tplugin list --output json\n:4\n")
+
+	rootCmd, err = NewRootCmd()
+	assert.Nil(err)
+	rootCmd.SetArgs([]string{"alias", "delete", "pl"})
+	assert.Nil(rootCmd.Execute())
+	assert.Empty(cliconfig.GetCommandAliases())
+}
@@ -22,7 +22,6 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/oauth2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clientauthv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
@@ -40,6 +39,7 @@ import (
 	kubecfg "github.com/vmware-tanzu/tanzu-cli/pkg/auth/utils/kubeconfig"
 	wcpauth "github.com/vmware-tanzu/tanzu-cli/pkg/auth/wcp"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
@@ -53,6 +53,11 @@ var (
 
 	projectStr, projectIDStr, spaceStr, clustergroupStr string
 	contextTypeStr                                      string
+
+	refreshContextPlugins  bool
+	discoveryNamespaces    []string
+	discoveryLabelSelector string
+	resetDiscoveryScope    bool
 )
 
 const (
@@ -273,7 +278,12 @@ func syncContextPlugins(cmd *cobra.Command, contextType configtypes.ContextType,
 		return nil
 	}
 
-	plugins, err := pluginmanager.DiscoverPluginsForContextType(contextType)
+	var discoveryOptions []discovery.DiscoveryOptions
+	if refreshContextPlugins {
+		// Ignore the cached plugins of the context
+		discoveryOptions = append(discoveryOptions, discovery.WithForceRefresh())
+	}
+	plugins, err := pluginmanager.DiscoverPluginsForContextType(contextType, discoveryOptions...)
 	if err != nil {
 		return err
 	}
@@ -288,10 +298,19 @@ func syncContextPlugins(cmd *cobra.Command, contextType configtypes.ContextType,
 
 	// sort the plugins based on the plugin name
 	sort.Sort(discovery.DiscoveredSorter(plugins))
+	logDeprecatedPlugins(plugins, ctxName)
 
 	pluginsNeedToBeInstalled := []discovery.Discovered{}
 	for idx := range plugins {
 		if plugins[idx].Status == common.PluginStatusNotInstalled || plugins[idx].Status == common.PluginStatusUpdateAvailable {
+			if pin := pluginmanager.GetPinnedPlugin(plugins[idx].Name, plugins[idx].Target); pin != nil {
+				pluginmanager.LogSkippedPinnedPlugin(pin)
+				continue
+			}
+			if err := pluginmanager.CheckMinCLIVersion(&plugins[idx]); err != nil {
+				log.Warningf("Skipping the installation of plugin '%s': %v", plugins[idx].Name, err)
+				continue
+			}
 			pluginsNeedToBeInstalled = append(pluginsNeedToBeInstalled, plugins[idx])
 		}
 	}
@@ -301,16 +320,24 @@ func syncContextPlugins(cmd *cobra.Command, contextType configtypes.ContextType,
 		return nil
 	}
 
-	errList := make([]error, 0)
 	log.Infof("Installing the following plugins recommended by context '%s':", ctxName)
-	displayToBeInstalledPluginsAsTable(plugins, cmd.ErrOrStderr())
+	displayToBeInstalledPluginsAsTable(pluginsNeedToBeInstalled, cmd.ErrOrStderr())
+	logReleaseNotes(pluginsNeedToBeInstalled)
+	requests := make([]pluginmanager.PluginInstallRequest, len(pluginsNeedToBeInstalled))
 	for i := range pluginsNeedToBeInstalled {
-		err = pluginmanager.InstallStandalonePlugin(pluginsNeedToBeInstalled[i].Name, pluginsNeedToBeInstalled[i].RecommendedVersion, pluginsNeedToBeInstalled[i].Target)
-		if err != nil {
-			errList = append(errList, err)
+		requests[i] = pluginmanager.PluginInstallRequest{
+			Name:    pluginsNeedToBeInstalled[i].Name,
+			Version: pluginsNeedToBeInstalled[i].RecommendedVersion,
+			Target:  pluginsNeedToBeInstalled[i].Target,
 		}
 	}
-	err = kerrors.NewAggregate(errList)
+	// Stay within the version constraints the plugins were installed with
+	requests = pluginmanager.ApplyVersionConstraints(requests)
+	results := pluginmanager.InstallStandalonePlugins(requests)
+	if len(results) > 0 {
+		pluginmanager.DisplayPluginInstallResults(results, cmd.ErrOrStderr())
+	}
+	err = pluginmanager.PluginInstallResultsError(results)
 	if err == nil {
 		log.Success("Successfully installed all recommended plugins.")
 	}
@@ -318,6 +345,32 @@ func syncContextPlugins(cmd *cobra.Command, contextType configtypes.ContextType,
 	return err
 }
 
+// logDeprecatedPlugins warns about the recommended plugins marked as deprecated by the context
+func logDeprecatedPlugins(plugins []discovery.Discovered, ctxName string) {
+	for idx := range plugins {
+		if !plugins[idx].Deprecated {
+			continue
+		}
+		msg := fmt.Sprintf("Plugin '%s' recommended by context '%s' is deprecated.", plugins[idx].Name, ctxName)
+		if plugins[idx].DeprecationMessage != "" {
+			msg += " " + plugins[idx].DeprecationMessage
+		}
+		if plugins[idx].ReplacedBy != "" {
+			msg += fmt.Sprintf(" Please use plugin '%s' instead.", plugins[idx].ReplacedBy)
+		}
+		log.Warning(msg)
+	}
+}
+
+// logReleaseNotes shows the release notes of the plugin versions about to be installed
+func logReleaseNotes(plugins []discovery.Discovered) {
+	for idx := range plugins {
+		if notes := plugins[idx].ReleaseNotes[plugins[idx].RecommendedVersion]; notes != "" {
+			log.Infof("Release notes of plugin '%s' version '%s':\n%s", plugins[idx].Name, plugins[idx].RecommendedVersion, notes)
+		}
+	}
+}
+
 // displayToBeInstalledPluginsAsTable takes a list of plugins and displays the plugin info as a table
 func displayToBeInstalledPluginsAsTable(plugins []discovery.Discovered, writer io.Writer) {
 	outputPlugins := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Target", "Current", "Installing")
@@ -1343,6 +1396,9 @@ func deleteCtx(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := cliconfig.DeleteKubernetesDiscoveryScope(name); err != nil {
+		log.Warningf("Failed to delete the plugin discovery scope of the context %q: %v", name, err)
+	}
 
 	deleteKubeconfigContext(ctx)
 	log.Successf("Successfully deleted context %q", name)
@@ -1383,6 +1439,7 @@ func newUseCtxCmd() *cobra.Command {
 		ValidArgsFunction: completeAllContexts,
 		RunE:              useCtx,
 	}
+	useCtxCmd.Flags().BoolVar(&refreshContextPlugins, "refresh", false, "ignore the cached plugins recommended by the context and discover them again")
 
 	return useCtxCmd
 }
@@ -1771,6 +1828,7 @@ func newUpdateCtxCmd() *cobra.Command {
 	}
 	updateCtxCmd.AddCommand(
 		newTanzuActiveResourceCmd(),
+		newPluginDiscoveryCtxCmd(),
 	)
 	return updateCtxCmd
 }
@@ -1795,6 +1853,78 @@ func newTanzuActiveResourceCmd() *cobra.Command {
 	return tanzuActiveResourceCmd
 }
 
+// newPluginDiscoveryCtxCmd sets the scope of the kubernetes plugin discovery of a context
+func newPluginDiscoveryCtxCmd() *cobra.Command {
+	pluginDiscoveryCmd := &cobra.Command{
+		Use:   "plugin-discovery CONTEXT_NAME",
+		Short: "Restrict the CLIPlugin resources used to discover the plugins recommended by a context",
+		Long: `Restrict the CLIPlugin resources used to discover the plugins recommended by a context.
+By default, the plugins recommended by a context are discovered from the CLIPlugin
+resources of all the namespaces of the cluster. Restricting the namespaces and labels
+of the CLIPlugin resources speeds up the discovery on clusters with many such resources.
+Without any flag, the current scope of the discovery is shown.`,
+		Example: `
+    # Only discover the plugins of the given namespaces
+    tanzu context update plugin-discovery mgmt-cluster --namespaces tanzu-cli-system,tkg-system
+
+    # Only discover the plugins matching a label selector
+    tanzu context update plugin-discovery mgmt-cluster --label-selector "env=prod,tier!=test"
+
+    # Discover the plugins of all the namespaces again
+    tanzu context update plugin-discovery mgmt-cluster --reset`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAllContexts,
+		RunE:              setCtxPluginDiscoveryScope,
+	}
+	pluginDiscoveryCmd.Flags().StringSliceVar(&discoveryNamespaces, "namespaces", nil, "namespaces in which to look for CLIPlugin resources")
+	pluginDiscoveryCmd.Flags().StringVar(&discoveryLabelSelector, "label-selector", "", "label selector the CLIPlugin resources must match")
+	pluginDiscoveryCmd.Flags().BoolVar(&resetDiscoveryScope, "reset", false, "discover the plugins from the CLIPlugin resources of all namespaces")
+	pluginDiscoveryCmd.MarkFlagsMutuallyExclusive("reset", "namespaces")
+	pluginDiscoveryCmd.MarkFlagsMutuallyExclusive("reset", "label-selector")
+
+	utils.PanicOnErr(pluginDiscoveryCmd.RegisterFlagCompletionFunc("namespaces", cobra.NoFileCompletions))
+	utils.PanicOnErr(pluginDiscoveryCmd.RegisterFlagCompletionFunc("label-selector", cobra.NoFileCompletions))
+
+	return pluginDiscoveryCmd
+}
+
+func setCtxPluginDiscoveryScope(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if _, err := config.GetContext(name); err != nil {
+		return err
+	}
+
+	if resetDiscoveryScope {
+		if err := cliconfig.DeleteKubernetesDiscoveryScope(name); err != nil {
+			return err
+		}
+		log.Successf("The plugins of context %q will be discovered from all namespaces", name)
+		return nil
+	}
+
+	if !cmd.Flags().Changed("namespaces") && !cmd.Flags().Changed("label-selector") {
+		scope := cliconfig.GetKubernetesDiscoveryScope(name)
+		if scope.IsEmpty() {
+			log.Infof("The plugins of context %q are discovered from all namespaces", name)
+			return nil
+		}
+		if len(scope.Namespaces) > 0 {
+			log.Infof("Namespaces: %s", strings.Join(scope.Namespaces, ","))
+		}
+		if scope.LabelSelector != "" {
+			log.Infof("Label selector: %s", scope.LabelSelector)
+		}
+		return nil
+	}
+
+	scope := &cliconfig.KubernetesDiscoveryScope{Namespaces: discoveryNamespaces, LabelSelector: discoveryLabelSelector}
+	if err := cliconfig.SetKubernetesDiscoveryScope(name, scope); err != nil {
+		return err
+	}
+	log.Successf("Updated the plugin discovery scope of context %q. Run 'tanzu plugin sync' to sync the plugins of the context", name)
+	return nil
+}
+
 func setTanzuCtxActiveResource(_ *cobra.Command, args []string) error {
 	name := args[0]
 
@@ -24,9 +24,12 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/auth/csp"
+	cliconfig "github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
 func TestCliCmdSuite(t *testing.T) {
@@ -1525,6 +1528,18 @@ func TestCompletionContext(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
+		{
+			test: "completion for the context update plugin-discovery command",
+			args: []string{"__complete", "context", "update", "plugin-discovery", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: expectedOutForAllCtxs + ":4\n",
+		},
+		{
+			test: "no completion for the --label-selector flag of the context update plugin-discovery command",
+			args: []string{"__complete", "context", "update", "plugin-discovery", "tkg1", "--label-selector", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: ":4\n",
+		},
 	}
 
 	// Setup a temporary configuration
@@ -1863,6 +1878,94 @@ func resetContextCommandFlags() {
 	contextTypeStr = ""
 	outputFormat = ""
 	shortCtx = false
+	refreshContextPlugins = false
+	discoveryNamespaces = nil
+	discoveryLabelSelector = ""
+	resetDiscoveryScope = false
+}
+
+func TestContextUpdatePluginDiscovery(t *testing.T) {
+	assert := assert.New(t)
+
+	configFile, err := os.CreateTemp("", "config")
+	assert.Nil(err)
+	t.Setenv("TANZU_CONFIG", configFile.Name())
+	configFileNG, err := os.CreateTemp("", "config_ng")
+	assert.Nil(err)
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", configFileNG.Name())
+	t.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(t.TempDir(), ".data-store.yaml"))
+	t.Setenv(constants.CEIPOptInUserPromptAnswer, "No")
+	t.Setenv(constants.EULAPromptAnswer, "Yes")
+	defer func() {
+		os.RemoveAll(configFile.Name())
+		os.RemoveAll(configFileNG.Name())
+	}()
+
+	ctx := &configtypes.Context{
+		Name:        "mgmt",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Path: "/home/user/.kube/config", Context: "mgmt-admin@mgmt"},
+	}
+	assert.Nil(config.SetContext(ctx, false))
+
+	runCmd := func(args ...string) error {
+		defer resetContextCommandFlags()
+		rootCmd, err := NewRootCmd()
+		assert.Nil(err)
+		rootCmd.SetArgs(append([]string{"context"}, args...))
+		return rootCmd.Execute()
+	}
+
+	err = runCmd("update", "plugin-discovery", "unknown", "--namespaces", "tkg-system")
+	assert.ErrorContains(err, "context unknown not found")
+
+	err = runCmd("update", "plugin-discovery", "mgmt", "--namespaces", "Invalid_Namespace")
+	assert.ErrorContains(err, `invalid namespace "Invalid_Namespace"`)
+	assert.Nil(cliconfig.GetKubernetesDiscoveryScope("mgmt"))
+
+	assert.Nil(runCmd("update", "plugin-discovery", "mgmt", "--namespaces", "tanzu-cli-system,tkg-system", "--label-selector", "env=prod"))
+	assert.Equal(&cliconfig.KubernetesDiscoveryScope{Namespaces: []string{"tanzu-cli-system", "tkg-system"}, LabelSelector: "env=prod"}, cliconfig.GetKubernetesDiscoveryScope("mgmt"))
+
+	// Showing the scope does not change it
+	assert.Nil(runCmd("update", "plugin-discovery", "mgmt"))
+	assert.Equal("env=prod", cliconfig.GetKubernetesDiscoveryScope("mgmt").LabelSelector)
+
+	err = runCmd("update", "plugin-discovery", "mgmt", "--reset", "--label-selector", "env=prod")
+	assert.ErrorContains(err, "none of the others can be")
+
+	assert.Nil(runCmd("update", "plugin-discovery", "mgmt", "--reset"))
+	assert.Nil(cliconfig.GetKubernetesDiscoveryScope("mgmt"))
+
+	// The scope is removed along with the context
+	assert.Nil(runCmd("update", "plugin-discovery", "mgmt", "--label-selector", "env=prod"))
+	assert.NotNil(cliconfig.GetKubernetesDiscoveryScope("mgmt"))
+	assert.Nil(runCmd("delete", "mgmt", "-y"))
+	assert.Nil(cliconfig.GetKubernetesDiscoveryScope("mgmt"))
+}
+
+func TestLogContextPluginNotices(t *testing.T) {
+	assert := assert.New(t)
+	b := bytes.NewBufferString("")
+	log.SetStdout(b)
+	log.SetStderr(b)
+	defer func() {
+		log.SetStdout(os.Stdout)
+		log.SetStderr(os.Stderr)
+	}()
+
+	plugins := []discovery.Discovered{
+		{Name: "cluster", RecommendedVersion: "v1.1.0", Deprecated: true, DeprecationMessage: "It is no longer maintained.", ReplacedBy: "workload"},
+		{Name: "feature", RecommendedVersion: "v1.1.0", ReleaseNotes: map[string]string{"v1.0.0": "First release", "v1.1.0": "Adds the list command"}},
+		{Name: "login", RecommendedVersion: "v1.0.0"},
+	}
+	logDeprecatedPlugins(plugins, "mgmt")
+	logReleaseNotes(plugins)
+
+	out := b.String()
+	assert.Contains(out, "Plugin 'cluster' recommended by context 'mgmt' is deprecated. It is no longer maintained. Please use plugin 'workload' instead.")
+	assert.Contains(out, "Release notes of plugin 'feature' version 'v1.1.0':\nAdds the list command")
+	assert.NotContains(out, "First release")
+	assert.NotContains(out, "login")
 }
 
 func TestMapTanzuEndpointToTMCEndpoint(t *testing.T) {
@@ -5,7 +5,10 @@ package command
 
 import (
 	"fmt"
+	"net/url"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -25,7 +28,22 @@ import (
 )
 
 var (
-	uri string
+	uri      string
+	priority int
+
+	publicKeys            []string
+	certificateIdentity   string
+	certificateOIDCIssuer string
+	certificateChain      string
+	skipTransparencyLog   bool
+)
+
+// signatureTrustFlags are the flags specifying how the signature of a discovery source is verified
+var signatureTrustFlags = []string{"public-key", "certificate-identity", "certificate-oidc-issuer", "certificate-chain", "skip-transparency-log"}
+
+const (
+	uriFlagDesc      = "URI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory"
+	priorityFlagDesc = "Priority of the discovery source. Discovery sources with a higher priority are searched first and take precedence when the same plugin is found in several discovery sources"
 )
 
 func newDiscoverySourceCmd() *cobra.Command {
@@ -38,6 +56,7 @@ func newDiscoverySourceCmd() *cobra.Command {
 
 	discoverySourceCmd.AddCommand(
 		newListDiscoverySourceCmd(),
+		newAddDiscoverySourceCmd(),
 		newUpdateDiscoverySourceCmd(),
 		newDeleteDiscoverySourceCmd(),
 		newInitDiscoverySourceCmd(),
@@ -52,17 +71,17 @@ func newListDiscoverySourceCmd() *cobra.Command {
 		Short:             "List available discovery sources",
 		ValidArgsFunction: noMoreCompletions,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "name", "image")
+			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "name", "image", "priority")
 			discoverySources, err := configlib.GetCLIDiscoverySources()
 			for _, ds := range discoverySources {
-				if ds.OCI != nil {
-					output.AddRow(ds.OCI.Name, ds.OCI.Image)
+				if name, uri := getDiscoverySourceNameAndURI(ds); name != "" {
+					output.AddRow(name, uri, config.GetDiscoverySourcePriority(name))
 				}
 			}
 			testPluginSources := pluginmanager.GetAdditionalTestPluginDiscoveries()
 			for _, ds := range testPluginSources {
 				if ds.OCI != nil {
-					output.AddRow(ds.OCI.Name+" (test only)", ds.OCI.Image)
+					output.AddRow(ds.OCI.Name+" (test only)", ds.OCI.Image, config.GetDiscoverySourcePriority(ds.OCI.Name))
 				}
 			}
 			output.Render()
@@ -76,6 +95,78 @@ func newListDiscoverySourceCmd() *cobra.Command {
 	return listDiscoverySourceCmd
 }
 
+func newAddDiscoverySourceCmd() *cobra.Command {
+	var addDiscoverySourceCmd = &cobra.Command{
+		Use:   "add SOURCE_NAME --uri <URI>",
+		Short: "Add a discovery source",
+		Long:  "Add a discovery source and refresh its plugin inventory local cache",
+		// We already include the only flag in the use text,
+		// we therefore don't show '[flags]' in the usage text.
+		DisableFlagsInUseLine: true,
+		Example: `
+    # Add a discovery source using an OCI image
+    tanzu plugin source add internal --uri registry.example.com/tanzu/plugin-inventory:latest
+
+    # Add a discovery source whose signature is verified using its own public key
+    tanzu plugin source add internal --uri registry.example.com/tanzu/plugin-inventory:latest --public-key /path/to/cosign.pub
+
+    # Add a discovery source using a plugin inventory served by a web server.
+    # The credentials to access the server can be configured using, e.g.,
+    # 'tanzu config set env.TANZU_CLI_DISCOVERY_SOURCE_INTERNAL_TOKEN <token>'
+    tanzu plugin source add internal --uri https://plugins.example.com/v1alpha1/cli/plugins`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAddDiscoverySource,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			discoveryName := args[0]
+
+			discoverySource, _ := configlib.GetCLIDiscoverySource(discoveryName)
+			if discoverySource != nil {
+				return fmt.Errorf("discovery %q already exists", discoveryName)
+			}
+
+			newDiscoverySource, err := createDiscoverySource(discoveryName, uri)
+			if err != nil {
+				return err
+			}
+			trust, err := getSignatureTrustFromFlags()
+			if err != nil {
+				return err
+			}
+
+			// Check the discovery source *before* we save it in the configuration
+			// file. This way, if the discovery source is invalid, we don't save it.
+			err = checkDiscoverySource(newDiscoverySource, discovery.WithSignatureTrust(trust))
+			if err != nil {
+				return err
+			}
+
+			err = configlib.SetCLIDiscoverySource(newDiscoverySource)
+			if err != nil {
+				return err
+			}
+			err = config.SetDiscoverySourcePriority(discoveryName, priority)
+			if err != nil {
+				return err
+			}
+			err = config.SetDiscoverySourceTrust(newDiscoverySource, trust)
+			if err != nil {
+				return err
+			}
+
+			log.Successf("added discovery source %s", discoveryName)
+			return nil
+		},
+	}
+
+	addDiscoverySourceCmd.Flags().StringVarP(&uri, "uri", "u", "", uriFlagDesc)
+	_ = addDiscoverySourceCmd.MarkFlagRequired("uri")
+	utils.PanicOnErr(addDiscoverySourceCmd.RegisterFlagCompletionFunc("uri", completeDiscoverySourceURI))
+	addDiscoverySourceCmd.Flags().IntVar(&priority, "priority", 0, priorityFlagDesc)
+	addSignatureTrustFlags(addDiscoverySourceCmd)
+
+	return addDiscoverySourceCmd
+}
+
 func newUpdateDiscoverySourceCmd() *cobra.Command {
 	var updateDiscoverySourceCmd = &cobra.Command{
 		Use:   "update SOURCE_NAME --uri <URI>",
@@ -85,8 +176,11 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 		// we therefore don't show '[flags]' in the usage text.
 		DisableFlagsInUseLine: true,
 		Example: `
-    # Update the discovery source for an air-gapped scenario. The URI must be an OCI image.
-    tanzu plugin source update default --uri registry.example.com/tanzu/plugin-inventory:latest`,
+    # Update the discovery source for an air-gapped scenario using an OCI image.
+    tanzu plugin source update default --uri registry.example.com/tanzu/plugin-inventory:latest
+
+    # Update the discovery source to use a plugin inventory served by a web server
+    tanzu plugin source update default --uri https://plugins.example.com/v1alpha1/cli/plugins`,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completeUpdateDiscoverySource,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -102,6 +196,25 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 				return err
 			}
 
+			// The signature verification configuration is only replaced when specified,
+			// in which case the inventory must be verified again even if it has not changed.
+			// Otherwise, the existing configuration is kept for the new URI.
+			var checkOptions []discovery.DiscoveryOptions
+			trust := config.GetDiscoverySourceTrust(*discoverySource)
+			if signatureTrustFlagsChanged(cmd) {
+				if trust, err = getSignatureTrustFromFlags(); err != nil {
+					return err
+				}
+				checkTrust := trust
+				if checkTrust == nil {
+					// The signature verification configuration is being removed
+					checkTrust = &config.DiscoverySourceTrust{}
+				}
+				checkOptions = append(checkOptions, discovery.WithSignatureTrust(checkTrust), discovery.WithForceInvalidation())
+			} else if trust != nil {
+				checkOptions = append(checkOptions, discovery.WithSignatureTrust(trust))
+			}
+
 			// Check the discovery source *before* we save it in the configuration
 			// file. This way, if the discovery source is invalid, we don't save it.
 			// NOTE: We cannot first save and then revert the change if the discovery
@@ -109,7 +222,7 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 			// will fail with a call to log.Fatal(), which will exit the program before
 			// we can revert the change; this happens when the discovery source is
 			// not properly signed.
-			err = checkDiscoverySource(newDiscoverySource)
+			err = checkDiscoverySource(newDiscoverySource, checkOptions...)
 			if err != nil {
 				return err
 			}
@@ -118,17 +231,27 @@ func newUpdateDiscoverySourceCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if cmd.Flags().Changed("priority") {
+				err = config.SetDiscoverySourcePriority(discoveryName, priority)
+				if err != nil {
+					return err
+				}
+			}
+			err = config.SetDiscoverySourceTrust(newDiscoverySource, trust)
+			if err != nil {
+				return err
+			}
 
 			log.Successf("updated discovery source %s", discoveryName)
 			return nil
 		},
 	}
 
-	updateDiscoverySourceCmd.Flags().StringVarP(&uri, "uri", "u", "", "URI for discovery source. The URI must be of an OCI image")
+	updateDiscoverySourceCmd.Flags().StringVarP(&uri, "uri", "u", "", uriFlagDesc)
 	_ = updateDiscoverySourceCmd.MarkFlagRequired("uri")
-	utils.PanicOnErr(updateDiscoverySourceCmd.RegisterFlagCompletionFunc("uri", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return cobra.AppendActiveHelp(nil, "Please enter the uri of the OCI image for plugin discovery"), cobra.ShellCompDirectiveNoFileComp
-	}))
+	utils.PanicOnErr(updateDiscoverySourceCmd.RegisterFlagCompletionFunc("uri", completeDiscoverySourceURI))
+	updateDiscoverySourceCmd.Flags().IntVar(&priority, "priority", 0, priorityFlagDesc)
+	addSignatureTrustFlags(updateDiscoverySourceCmd)
 
 	return updateDiscoverySourceCmd
 }
@@ -157,6 +280,12 @@ func newDeleteDiscoverySourceCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err = config.DeleteDiscoverySourcePriority(discoveryName); err != nil {
+				return err
+			}
+			if err = config.DeleteDiscoverySourceTrust(discoveryName); err != nil {
+				return err
+			}
 			log.Successf("deleted discovery source %s", discoveryName)
 			return nil
 		},
@@ -204,6 +333,30 @@ func createDiscoverySource(dsName, uri string) (configtypes.PluginDiscovery, err
 		return pluginDiscoverySource, errors.New("discovery source name cannot be empty")
 	}
 
+	// An HTTP(S) URL is the URL of a plugin inventory served by a REST API
+	if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		u, err := url.ParseRequestURI(uri)
+		if err != nil || u.Host == "" {
+			return pluginDiscoverySource, fmt.Errorf("invalid discovery source URL %q", uri)
+		}
+		if u.Scheme == "http" {
+			log.Warningf("The discovery source '%s' uses an insecure http URL. Its content can be tampered with and the credentials set with %s or %s are not sent to it",
+				dsName, discovery.RESTDiscoveryAuthVariable(dsName, "TOKEN"), discovery.RESTDiscoveryAuthVariable(dsName, "PASSWORD"))
+		}
+		// The query is kept as it can be required by the server, e.g., to select a version of the inventory
+		basePath := u.EscapedPath()
+		if u.RawQuery != "" {
+			basePath += "?" + u.RawQuery
+		}
+		pluginDiscoverySource = configtypes.PluginDiscovery{
+			REST: &configtypes.GenericRESTDiscovery{
+				Name:     dsName,
+				Endpoint: u.Scheme + "://" + u.Host,
+				BasePath: basePath,
+			}}
+		return pluginDiscoverySource, nil
+	}
+
 	pluginDiscoverySource = configtypes.PluginDiscovery{
 		OCI: &configtypes.OCIDiscovery{
 			Name:  dsName,
@@ -212,15 +365,86 @@ func createDiscoverySource(dsName, uri string) (configtypes.PluginDiscovery, err
 	return pluginDiscoverySource, nil
 }
 
+// getDiscoverySourceNameAndURI returns the name and the URI of the OCI and REST
+// discovery sources, or empty strings for other types of discovery sources
+func getDiscoverySourceNameAndURI(ds configtypes.PluginDiscovery) (string, string) {
+	switch {
+	case ds.OCI != nil:
+		return ds.OCI.Name, ds.OCI.Image
+	case ds.REST != nil:
+		return ds.REST.Name, discovery.RESTDiscoveryURL(ds.REST.Endpoint, ds.REST.BasePath)
+	}
+	return "", ""
+}
+
 // checkDiscoverySource attempts to access the content of the discovery to
 // confirm it is valid; this implies refreshing the DB.
-func checkDiscoverySource(source configtypes.PluginDiscovery) error {
+func checkDiscoverySource(source configtypes.PluginDiscovery, options ...discovery.DiscoveryOptions) error {
+	if config.IsOfflineMode() {
+		name, _ := getDiscoverySourceNameAndURI(source)
+		return discovery.NewOfflineError(fmt.Sprintf("refreshing the inventory of discovery source %q", name))
+	}
 	// If the URI has changed, the cache will be refreshed automatically.  However, if the URI has not changed,
 	// normally the TTL would be respected and the cache would not be refreshed.  However, we choose to pass
 	// the WithForceRefresh() option to ensure we refresh the DB no matter if the TTL has expired or not.
 	// This provides a way for the user to force a refresh of the DB by running "tanzu plugin source init/update"
 	// without waiting for the TTL to expire.
-	return discovery.RefreshDiscoveryDatabaseForSource(source, discovery.WithForceRefresh())
+	return discovery.RefreshDiscoveryDatabaseForSource(source, append([]discovery.DiscoveryOptions{discovery.WithForceRefresh()}, options...)...)
+}
+
+func addSignatureTrustFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&publicKeys, "public-key", nil, "Public key used to verify the signature of the discovery source. Can be specified multiple times to accept the signature of any of the keys")
+	cmd.Flags().StringVar(&certificateIdentity, "certificate-identity", "", "Identity expected in the certificate of a keyless signature of the discovery source")
+	cmd.Flags().StringVar(&certificateOIDCIssuer, "certificate-oidc-issuer", "", "OIDC issuer expected in the certificate of a keyless signature of the discovery source")
+	cmd.Flags().StringVar(&certificateChain, "certificate-chain", "", "Path to the PEM encoded root and intermediate CA certificates used to verify the certificate of a keyless signature of the discovery source")
+	cmd.Flags().BoolVar(&skipTransparencyLog, "skip-transparency-log", false, "Do not verify the keyless signature of the discovery source against the Rekor transparency log and the certificate transparency log, e.g., for a private certificate authority in an air-gapped environment")
+	cmd.MarkFlagsMutuallyExclusive("public-key", "certificate-identity")
+	cmd.MarkFlagsMutuallyExclusive("public-key", "certificate-chain")
+}
+
+func signatureTrustFlagsChanged(cmd *cobra.Command) bool {
+	for _, flag := range signatureTrustFlags {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// getSignatureTrustFromFlags returns the signature verification configuration specified
+// by the flags, or nil if none is specified
+func getSignatureTrustFromFlags() (*config.DiscoverySourceTrust, error) {
+	trust := &config.DiscoverySourceTrust{
+		PublicKeys:            publicKeys,
+		CertificateIdentity:   certificateIdentity,
+		CertificateOIDCIssuer: certificateOIDCIssuer,
+		CertificateChain:      certificateChain,
+		SkipTransparencyLog:   skipTransparencyLog,
+	}
+	if trust.IsEmpty() {
+		return nil, nil
+	}
+	if err := trust.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Local files are stored using their absolute path as the signature can be
+	// verified from any directory. Other key references, e.g., "k8s://", are kept as is.
+	for i := range trust.PublicKeys {
+		trust.PublicKeys[i] = absPathIfExists(trust.PublicKeys[i])
+	}
+	trust.CertificateChain = absPathIfExists(trust.CertificateChain)
+	return trust, nil
+}
+
+func absPathIfExists(p string) string {
+	if p == "" || !utils.PathExists(p) {
+		return p
+	}
+	if absPath, err := filepath.Abs(p); err == nil {
+		return absPath
+	}
+	return p
 }
 
 // ====================================
@@ -234,8 +458,8 @@ func completeDiscoverySources(_ *cobra.Command, args []string, _ string) ([]stri
 	var comps []string
 	discoverySources, _ := configlib.GetCLIDiscoverySources()
 	for _, ds := range discoverySources {
-		if ds.OCI != nil {
-			comps = append(comps, fmt.Sprintf("%s\t%s", ds.OCI.Name, ds.OCI.Image))
+		if name, uri := getDiscoverySourceNameAndURI(ds); name != "" {
+			comps = append(comps, fmt.Sprintf("%s\t%s", name, uri))
 		}
 	}
 	// Sort the completion to make testing easier
@@ -253,3 +477,18 @@ func completeUpdateDiscoverySource(cmd *cobra.Command, args []string, toComplete
 	// The user has provided enough information
 	return completeDiscoverySources(cmd, args, toComplete)
 }
+
+func completeAddDiscoverySource(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return cobra.AppendActiveHelp(nil, "Please enter the name of the new discovery source"), cobra.ShellCompDirectiveNoFileComp
+	}
+	if uri == "" {
+		// The --uri flag is required, so completion will be provided for it
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeDiscoverySourceURI(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return cobra.AppendActiveHelp(nil, "Please enter the uri of the OCI image or the HTTP(S) URL of the plugin inventory for plugin discovery"), cobra.ShellCompDirectiveNoFileComp
+}
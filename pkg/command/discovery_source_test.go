@@ -6,6 +6,8 @@ package command
 import (
 	"bytes"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,6 +19,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
@@ -44,6 +47,26 @@ func Test_createDiscoverySource(t *testing.T) {
 	assert.NotNil(pd.OCI)
 	assert.Equal(pd.OCI.Name, config.DefaultStandaloneDiscoveryName)
 	assert.Equal(pd.OCI.Image, constants.TanzuCLIDefaultCentralPluginDiscoveryImage)
+
+	// With an HTTP(S) URL
+	pd, err = createDiscoverySource("fake-rest-discovery-name", "https://plugins.example.com/v1alpha1/cli/plugins")
+	assert.Nil(err)
+	assert.Nil(pd.OCI)
+	assert.NotNil(pd.REST)
+	assert.Equal("fake-rest-discovery-name", pd.REST.Name)
+	assert.Equal("https://plugins.example.com", pd.REST.Endpoint)
+	assert.Equal("/v1alpha1/cli/plugins", pd.REST.BasePath)
+
+	// The query of the URL is kept
+	pd, err = createDiscoverySource("fake-rest-discovery-name", "https://plugins.example.com/inventory.json?channel=stable&token=a%2Fb")
+	assert.Nil(err)
+	assert.Equal("https://plugins.example.com", pd.REST.Endpoint)
+	assert.Equal("/inventory.json?channel=stable&token=a%2Fb", pd.REST.BasePath)
+	assert.Equal("https://plugins.example.com/inventory.json?channel=stable&token=a%2Fb", discovery.RESTDiscoveryURL(pd.REST.Endpoint, pd.REST.BasePath))
+
+	_, err = createDiscoverySource("fake-rest-discovery-name", "https://")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "invalid discovery source URL")
 }
 
 // test that checkDiscoverySource() will download the DB and digest file
@@ -170,6 +193,98 @@ func Test_createAndListDiscoverySources(t *testing.T) {
 	os.Unsetenv(constants.ConfigVariableAdditionalDiscoveryForTesting)
 }
 
+// Test_addAndListRESTDiscoverySources tests adding, updating and listing a discovery source
+// serving the plugin inventory through a REST API
+func Test_addAndListRESTDiscoverySources(t *testing.T) {
+	assert := assert.New(t)
+
+	defer setupPluginSourceForTesting(t)()
+	t.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(t.TempDir(), ".data-store.yaml"))
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1alpha1/cli/plugins" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"plugins":[],"groups":[]}`))
+	}))
+	defer s.Close()
+
+	runCmd := func(args ...string) (string, error) {
+		rootCmd, err := NewRootCmd()
+		assert.Nil(err)
+		rootCmd.SetArgs(args)
+		b := bytes.NewBufferString("")
+		rootCmd.SetOut(b)
+		rootCmd.SetErr(b)
+		log.SetStdout(b)
+		log.SetStderr(b)
+		err = rootCmd.Execute()
+		resetPluginCommandFlags()
+		return strings.Join(strings.Fields(b.String()), " "), err
+	}
+
+	out, err := runCmd("plugin", "source", "add", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins")
+	assert.Nil(err)
+	assert.Contains(out, "added discovery source internal")
+	// The test server uses http
+	assert.Contains(out, "The discovery source 'internal' uses an insecure http URL")
+
+	ds, err := configlib.GetCLIDiscoverySource("internal")
+	assert.Nil(err)
+	assert.NotNil(ds.REST)
+	assert.Equal(s.URL, ds.REST.Endpoint)
+	assert.Equal("/v1alpha1/cli/plugins", ds.REST.BasePath)
+
+	out, err = runCmd("plugin", "source", "list")
+	assert.Nil(err)
+	assert.Contains(out, "internal "+s.URL+"/v1alpha1/cli/plugins")
+
+	_, err = runCmd("plugin", "source", "add", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), `discovery "internal" already exists`)
+
+	// An invalid URL is not saved in the configuration
+	_, err = runCmd("plugin", "source", "update", "internal", "--uri", s.URL+"/invalid")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "API error, status code: 404")
+	ds, err = configlib.GetCLIDiscoverySource("internal")
+	assert.Nil(err)
+	assert.Equal("/v1alpha1/cli/plugins", ds.REST.BasePath)
+
+	// The priority is only changed when specified
+	_, err = runCmd("plugin", "source", "update", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins", "--priority", "10")
+	assert.Nil(err)
+	_, err = runCmd("plugin", "source", "update", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins")
+	assert.Nil(err)
+	out, err = runCmd("plugin", "source", "list")
+	assert.Nil(err)
+	assert.Contains(out, "internal "+s.URL+"/v1alpha1/cli/plugins 10")
+
+	// The signature verification configuration is validated and only changed when specified
+	_, err = runCmd("plugin", "source", "update", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins", "--certificate-identity", "release@example.com")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "must all be specified")
+	_, err = runCmd("plugin", "source", "update", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins", "--public-key", "k8s://tanzu/key", "--certificate-chain", "chain.pem")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "none of the others can be")
+	_, err = runCmd("plugin", "source", "update", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins", "--public-key", "k8s://tanzu/key1,k8s://tanzu/key2")
+	assert.Nil(err)
+	_, err = runCmd("plugin", "source", "update", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins")
+	assert.Nil(err)
+	ds, err = configlib.GetCLIDiscoverySource("internal")
+	assert.Nil(err)
+	assert.Equal(&config.DiscoverySourceTrust{PublicKeys: []string{"k8s://tanzu/key1", "k8s://tanzu/key2"}}, config.GetDiscoverySourceTrust(*ds))
+	_, err = runCmd("plugin", "source", "update", "internal", "--uri", s.URL+"/v1alpha1/cli/plugins", "--public-key", "k8s://tanzu/key", "--skip-transparency-log")
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "can only be skipped for the certificate of a keyless signature")
+
+	_, err = runCmd("plugin", "source", "delete", "internal")
+	assert.Nil(err)
+	assert.Empty(config.GetDiscoverySourcePriorities())
+	assert.Nil(config.GetDiscoverySourceTrust(*ds))
+}
+
 func Test_initDiscoverySources(t *testing.T) {
 	tests := []struct {
 		test            string
@@ -518,6 +633,31 @@ func TestCompletionPluginSource(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: expectedOutForOutputFlag + ":4\n",
 		},
+		// =======================
+		// tanzu plugin source add
+		// =======================
+		{
+			test: "completion for the source add command",
+			args: []string{"__complete", "plugin", "source", "add", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "--uri\tURI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory\n" +
+				"-u\tURI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory\n" +
+				"_activeHelp_ Please enter the name of the new discovery source\n:4\n",
+		},
+		{
+			test: "completion after the first arg of the source add command without --uri",
+			args: []string{"__complete", "plugin", "source", "add", "internal", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "--uri\tURI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory\n" +
+				"-u\tURI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory\n" +
+				":4\n",
+		},
+		{
+			test: "no completion after the first arg of the source add command with --uri",
+			args: []string{"__complete", "plugin", "source", "add", "internal", "--uri", "someURI", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
 		// ==========================
 		// tanzu plugin source update
 		// ==========================
@@ -525,8 +665,8 @@ func TestCompletionPluginSource(t *testing.T) {
 			test: "completion for the source update command",
 			args: []string{"__complete", "plugin", "source", "update", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
-			expected: "--uri\tURI for discovery source. The URI must be of an OCI image\n" +
-				"-u\tURI for discovery source. The URI must be of an OCI image\n" +
+			expected: "--uri\tURI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory\n" +
+				"-u\tURI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory\n" +
 				"default\texample.com/tanzu_cli/plugins/plugin-inventory:latest\n" +
 				":4\n",
 		},
@@ -534,8 +674,8 @@ func TestCompletionPluginSource(t *testing.T) {
 			test: "completion after the first arg of the source update command without --uri",
 			args: []string{"__complete", "plugin", "source", "update", "default", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
-			expected: "--uri\tURI for discovery source. The URI must be of an OCI image\n" +
-				"-u\tURI for discovery source. The URI must be of an OCI image\n" +
+			expected: "--uri\tURI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory\n" +
+				"-u\tURI for discovery source. The URI must be of an OCI image or the HTTP(S) URL of a plugin inventory\n" +
 				":4\n",
 		},
 		{
@@ -548,7 +688,7 @@ func TestCompletionPluginSource(t *testing.T) {
 			test: "completion of the --uri flag value for the source update command",
 			args: []string{"__complete", "plugin", "source", "update", "default", "--uri", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
-			expected: "_activeHelp_ Please enter the uri of the OCI image for plugin discovery\n:4\n",
+			expected: "_activeHelp_ Please enter the uri of the OCI image or the HTTP(S) URL of the plugin inventory for plugin discovery\n:4\n",
 		},
 		// ==========================
 		// tanzu plugin source delete
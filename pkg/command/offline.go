@@ -0,0 +1,44 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const offlineFlag = "--offline"
+
+// extractOfflineArg removes the --offline flag specified before the command from the arguments
+// and returns the remaining arguments along with whether the flag was found.
+// The flag is processed before the command tree is built so that it also applies to the
+// plugin commands, which do not parse the flags of the CLI.
+func extractOfflineArg(args []string) (remainingArgs []string, offline bool) {
+	pos := 0
+	if len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd) {
+		pos = 1
+	}
+	remainingArgs = append(remainingArgs, args[:pos]...)
+
+	for pos < len(args) {
+		switch {
+		case args[pos] == offlineFlag || args[pos] == offlineFlag+"=true":
+			offline = true
+			pos++
+		case args[pos] == offlineFlag+"=false":
+			pos++
+		case strings.HasPrefix(args[pos], pluginVersionFlag+"="):
+			// Other global flags specified before the command are kept
+			remainingArgs = append(remainingArgs, args[pos])
+			pos++
+		case args[pos] == pluginVersionFlag && pos+1 < len(args):
+			remainingArgs = append(remainingArgs, args[pos], args[pos+1])
+			pos += 2
+		default:
+			return append(remainingArgs, args[pos:]...), offline
+		}
+	}
+	return remainingArgs, offline
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractOfflineArg(t *testing.T) {
+	tests := []struct {
+		args            []string
+		expectedArgs    []string
+		expectedOffline bool
+	}{
+		{
+			args:         []string{"plugin", "search"},
+			expectedArgs: []string{"plugin", "search"},
+		},
+		{
+			args:            []string{"--offline", "cluster", "list"},
+			expectedArgs:    []string{"cluster", "list"},
+			expectedOffline: true,
+		},
+		{
+			args:            []string{"--plugin-version", "cluster=v1.2.0", "--offline=true", "cluster", "list"},
+			expectedArgs:    []string{"--plugin-version", "cluster=v1.2.0", "cluster", "list"},
+			expectedOffline: true,
+		},
+		{
+			args:         []string{"--offline=false", "plugin", "search"},
+			expectedArgs: []string{"plugin", "search"},
+		},
+		{
+			// Only the flag before the command is extracted
+			args:         []string{"plugin", "search", "--offline"},
+			expectedArgs: []string{"plugin", "search", "--offline"},
+		},
+		{
+			args:            []string{"__complete", "--offline", "plugin", ""},
+			expectedArgs:    []string{"__complete", "plugin", ""},
+			expectedOffline: true,
+		},
+	}
+
+	for _, tc := range tests {
+		args, offline := extractOfflineArg(tc.args)
+		assert.Equal(t, tc.expectedArgs, args)
+		assert.Equal(t, tc.expectedOffline, offline)
+	}
+}
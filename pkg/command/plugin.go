@@ -30,12 +30,21 @@ import (
 )
 
 var (
-	local        string
-	version      string
-	forceDelete  bool
-	outputFormat string
-	targetStr    string
-	group        string
+	local            string
+	version          string
+	forceDelete      bool
+	forceUninstall   bool
+	outputFormat     string
+	targetStr        string
+	group            string
+	lockFile         string
+	upgradeAll       bool
+	assumeYes        bool
+	sideBySide       bool
+	image            string
+	pluginURL        string
+	pluginSHA256     string
+	uninstallVersion string
 )
 
 const (
@@ -65,13 +74,22 @@ func newPluginCmd() *cobra.Command {
 		newListPluginCmd(),
 		newInstallPluginCmd(),
 		newUpgradePluginCmd(),
+		newRollbackPluginCmd(),
 		newDescribePluginCmd(),
 		newDeletePluginCmd(),
 		newCleanPluginCmd(),
 		newSyncPluginCmd(),
+		newExportPluginCmd(),
+		newOutdatedPluginCmd(),
+		newPinPluginCmd(),
+		newUnpinPluginCmd(),
+		newSetDefaultPluginCmd(),
+		newGCPluginCmd(),
+		newVerifyPluginCmd(),
 		newDiscoverySourceCmd(),
 		newSearchPluginCmd(),
 		newPluginGroupCmd(),
+		newPluginCacheCmd(),
 		newDownloadBundlePluginCmd(),
 		newUploadBundlePluginCmd(),
 	)
@@ -94,6 +112,17 @@ func newListPluginCmd() *cobra.Command {
 				log.Warningf("there was an error while getting installed plugins, error information: '%v'", err.Error())
 			}
 
+			// List the versions installed alongside the default version of the plugins
+			sideBySidePlugins, err := pluginsupplier.GetSideBySidePlugins()
+			if err != nil {
+				errorList = append(errorList, err)
+				log.Warningf("there was an error while getting the plugin versions installed side by side, error information: '%v'", err.Error())
+			}
+			for i := range sideBySidePlugins {
+				sideBySidePlugins[i].Status = common.PluginStatusSideBySide
+			}
+			installedPlugins = append(installedPlugins, sideBySidePlugins...)
+
 			// Get List of discovered Server Plugins
 			discoveredServerPlugins, err := pluginmanager.DiscoverServerPlugins()
 			if err != nil {
@@ -188,7 +217,22 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
     tanzu plugin install myPlugin --version v1.0
 
     # Install latest minor and patch version of v1 of plugin "myPlugin"
-    tanzu plugin install myPlugin --version v1`,
+    tanzu plugin install myPlugin --version v1
+
+    # Install the latest version of plugin "myPlugin" satisfying a semver range
+    # Later upgrades of the plugin stay within that range
+    tanzu plugin install myPlugin --version "~1.4"
+    tanzu plugin install myPlugin --version ">=1.2 <2.0"
+
+    # Install version v1.0.0 of plugin "myPlugin" alongside the installed version
+    # and invoke it using 'tanzu --plugin-version myPlugin=v1.0.0 myPlugin'
+    tanzu plugin install myPlugin --version v1.0.0 --side-by-side
+
+    # Install the plugin contained in an OCI image referenced by its digest
+    tanzu plugin install --image my-registry.example.com/plugins/myplugin@sha256:<DIGEST>
+
+    # Install the plugin binary available at a URL after verifying its SHA256 digest
+    tanzu plugin install --url https://example.com/plugins/myplugin --sha256 <DIGEST>`,
 		Args:              cobra.MaximumNArgs(1),
 		ValidArgsFunction: completeAllPluginsToInstall,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -203,6 +247,10 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
 				return installPluginsForPluginGroup(cmd, args)
 			}
 
+			if image != "" || pluginURL != "" {
+				return installAdHocPlugin(args)
+			}
+
 			// Invoke install plugin from local source if local files are provided
 			if local != "" {
 				if len(args) == 0 {
@@ -237,7 +285,11 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
 			}
 
 			pluginVersion := version
-			err = pluginmanager.InstallStandalonePlugin(pluginName, pluginVersion, getTarget())
+			if sideBySide {
+				err = pluginmanager.InstallStandalonePluginSideBySide(pluginName, pluginVersion, getTarget())
+			} else {
+				err = pluginmanager.InstallStandalonePlugin(pluginName, pluginVersion, getTarget())
+			}
 			if err != nil {
 				return err
 			}
@@ -257,23 +309,69 @@ func newInstallPluginCmd() *cobra.Command { //nolint:funlen
 	// The --local-source flag for installing plugins is only used in development testing
 	// and should not be used in production.  We mark it as hidden to help convey this reality.
 	// Shell completion for this flag is the default behavior of doing file completion
-	installPluginCmd.Flags().StringVarP(&local, "local-source", "l", "", "path to local plugin source")
+	installPluginCmd.Flags().StringVarP(&local, "local-source", "l", "", "path to local plugin source directory or plugin archive (.tar.gz, .tgz or .tar)")
 	utils.PanicOnErr(installPluginCmd.Flags().MarkHidden("local-source"))
 
-	installPluginCmd.Flags().StringVarP(&version, "version", "v", cli.VersionLatest, "version of the plugin")
+	installPluginCmd.Flags().StringVarP(&version, "version", "v", cli.VersionLatest, "version of the plugin or semver range of versions, e.g., ~1.4")
 	utils.PanicOnErr(installPluginCmd.RegisterFlagCompletionFunc("version", completePluginVersions))
 
 	installPluginCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
 	utils.PanicOnErr(installPluginCmd.RegisterFlagCompletionFunc("target", completeTargetsForAllPlugins))
 
+	installPluginCmd.Flags().BoolVar(&sideBySide, "side-by-side", false, "install the version of the plugin alongside the installed version, which remains the default version")
+
+	installPluginCmd.Flags().StringVar(&image, "image", "", "install the plugin contained in the OCI image referenced by its digest, e.g., REPOSITORY@sha256:DIGEST")
+	utils.PanicOnErr(installPluginCmd.RegisterFlagCompletionFunc("image", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return cobra.AppendActiveHelp(nil, "Please enter the URI of the plugin image, referenced by its digest"), cobra.ShellCompDirectiveNoFileComp
+	}))
+	installPluginCmd.Flags().StringVar(&pluginURL, "url", "", "install the plugin binary available at the URL, requires '--sha256'")
+	utils.PanicOnErr(installPluginCmd.RegisterFlagCompletionFunc("url", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return cobra.AppendActiveHelp(nil, "Please enter the URL of the plugin binary"), cobra.ShellCompDirectiveNoFileComp
+	}))
+	installPluginCmd.Flags().StringVar(&pluginSHA256, "sha256", "", "SHA256 digest of the plugin binary specified using '--url'")
+	utils.PanicOnErr(installPluginCmd.RegisterFlagCompletionFunc("sha256", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return cobra.AppendActiveHelp(nil, "Please enter the SHA256 digest of the plugin binary"), cobra.ShellCompDirectiveNoFileComp
+	}))
+
 	installPluginCmd.MarkFlagsMutuallyExclusive("group", "local")
 	installPluginCmd.MarkFlagsMutuallyExclusive("group", "local-source")
 	installPluginCmd.MarkFlagsMutuallyExclusive("group", "version")
 	installPluginCmd.MarkFlagsMutuallyExclusive("group", "target")
+	installPluginCmd.MarkFlagsMutuallyExclusive("group", "side-by-side")
+	installPluginCmd.MarkFlagsMutuallyExclusive("local-source", "side-by-side")
+	installPluginCmd.MarkFlagsRequiredTogether("url", "sha256")
+	for _, flag := range []string{"group", "local-source", "version", "side-by-side", "url"} {
+		installPluginCmd.MarkFlagsMutuallyExclusive("image", flag)
+	}
+	for _, flag := range []string{"group", "local-source", "version", "side-by-side"} {
+		installPluginCmd.MarkFlagsMutuallyExclusive("url", flag)
+	}
 
 	return installPluginCmd
 }
 
+// installAdHocPlugin installs the plugin specified using the '--image' or '--url' flags.
+// The plugin name is optional and, if specified, must match the name of the plugin binary.
+func installAdHocPlugin(args []string) error {
+	var pluginName string
+	if len(args) > 0 {
+		pluginName = args[0]
+	}
+
+	var plugin *cli.PluginInfo
+	var err error
+	if image != "" {
+		plugin, err = pluginmanager.InstallPluginFromImage(image, pluginName, getTarget())
+	} else {
+		plugin, err = pluginmanager.InstallPluginFromURL(pluginURL, pluginSHA256, pluginName, getTarget())
+	}
+	if err != nil {
+		return err
+	}
+	log.Successf("successfully installed '%s' plugin", plugin.Name)
+	return nil
+}
+
 func installPluginsForPluginGroup(cmd *cobra.Command, args []string) error {
 	var pluginName string
 	// We are installing from a group
@@ -310,20 +408,38 @@ func installPluginsForPluginGroup(cmd *cobra.Command, args []string) error {
 
 func newUpgradePluginCmd() *cobra.Command {
 	var upgradeCmd = &cobra.Command{
-		Use:               "upgrade " + pluginNameCaps,
-		Short:             "Upgrade a plugin",
-		Long:              "Installs the latest version available for the specified plugin",
+		Use:   "upgrade " + pluginNameCaps,
+		Short: "Upgrade a plugin",
+		Long: `Installs the latest version available for the specified plugin.
+When using the '--all' flag, every installed plugin for which a newer recommended version
+is available is upgraded, after confirming the upgrade plan.`,
+		Example: `
+    # Upgrade a plugin
+    tanzu plugin upgrade cluster --target k8s
+
+    # Upgrade all installed plugins after confirming the upgrade plan
+    tanzu plugin upgrade --all
+
+    # Upgrade all installed plugins without asking for confirmation
+    tanzu plugin upgrade --all --yes`,
 		ValidArgsFunction: completeAllPluginsToInstall,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if !configtypes.IsValidTarget(targetStr, true, true) {
+				return errors.New(invalidTargetMsg)
+			}
+
+			if upgradeAll {
+				if len(args) != 0 {
+					return fmt.Errorf("the plugin name cannot be specified when using the '--all' flag")
+				}
+				return upgradeAllPlugins(cmd)
+			}
+
 			if len(args) != 1 {
 				return fmt.Errorf("must provide plugin name as positional argument")
 			}
 			pluginName := args[0]
 
-			if !configtypes.IsValidTarget(targetStr, true, true) {
-				return errors.New(invalidTargetMsg)
-			}
-
 			// With the Central Repository feature we can simply request to install
 			// the recommendedVersion.
 			err = pluginmanager.UpgradePlugin(pluginName, cli.VersionLatest, getTarget())
@@ -337,10 +453,92 @@ func newUpgradePluginCmd() *cobra.Command {
 
 	upgradeCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
 	utils.PanicOnErr(upgradeCmd.RegisterFlagCompletionFunc("target", completeTargetsForAllPlugins))
+	upgradeCmd.Flags().BoolVarP(&upgradeAll, "all", "a", false, "upgrade all installed plugins for which a newer recommended version is available")
+	upgradeCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "upgrade the plugins without asking for confirmation")
 
 	return upgradeCmd
 }
 
+// upgradeAllPlugins upgrades all installed plugins (of the requested target)
+// to their recommended version once the upgrade plan is confirmed
+func upgradeAllPlugins(cmd *cobra.Command) error {
+	plan, err := pluginmanager.GetPluginUpgradePlan()
+	if err != nil {
+		return err
+	}
+
+	target := getTarget()
+	if target != configtypes.TargetUnknown {
+		var targetPlan []pluginmanager.PluginUpgrade
+		for i := range plan {
+			if plan[i].Target == target {
+				targetPlan = append(targetPlan, plan[i])
+			}
+		}
+		plan = targetPlan
+	}
+
+	if len(plan) == 0 {
+		log.Success("All installed plugins are up-to-date.")
+		return nil
+	}
+
+	log.Info("The following plugins will be upgraded:")
+	displayPluginUpgradePlan(plan, cmd.ErrOrStderr())
+	if !assumeYes {
+		if err := component.AskForConfirmation(fmt.Sprintf("%d plugin(s) will be upgraded. Are you sure?", len(plan))); err != nil {
+			return err
+		}
+	}
+
+	results := pluginmanager.UpgradePlugins(plan)
+	pluginmanager.DisplayPluginInstallResults(results, cmd.ErrOrStderr())
+	if err := pluginmanager.PluginInstallResultsError(results); err != nil {
+		return err
+	}
+	log.Successf("successfully upgraded %d plugin(s)", len(plan))
+	return nil
+}
+
+// displayPluginUpgradePlan displays the plugins to upgrade as a table
+func displayPluginUpgradePlan(plan []pluginmanager.PluginUpgrade, writer io.Writer) {
+	output := component.NewOutputWriterWithOptions(writer, "", []component.OutputWriterOption{}, "Name", "Target", "Current", "Upgrading To", "Source")
+	for i := range plan {
+		output.AddRow(plan[i].Name, plan[i].Target, plan[i].CurrentVersion, plan[i].TargetVersion, plan[i].Source)
+	}
+	output.Render()
+}
+
+func newRollbackPluginCmd() *cobra.Command {
+	var rollbackCmd = &cobra.Command{
+		Use:   "rollback " + pluginNameCaps,
+		Short: "Rollback a plugin to its previous version",
+		Long: `Restores the version of the specified plugin that was installed before the current one.
+The previous installation is reused if it is still available locally, otherwise it is installed again.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeInstalledPlugins,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			pluginName := args[0]
+
+			if !configtypes.IsValidTarget(targetStr, true, true) {
+				return errors.New(invalidTargetMsg)
+			}
+
+			restoredVersion, err := pluginmanager.RollbackPlugin(pluginName, getTarget())
+			if err != nil {
+				return err
+			}
+			log.Successf("successfully rolled back plugin '%s' to version '%s'", pluginName, restoredVersion)
+			return nil
+		},
+	}
+
+	rollbackCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
+	utils.PanicOnErr(rollbackCmd.RegisterFlagCompletionFunc("target", completeTargetsForInstalledPlugins))
+
+	return rollbackCmd
+}
+
 func newDeletePluginCmd() *cobra.Command {
 	var deleteCmd = &cobra.Command{
 		Use:               "uninstall " + pluginNameCaps,
@@ -365,10 +563,22 @@ func newDeletePluginCmd() *cobra.Command {
 				}
 			}
 
+			if uninstallVersion != "" {
+				if pluginName == cli.AllPlugins {
+					return fmt.Errorf("the '%s' argument cannot be used with the '--version' flag", cli.AllPlugins)
+				}
+				if err := pluginmanager.DeleteSideBySideVersion(pluginName, uninstallVersion, target); err != nil {
+					return err
+				}
+				log.Successf("successfully uninstalled version '%s' of plugin '%s'", uninstallVersion, pluginName)
+				return nil
+			}
+
 			deletePluginOptions := pluginmanager.DeletePluginOptions{
-				PluginName:  pluginName,
-				Target:      target,
-				ForceDelete: forceDelete,
+				PluginName:        pluginName,
+				Target:            target,
+				ForceDelete:       forceDelete,
+				IgnoreHookFailure: forceUninstall,
 			}
 
 			err = pluginmanager.DeletePlugin(deletePluginOptions)
@@ -386,6 +596,9 @@ func newDeletePluginCmd() *cobra.Command {
 	}
 
 	deleteCmd.Flags().BoolVarP(&forceDelete, "yes", "y", false, "uninstall the plugin without asking for confirmation")
+	deleteCmd.Flags().BoolVar(&forceUninstall, "force", false, "uninstall the plugin even if its pre-uninstall hook fails")
+
+	deleteCmd.Flags().StringVarP(&uninstallVersion, "version", "v", "", "uninstall only the specified version of the plugin installed side by side")
 
 	deleteCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
 	utils.PanicOnErr(deleteCmd.RegisterFlagCompletionFunc("target", completeTargetsForInstalledPlugins))
@@ -397,7 +610,7 @@ func newCleanPluginCmd() *cobra.Command {
 	var cleanCmd = &cobra.Command{
 		Use:               "clean",
 		Short:             "Clean the plugins",
-		Long:              "Remove all installed plugins from the system",
+		Long:              "Remove all installed plugins from the system. The pre-uninstall hook of the plugins is run, but a failing hook does not prevent its plugin from being removed. The cache of downloaded plugin binaries is kept, use 'tanzu plugin cache prune --all' to empty it",
 		ValidArgsFunction: noMoreCompletions,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			err = pluginmanager.Clean()
@@ -416,9 +629,30 @@ func newSyncPluginCmd() *cobra.Command {
 		Use:   "sync",
 		Short: "Installs all plugins recommended by the active contexts",
 		Long: `Installs all plugins recommended by the active contexts.
-Plugins installed with this command will only be available while the context remains active.`,
+Plugins installed with this command will only be available while the context remains active.
+
+When a lockfile generated by 'tanzu plugin export' is provided, the exact plugin versions
+recorded in the lockfile are installed instead.`,
+		Example: `
+    # Install all plugins recommended by the active contexts
+    tanzu plugin sync
+
+    # Install all plugins recommended by the active contexts, ignoring the cached recommendations
+    tanzu plugin sync --refresh
+
+    # Install the exact plugin versions recorded in a lockfile
+    tanzu plugin sync --lockfile plugins.lock.yaml`,
 		ValidArgsFunction: noMoreCompletions,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if lockFile != "" {
+				err = pluginmanager.InstallPluginsFromLockFile(lockFile)
+				if err != nil {
+					return err
+				}
+				log.Successf("successfully installed all plugins from lockfile '%s'", lockFile)
+				return nil
+			}
+
 			err = syncPlugins(cmd)
 			if err != nil {
 				return err
@@ -426,6 +660,12 @@ Plugins installed with this command will only be available while the context rem
 			return nil
 		},
 	}
+
+	// Shell completion for this flag is the default behavior of doing file completion
+	syncCmd.Flags().StringVar(&lockFile, "lockfile", "", "install the exact plugin versions recorded in the specified lockfile")
+	syncCmd.Flags().BoolVar(&refreshContextPlugins, "refresh", false, "ignore the cached plugins recommended by the active contexts and discover them again")
+	syncCmd.MarkFlagsMutuallyExclusive("lockfile", "refresh")
+
 	return syncCmd
 }
 
@@ -497,6 +737,17 @@ func displayInstalledPlugins(installedPlugins []cli.PluginInfo, recommendedConte
 	plugins := []pluginListInfo{}
 
 	for index := range installedPlugins {
+		if installedPlugins[index].Status == common.PluginStatusSideBySide {
+			plugins = append(plugins, pluginListInfo{
+				name:        installedPlugins[index].Name,
+				description: installedPlugins[index].Description,
+				target:      string(installedPlugins[index].Target),
+				installed:   installedPlugins[index].Version,
+				status:      common.PluginStatusSideBySide,
+				active:      pluginsupplier.IsPluginActive(&installedPlugins[index]),
+			})
+			continue
+		}
 		p := pluginListInfo{
 			name:        installedPlugins[index].Name,
 			description: installedPlugins[index].Description,
@@ -506,7 +757,10 @@ func displayInstalledPlugins(installedPlugins []cli.PluginInfo, recommendedConte
 			status:      common.PluginStatusInstalled,
 			active:      pluginsupplier.IsPluginActive(&installedPlugins[index]),
 		}
-		if p.recommended != "" && p.installed != p.recommended {
+		if pluginmanager.GetPinnedPlugin(installedPlugins[index].Name, installedPlugins[index].Target) != nil {
+			// A pinned plugin is not updated by a plugin sync
+			p.status = common.PluginStatusPinned
+		} else if p.recommended != "" && p.installed != p.recommended {
 			p.status = "update needed"
 			pluginSyncRequired = true
 		}
@@ -529,7 +783,8 @@ func displayInstalledPlugins(installedPlugins []cli.PluginInfo, recommendedConte
 		}
 	}
 
-	sort.Sort(pluginListInfoSorter(plugins))
+	// Keep the versions installed side by side after the default version of a plugin
+	sort.Stable(pluginListInfoSorter(plugins))
 
 	outputPluginWriter := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{})
 	if isTableOutputFormat() {
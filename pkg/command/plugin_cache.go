@@ -0,0 +1,147 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/downloadcache"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+var pruneAll bool
+
+func newPluginCacheCmd() *cobra.Command {
+	var pluginCacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the cache of downloaded plugin binaries",
+		Long: fmt.Sprintf(`Manage the cache of downloaded plugin binaries.
+Downloaded plugin binaries are cached by digest so that re-installing a plugin or switching
+between versions of a plugin does not require downloading the binary again. The least recently
+used binaries are removed when the cache grows larger than its limit, which can be set in
+megabytes using the %s environment variable.`, constants.PluginDownloadCacheMaxSize),
+	}
+	pluginCacheCmd.SetUsageFunc(cli.SubCmdUsageFunc)
+
+	pluginCacheCmd.AddCommand(
+		newListPluginCacheCmd(),
+		newPrunePluginCacheCmd(),
+		newSizePluginCacheCmd(),
+	)
+
+	return pluginCacheCmd
+}
+
+func newListPluginCacheCmd() *cobra.Command {
+	var listCmd = &cobra.Command{
+		Use:               "list",
+		Short:             "List the plugin binaries in the download cache",
+		Long:              "List the plugin binaries in the download cache, the most recently used first",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := downloadcache.List()
+			if err != nil {
+				return err
+			}
+
+			output := component.NewOutputWriterWithOptions(cmd.OutOrStdout(), outputFormat, []component.OutputWriterOption{}, "Name", "Target", "Version", "OS", "Arch", "Size", "Last Used", "Digest")
+			for i := range entries {
+				output.AddRow(entries[i].Name, entries[i].Target, entries[i].Version, entries[i].OS, entries[i].Arch,
+					formatSize(entries[i].Size), entries[i].LastUsed.Format("2006-01-02 15:04:05"), entries[i].Digest)
+			}
+			output.Render()
+			return nil
+		},
+	}
+
+	listCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(listCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return listCmd
+}
+
+func newPrunePluginCacheCmd() *cobra.Command {
+	var pruneCmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Remove plugin binaries from the download cache",
+		Long: `Remove the least recently used plugin binaries from the download cache until it is
+within its size limit, or remove all the plugin binaries from the download cache if --all is specified.`,
+		Example: `
+    # Remove the least recently used plugin binaries exceeding the size limit
+    tanzu plugin cache prune
+
+    # Empty the download cache
+    tanzu plugin cache prune --all`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			maxSize := downloadcache.MaxSize()
+			if pruneAll {
+				maxSize = 0
+			}
+			removed, err := downloadcache.Prune(maxSize)
+			if err != nil {
+				return err
+			}
+
+			var freed int64
+			for i := range removed {
+				freed += removed[i].Size
+			}
+			log.Successf("Removed %d plugin binaries (%s) from the download cache", len(removed), formatSize(freed))
+			return nil
+		},
+	}
+
+	pruneCmd.Flags().BoolVar(&pruneAll, "all", false, "remove all the plugin binaries from the download cache")
+
+	return pruneCmd
+}
+
+func newSizePluginCacheCmd() *cobra.Command {
+	var sizeCmd = &cobra.Command{
+		Use:               "size",
+		Short:             "Show the size of the download cache",
+		Long:              "Show the total size of the plugin binaries in the download cache and the size limit of the cache",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			size, err := downloadcache.Size()
+			if err != nil {
+				return err
+			}
+
+			limit := "disabled"
+			if downloadcache.IsEnabled() {
+				limit = formatSize(downloadcache.MaxSize())
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Size: %s\nLimit: %s\nLocation: %s\n", formatSize(size), limit, downloadcache.Dir())
+			return nil
+		},
+	}
+
+	return sizeCmd
+}
+
+// formatSize formats a number of bytes in a human readable form
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
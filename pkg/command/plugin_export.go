@@ -0,0 +1,54 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+var exportFile string
+
+func newExportPluginCmd() *cobra.Command {
+	var exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export the installed plugins to a lockfile",
+		Long: `Export the name, target, version, discovery source and digest of every installed
+plugin to a lockfile. The lockfile can then be used with 'tanzu plugin sync --lockfile'
+to install the exact same set of plugins on another machine.`,
+		Example: `
+    # Print the lockfile of the installed plugins
+    tanzu plugin export
+
+    # Save the lockfile of the installed plugins to a file
+    tanzu plugin export --file plugins.lock.yaml`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if exportFile == "" {
+				return pluginmanager.ExportPluginLockFile(cmd.OutOrStdout())
+			}
+
+			var b bytes.Buffer
+			if err := pluginmanager.ExportPluginLockFile(&b); err != nil {
+				return err
+			}
+			if err := utils.SaveFile(exportFile, b.Bytes()); err != nil {
+				return err
+			}
+			log.Successf("successfully exported the installed plugins to '%s'", exportFile)
+			return nil
+		},
+	}
+
+	// Shell completion for this flag is the default behavior of doing file completion
+	exportCmd.Flags().StringVarP(&exportFile, "file", "f", "", "file to write the lockfile to instead of the standard output")
+
+	return exportCmd
+}
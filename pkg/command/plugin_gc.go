@@ -0,0 +1,72 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+var gcDryRun bool
+
+func newGCPluginCmd() *cobra.Command {
+	var gcCmd = &cobra.Command{
+		Use:   "gc",
+		Short: "Remove plugin binaries that are no longer used",
+		Long: `Remove the plugin binaries and test plugin binaries that are no longer referenced
+by an installed plugin or by the installation history used by 'tanzu plugin rollback'.
+Such binaries are left behind when plugins are upgraded or uninstalled.`,
+		Example: `
+    # List the plugin binaries that would be removed
+    tanzu plugin gc --dry-run
+
+    # Remove the plugin binaries that are no longer used
+    tanzu plugin gc`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := pluginmanager.GarbageCollectPlugins(gcDryRun)
+
+			var size int64
+			for i := range files {
+				size += files[i].Size
+			}
+			if len(files) > 0 {
+				displayUnreferencedPluginFiles(files, cmd.OutOrStdout())
+			}
+
+			switch {
+			case gcDryRun:
+				log.Infof("%d unused plugin file(s) would be removed, reclaiming %s", len(files), formatSize(size))
+			case len(files) > 0:
+				log.Successf("Removed %d unused plugin file(s), reclaiming %s", len(files), formatSize(size))
+			case err == nil:
+				log.Success("There are no unused plugin files to remove")
+			}
+			return err
+		},
+	}
+
+	gcCmd.Flags().BoolVar(&gcDryRun, "dry-run", false, "list the unused plugin binaries without removing them")
+	gcCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(gcCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return gcCmd
+}
+
+// displayUnreferencedPluginFiles displays the unused plugin files in the requested output format
+func displayUnreferencedPluginFiles(files []pluginmanager.UnreferencedPluginFile, writer io.Writer) {
+	output := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Path", "Size")
+	for i := range files {
+		output.AddRow(files[i].Name, files[i].Path, formatSize(files[i].Size))
+	}
+	output.Render()
+}
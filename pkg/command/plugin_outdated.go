@@ -0,0 +1,74 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+var failIfOutdated bool
+
+func newOutdatedPluginCmd() *cobra.Command {
+	var outdatedCmd = &cobra.Command{
+		Use:   "outdated",
+		Short: "List installed plugins for which a newer version is available",
+		Long: `List the installed plugins for which a newer version is available, comparing the installed
+version with the version recommended by the discovery sources or the active contexts and with the
+latest supported version of the plugin.`,
+		Example: `
+    # List the outdated plugins
+    tanzu plugin outdated
+
+    # Fail if any installed plugin is outdated, e.g., in a CI pipeline
+    tanzu plugin outdated --exit-code -o json`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Report the outdated plugins found using the reachable discovery sources
+			outdatedPlugins, err := pluginmanager.GetOutdatedPlugins()
+			if err != nil {
+				log.Warningf(errorWhileDiscoveringPlugins, err.Error())
+			}
+
+			if len(outdatedPlugins) == 0 && err == nil && (outputFormat == "" || outputFormat == string(component.TableOutputType)) {
+				log.Success("All installed plugins are up-to-date.")
+				return nil
+			}
+			displayOutdatedPlugins(outdatedPlugins, cmd.OutOrStdout())
+
+			if !failIfOutdated {
+				// The discovery error was already reported as a warning
+				return nil
+			}
+			if len(outdatedPlugins) > 0 {
+				return fmt.Errorf("%d installed plugin(s) are outdated", len(outdatedPlugins))
+			}
+			return err
+		},
+	}
+
+	outdatedCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(outdatedCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+	outdatedCmd.Flags().BoolVar(&failIfOutdated, "exit-code", false, "exit with a non-zero status if any installed plugin is outdated or if the discovery sources could not be checked")
+
+	return outdatedCmd
+}
+
+// displayOutdatedPlugins displays the outdated plugins in the requested output format
+func displayOutdatedPlugins(plugins []pluginmanager.OutdatedPlugin, writer io.Writer) {
+	output := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Target", "Installed", "Recommended", "Latest", "Newer Major", "Context")
+	for i := range plugins {
+		output.AddRow(plugins[i].Name, plugins[i].Target, plugins[i].Installed, plugins[i].Recommended, plugins[i].Latest, plugins[i].NewerMajor, plugins[i].ContextName)
+	}
+	output.Render()
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestOutdatedPluginWithUnreachableDiscovery(t *testing.T) {
+	tests := []struct {
+		test            string
+		args            []string
+		expectedFailure bool
+	}{
+		{
+			test:            "without --exit-code",
+			args:            []string{"plugin", "outdated"},
+			expectedFailure: false,
+		},
+		{
+			test:            "with --exit-code",
+			args:            []string{"plugin", "outdated", "--exit-code"},
+			expectedFailure: true,
+		},
+	}
+
+	dir := t.TempDir()
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	t.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
+	t.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(dir, "data-store.yaml"))
+	t.Setenv("TANZU_CLI_CEIP_OPT_IN_PROMPT_ANSWER", "No")
+	t.Setenv("TANZU_CLI_EULA_PROMPT_ANSWER", "Yes")
+	// Without any cached plugin inventory, the discovery sources cannot be reached in offline mode
+	t.Setenv(constants.OfflineMode, "true")
+	defaultCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = filepath.Join(dir, "cache")
+	defer func() { common.DefaultCacheDir = defaultCacheDir }()
+
+	err := setupFakePlugin(dir, "foo", "v0.1.0", plugin.SystemCmdGroup, 0, configtypes.TargetK8s, 1, false, nil)
+	assert.Nil(t, err)
+	cc, err := catalog.NewContextCatalogUpdater("")
+	assert.Nil(t, err)
+	err = cc.Upsert(&cli.PluginInfo{
+		Name:             "foo",
+		Version:          "v0.1.0",
+		Target:           configtypes.TargetK8s,
+		InstallationPath: filepath.Join(dir, "foo_kubernetes"),
+		Status:           common.PluginStatusInstalled,
+	})
+	assert.Nil(t, err)
+	cc.Unlock()
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			stderr := &bytes.Buffer{}
+			log.SetStderr(stderr)
+			defer log.SetStderr(os.Stderr)
+
+			rootCmd, err := NewRootCmd()
+			assert.Nil(t, err)
+			rootCmd.SetArgs(spec.args)
+			rootCmd.SetOut(&bytes.Buffer{})
+
+			err = rootCmd.Execute()
+			assert.Equal(t, spec.expectedFailure, err != nil)
+			assert.Contains(t, stderr.String(), "there was an error while discovering plugins")
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func newPinPluginCmd() *cobra.Command {
+	var pinCmd = &cobra.Command{
+		Use:   "pin " + pluginNameCaps + "[@TARGET]",
+		Short: "Pin an installed plugin to its installed version",
+		Long: `Pin an installed plugin to its installed version.
+A pinned plugin is not upgraded by 'tanzu plugin upgrade', 'tanzu plugin sync',
+the creation of a context or the installation of a plugin group, and another version
+of it cannot be installed with 'tanzu plugin install', until it is unpinned or uninstalled.`,
+		Example: `
+    # Pin the installed version of the cluster plugin for kubernetes
+    tanzu plugin pin cluster@kubernetes
+
+    # Pin the installed version of the login plugin
+    tanzu plugin pin login`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeInstalledPlugins,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pluginName, target, err := parsePluginNameAndTarget(args[0])
+			if err != nil {
+				return err
+			}
+			pin, err := pluginmanager.PinPlugin(pluginName, target)
+			if err != nil {
+				return err
+			}
+			log.Successf("Plugin '%s' with target '%s' is pinned to version '%s'", pin.Name, pin.Target, pin.Version)
+			return nil
+		},
+	}
+
+	pinCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
+	utils.PanicOnErr(pinCmd.RegisterFlagCompletionFunc("target", completeTargetsForInstalledPlugins))
+
+	return pinCmd
+}
+
+func newUnpinPluginCmd() *cobra.Command {
+	var unpinCmd = &cobra.Command{
+		Use:   "unpin " + pluginNameCaps + "[@TARGET]",
+		Short: "Unpin a plugin to allow it to be upgraded",
+		Long:  "Remove the pin of a plugin so that it can be upgraded again",
+		Example: `
+    # Unpin the cluster plugin for kubernetes
+    tanzu plugin unpin cluster@kubernetes`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePinnedPlugins,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pluginName, target, err := parsePluginNameAndTarget(args[0])
+			if err != nil {
+				return err
+			}
+			pin, err := pluginmanager.UnpinPlugin(pluginName, target)
+			if err != nil {
+				return err
+			}
+			log.Successf("Plugin '%s' with target '%s' is no longer pinned", pin.Name, pin.Target)
+			return nil
+		},
+	}
+
+	unpinCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
+	utils.PanicOnErr(unpinCmd.RegisterFlagCompletionFunc("target", completeTargetsForInstalledPlugins))
+
+	return unpinCmd
+}
+
+// parsePluginNameAndTarget parses an argument of the form NAME[@TARGET].
+// If the argument does not specify a target, the value of the --target flag is used.
+func parsePluginNameAndTarget(arg string) (string, configtypes.Target, error) {
+	pluginName, target, found := strings.Cut(arg, "@")
+	if !found {
+		target = targetStr
+	}
+	if pluginName == "" {
+		return "", configtypes.TargetUnknown, fmt.Errorf("invalid plugin '%s', expected the format %s[@TARGET]", arg, pluginNameCaps)
+	}
+	if !configtypes.IsValidTarget(target, true, true) {
+		return "", configtypes.TargetUnknown, errors.New(invalidTargetMsg)
+	}
+	return pluginName, configtypes.StringToTarget(strings.ToLower(target)), nil
+}
+
+func completePinnedPlugins(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var comps []string
+	for _, pin := range pluginmanager.GetPinnedPlugins() {
+		comps = append(comps, fmt.Sprintf("%s@%s\tPinned to version %s", pin.Name, pin.Target, pin.Version))
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
@@ -8,11 +8,13 @@ import (
 	"io"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
@@ -22,28 +24,65 @@ import (
 )
 
 var (
-	showDetails bool
-	pluginName  string
+	showDetails         bool
+	pluginName          string
+	searchPublisher     string
+	searchVendor        string
+	searchOSArch        string
+	searchIncludeHidden bool
+	searchSortBy        string
+)
+
+const (
+	sortByName      = "name"
+	sortByTarget    = "target"
+	sortByRelevance = "relevance"
 )
 
 const searchLongDesc = `Search provides the ability to search for plugins that can be installed.
 The command lists all plugins currently available for installation.
+Keywords can be specified to only list the plugins whose name, description,
+publisher or vendor contain all of them; the plugins are then ranked by relevance.
 The search command also provides flags to limit the scope of the search.
 `
 
+const searchExamples = `
+    # Search for all the available plugins
+    tanzu plugin search
+
+    # Search for the plugins related to clusters, listing the most relevant first
+    tanzu plugin search cluster
+
+    # Search for the plugins of a publisher available for Apple silicon
+    tanzu plugin search --vendor vmware --publisher tkg --os-arch darwin_arm64
+
+    # Search for the plugins of a target sorted by name
+    tanzu plugin search package --target k8s --sort-by name`
+
 func newSearchPluginCmd() *cobra.Command {
 	var searchCmd = &cobra.Command{
-		Use:               "search",
+		Use:               "search [KEYWORD]...",
 		Short:             "Search for available plugins",
 		Long:              searchLongDesc,
-		Args:              cobra.MaximumNArgs(0),
-		ValidArgsFunction: noMoreCompletions,
+		Example:           searchExamples,
+		ValidArgsFunction: completeSearchKeywords,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !configtypes.IsValidTarget(targetStr, true, true) {
 				return errors.New(invalidTargetMsg)
 			}
+			keywords := strings.Join(args, " ")
+			if local != "" && keywords != "" {
+				return errors.New("keywords cannot be used to search plugins of a local source")
+			}
+			sortBy, err := getSearchSortBy(keywords)
+			if err != nil {
+				return err
+			}
+			osName, arch, err := parseSearchOSArch(searchOSArch)
+			if err != nil {
+				return err
+			}
 			errorList := make([]error, 0)
-			var err error
 			var allPlugins []discovery.Discovered
 			if local != "" {
 				// The user requested the list of plugins from a local path
@@ -58,15 +97,21 @@ func newSearchPluginCmd() *cobra.Command {
 			} else {
 				// Show plugins found in the central repos
 				criteria := &discovery.PluginDiscoveryCriteria{
-					Name:   pluginName,
-					Target: configtypes.StringToTarget(targetStr),
+					Name:          pluginName,
+					Target:        configtypes.StringToTarget(targetStr),
+					OS:            osName,
+					Arch:          arch,
+					Publisher:     searchPublisher,
+					Vendor:        searchVendor,
+					Keywords:      keywords,
+					IncludeHidden: searchIncludeHidden,
 				}
 				allPlugins, err = pluginmanager.DiscoverStandalonePlugins(discovery.WithPluginDiscoveryCriteria(criteria))
 				if err != nil {
 					errorList = append(errorList, fmt.Errorf("there was an error while discovering standalone plugins, error information: '%w'", err))
 				}
 			}
-			sort.Sort(discovery.DiscoveredSorter(allPlugins))
+			sortPluginsFound(allPlugins, sortBy)
 
 			if !showDetails {
 				displayPluginsFound(allPlugins, cmd.OutOrStdout())
@@ -93,7 +138,7 @@ func newSearchPluginCmd() *cobra.Command {
 	utils.PanicOnErr(f.MarkDeprecated("local", msg))
 
 	// Shell completion for this flag is the default behavior of doing file completion
-	f.StringVarP(&local, "local-source", "l", "", "path to local plugin source")
+	f.StringVarP(&local, "local-source", "l", "", "path to local plugin source directory or plugin archive (.tar.gz, .tgz or .tar)")
 	// We hide the "local-source" flag because installing from a local-source is not supported in production.
 	// See the "local-source" flag of the "plugin install" command.
 	utils.PanicOnErr(f.MarkHidden("local-source"))
@@ -103,16 +148,85 @@ func newSearchPluginCmd() *cobra.Command {
 		return []string{compGlobalTarget, compK8sTarget, compTMCTarget, compOpsTarget}, cobra.ShellCompDirectiveNoFileComp
 	}))
 
-	searchCmd.MarkFlagsMutuallyExclusive("local", "name")
-	searchCmd.MarkFlagsMutuallyExclusive("local", "target")
-	searchCmd.MarkFlagsMutuallyExclusive("local", "show-details")
-	searchCmd.MarkFlagsMutuallyExclusive("local-source", "name")
-	searchCmd.MarkFlagsMutuallyExclusive("local-source", "target")
-	searchCmd.MarkFlagsMutuallyExclusive("local-source", "show-details")
+	f.StringVarP(&searchPublisher, "publisher", "", "", "limit the search to plugins of the specified publisher")
+	f.StringVarP(&searchVendor, "vendor", "", "", "limit the search to plugins of the specified vendor")
+	f.StringVarP(&searchOSArch, "os-arch", "", "", "limit the search to plugins available for the specified OS and architecture, e.g., darwin_arm64")
+	utils.PanicOnErr(searchCmd.RegisterFlagCompletionFunc("os-arch", completionAllOSArch))
+	f.BoolVarP(&searchIncludeHidden, "include-hidden", "", false, "include the hidden plugins in the search")
+	f.StringVarP(&searchSortBy, "sort-by", "", "", fmt.Sprintf("sort the plugins found by %s, %s or %s. Defaults to %s when searching with keywords, %s otherwise", sortByName, sortByTarget, sortByRelevance, sortByRelevance, sortByName))
+	utils.PanicOnErr(searchCmd.RegisterFlagCompletionFunc("sort-by", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{sortByName, sortByTarget, sortByRelevance}, cobra.ShellCompDirectiveNoFileComp
+	}))
+
+	for _, localFlag := range []string{"local", "local-source"} {
+		for _, flag := range []string{"name", "target", "show-details", "publisher", "vendor", "os-arch", "include-hidden"} {
+			searchCmd.MarkFlagsMutuallyExclusive(localFlag, flag)
+		}
+	}
 
 	return searchCmd
 }
 
+func completeSearchKeywords(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return cobra.AppendActiveHelp(nil, "You can optionally enter keywords to search for in the plugin names and descriptions"), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completionAllOSArch(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	var comps []string
+	for _, osArch := range cli.AllOSArch {
+		comps = append(comps, osArch.String())
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+// getSearchSortBy returns how to sort the plugins found, which by default is by relevance
+// when searching with keywords and by name otherwise
+func getSearchSortBy(keywords string) (string, error) {
+	switch searchSortBy {
+	case "":
+		if keywords != "" {
+			return sortByRelevance, nil
+		}
+		return sortByName, nil
+	case sortByName, sortByTarget, sortByRelevance:
+		return searchSortBy, nil
+	}
+	return "", errors.Errorf("invalid value %q for --sort-by, the supported values are %s, %s and %s", searchSortBy, sortByName, sortByTarget, sortByRelevance)
+}
+
+// parseSearchOSArch splits the value of the --os-arch flag, e.g., darwin_arm64, into an OS and an architecture
+func parseSearchOSArch(osArch string) (string, string, error) {
+	if osArch == "" {
+		return "", "", nil
+	}
+	for _, a := range cli.AllOSArch {
+		if a.String() == osArch {
+			return a.OS(), a.Arch(), nil
+		}
+	}
+	var supported []string
+	for _, a := range cli.AllOSArch {
+		supported = append(supported, a.String())
+	}
+	return "", "", errors.Errorf("invalid value %q for --os-arch, the supported values are: %s", osArch, strings.Join(supported, ", "))
+}
+
+// sortPluginsFound sorts the plugins by name, target or relevance. Plugins of the same
+// target or relevance are sorted by name, and plugins of the same name by target.
+func sortPluginsFound(plugins []discovery.Discovered, sortBy string) {
+	sort.Sort(discovery.DiscoveredSorter(plugins))
+	switch sortBy {
+	case sortByTarget:
+		sort.SliceStable(plugins, func(i, j int) bool {
+			return plugins[i].Target < plugins[j].Target
+		})
+	case sortByRelevance:
+		sort.SliceStable(plugins, func(i, j int) bool {
+			return plugins[i].Relevance > plugins[j].Relevance
+		})
+	}
+}
+
 func displayPluginsFound(plugins []discovery.Discovered, writer io.Writer) {
 	outputWriter := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Description", "Target", "Latest")
 
@@ -135,6 +249,7 @@ func displayPluginDetails(plugins []discovery.Discovered, writer io.Writer) {
 		Target      string
 		Latest      string
 		Versions    []string
+		Source      string
 	}
 
 	// For the table format, we will use individual yaml output for each plugin
@@ -149,6 +264,7 @@ func displayPluginDetails(plugins []discovery.Discovered, writer io.Writer) {
 				Target:      string(plugins[i].Target),
 				Latest:      plugins[i].RecommendedVersion,
 				Versions:    plugins[i].SupportedVersions,
+				Source:      plugins[i].Source,
 			}
 			component.NewObjectWriter(writer, string(component.YAMLOutputType), details).Render()
 		}
@@ -166,6 +282,7 @@ func displayPluginDetails(plugins []discovery.Discovered, writer io.Writer) {
 			Target:      string(plugins[i].Target),
 			Latest:      plugins[i].RecommendedVersion,
 			Versions:    plugins[i].SupportedVersions,
+			Source:      plugins[i].Source,
 		})
 	}
 	component.NewObjectWriter(writer, outputFormat, details).Render()
@@ -5,12 +5,17 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
 )
 
 func TestPluginSearch(t *testing.T) {
@@ -106,10 +111,10 @@ func TestCompletionPluginSearch(t *testing.T) {
 		expected string
 	}{
 		{
-			test: "no completion after the plugin search command",
+			test: "no completion for the keywords of the plugin search command",
 			args: []string{"__complete", "plugin", "search", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
-			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+			expected: "_activeHelp_ You can optionally enter keywords to search for in the plugin names and descriptions\n:4\n",
 		},
 		{
 			test: "completion for the --name flag value",
@@ -144,6 +149,18 @@ func TestCompletionPluginSearch(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: expectedOutforTargetFlag + ":4\n",
 		},
+		{
+			test: "completion for the --os-arch flag value",
+			args: []string{"__complete", "plugin", "search", "--os-arch", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "linux_amd64\ndarwin_amd64\nwindows_amd64\nlinux_arm64\ndarwin_arm64\nwindows_arm64\n:4\n",
+		},
+		{
+			test: "completion for the --sort-by flag value",
+			args: []string{"__complete", "plugin", "search", "--sort-by", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "name\ntarget\nrelevance\n:4\n",
+		},
 		{
 			test: "completion for the --local-source flag value",
 			args: []string{"__complete", "plugin", "search", "--local-source", ""},
@@ -178,3 +195,107 @@ func TestCompletionPluginSearch(t *testing.T) {
 
 	os.Unsetenv("TANZU_ACTIVE_HELP")
 }
+
+func TestPluginSearchWithKeywordsAndFilters(t *testing.T) {
+	tests := []struct {
+		test            string
+		args            []string
+		expected        []string
+		expectedFailure string
+	}{
+		{
+			test:     "keywords rank the plugins by relevance",
+			args:     []string{"plugin", "search", "cluster"},
+			expected: []string{"cluster/kubernetes", "cluster/mission-control", "isolated-cluster/global", "management-cluster/kubernetes", "management-cluster/mission-control"},
+		},
+		{
+			test:     "all the keywords must match",
+			args:     []string{"plugin", "search", "isolated", "cluster"},
+			expected: []string{"isolated-cluster/global"},
+		},
+		{
+			test:     "keywords match the description",
+			args:     []string{"plugin", "search", "login/global"},
+			expected: []string{"login/global"},
+		},
+		{
+			test:     "sort by target",
+			args:     []string{"plugin", "search", "cluster", "--sort-by", "target"},
+			expected: []string{"isolated-cluster/global", "cluster/kubernetes", "management-cluster/kubernetes", "cluster/mission-control", "management-cluster/mission-control"},
+		},
+		{
+			test:     "sort by name",
+			args:     []string{"plugin", "search", "cluster", "--sort-by", "name"},
+			expected: []string{"cluster/kubernetes", "cluster/mission-control", "isolated-cluster/global", "management-cluster/kubernetes", "management-cluster/mission-control"},
+		},
+		{
+			test:     "filter by publisher, vendor, target and os-arch",
+			args:     []string{"plugin", "search", "--publisher", "test", "--vendor", "vmware", "--target", "global", "--os-arch", "darwin_arm64"},
+			expected: []string{"isolated-cluster/global", "login/global"},
+		},
+		{
+			test:     "no plugin of another publisher",
+			args:     []string{"plugin", "search", "--publisher", "other"},
+			expected: []string{},
+		},
+		{
+			test:            "invalid os-arch",
+			args:            []string{"plugin", "search", "--os-arch", "darwin"},
+			expectedFailure: `invalid value "darwin" for --os-arch`,
+		},
+		{
+			test:            "invalid sort-by",
+			args:            []string{"plugin", "search", "--sort-by", "version"},
+			expectedFailure: `invalid value "version" for --sort-by`,
+		},
+		{
+			test:            "no keywords with --local-source",
+			args:            []string{"plugin", "search", "cluster", "--local-source", "./"},
+			expectedFailure: "keywords cannot be used to search plugins of a local source",
+		},
+		{
+			test:            "no --local-source and --publisher together",
+			args:            []string{"plugin", "search", "--local-source", "./", "--publisher", "test"},
+			expectedFailure: "if any flags in the group [local-source publisher] are set none of the others can be",
+		},
+	}
+
+	// Setup a plugin source and a set of installed plugins
+	defer setupPluginSourceForTesting(t)()
+
+	// Mark the cache of the test inventory as recently refreshed
+	// so that searching does not try to download the inventory
+	inventoryDir := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, config.DefaultStandaloneDiscoveryName)
+	err := os.WriteFile(filepath.Join(inventoryDir, "digest.0000000000"), []byte("example.com/tanzu_cli/plugins/plugin-inventory:latest"), 0644)
+	assert.Nil(t, err)
+
+	for _, spec := range tests {
+		t.Run(spec.test, func(t *testing.T) {
+			assert := assert.New(t)
+
+			rootCmd, err := NewRootCmd()
+			assert.Nil(err)
+
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs(append(spec.args, "-o", "json"))
+
+			err = rootCmd.Execute()
+			resetPluginCommandFlags()
+			if spec.expectedFailure != "" {
+				assert.NotNil(err)
+				assert.Contains(err.Error(), spec.expectedFailure)
+				return
+			}
+			assert.Nil(err)
+
+			var plugins []map[string]string
+			assert.Nil(json.Unmarshal(out.Bytes(), &plugins))
+			found := []string{}
+			for _, p := range plugins {
+				found = append(found, p["name"]+"/"+p["target"])
+			}
+			assert.Equal(spec.expected, found)
+		})
+	}
+}
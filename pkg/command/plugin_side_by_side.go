@@ -0,0 +1,153 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+const pluginVersionFlag = "--plugin-version"
+
+func newSetDefaultPluginCmd() *cobra.Command {
+	var setDefaultCmd = &cobra.Command{
+		Use:   "set-default " + pluginNameCaps + "[@TARGET] VERSION",
+		Short: "Set the default version of a plugin installed side by side",
+		Long: `Set the default version of a plugin among the versions installed side by side.
+The previous default version remains installed and can still be invoked explicitly using
+'tanzu --plugin-version NAME=VERSION'.`,
+		Example: `
+    # Install version v1.2.0 of the cluster plugin alongside the installed version
+    tanzu plugin install cluster --target k8s --version v1.2.0 --side-by-side
+
+    # Make version v1.2.0 the default version of the cluster plugin
+    tanzu plugin set-default cluster@kubernetes v1.2.0`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeSetDefaultPlugin,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pluginName, target, err := parsePluginNameAndTarget(args[0])
+			if err != nil {
+				return err
+			}
+			if err := pluginmanager.SetDefaultPluginVersion(pluginName, args[1], target); err != nil {
+				return err
+			}
+			log.Successf("Version '%s' is now the default version of plugin '%s'", args[1], pluginName)
+			return nil
+		},
+	}
+
+	setDefaultCmd.Flags().StringVarP(&targetStr, "target", "t", "", targetFlagDesc)
+	utils.PanicOnErr(setDefaultCmd.RegisterFlagCompletionFunc("target", completeTargetsForInstalledPlugins))
+
+	return setDefaultCmd
+}
+
+func completeSetDefaultPlugin(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeInstalledPlugins(cmd, args, toComplete)
+	}
+	if len(args) > 1 {
+		return activeHelpNoMoreArgs(nil), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	pluginName, target, err := parsePluginNameAndTarget(args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	sideBySideVersions, err := pluginmanager.GetSideBySideVersions(pluginName, target)
+	if err != nil || len(sideBySideVersions) == 0 {
+		comps := cobra.AppendActiveHelp(nil, fmt.Sprintf("Plugin '%s' has no version installed side by side", args[0]))
+		return comps, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var comps []string
+	for i := range sideBySideVersions {
+		comps = append(comps, sideBySideVersions[i].Version)
+	}
+	return comps, cobra.ShellCompDirectiveNoFileComp
+}
+
+// extractPluginVersionArgs removes the --plugin-version flags specified before the command from
+// the arguments of the CLI, including when the shell requests completions, and returns the
+// remaining arguments along with the values of the flags.
+// The flags are processed before the command tree is built as they select which plugin binaries
+// provide the commands.
+func extractPluginVersionArgs(args []string) (remainingArgs, selections []string) {
+	pos := 0
+	if len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd) {
+		pos = 1
+	}
+	remainingArgs = append(remainingArgs, args[:pos]...)
+
+	for pos < len(args) {
+		switch {
+		case strings.HasPrefix(args[pos], pluginVersionFlag+"="):
+			selections = append(selections, strings.TrimPrefix(args[pos], pluginVersionFlag+"="))
+			pos++
+		case args[pos] == pluginVersionFlag && pos+1 < len(args):
+			selections = append(selections, args[pos+1])
+			pos += 2
+		default:
+			return append(remainingArgs, args[pos:]...), selections
+		}
+	}
+	return remainingArgs, selections
+}
+
+// getPluginVersionSelections returns the versions of the plugins to invoke instead of their default
+// version, as specified by the TANZU_CLI_PLUGIN_VERSIONS environment variable
+func getPluginVersionSelections() (map[string]string, error) {
+	value := strings.TrimSpace(os.Getenv(constants.PluginVersions))
+	if value == "" {
+		return nil, nil
+	}
+
+	selections := map[string]string{}
+	for _, selection := range strings.Split(value, ",") {
+		nameTarget, version, found := strings.Cut(strings.TrimSpace(selection), "=")
+		if !found || nameTarget == "" || version == "" {
+			return nil, errors.Errorf("invalid plugin version selection '%s', expected the format NAME[@TARGET]=VERSION", selection)
+		}
+		selections[nameTarget] = version
+	}
+	return selections, nil
+}
+
+// unavailablePluginVersions contains, keyed by installation path, the plugins for which the
+// user selected a version that is not installed, with the error to report when they are invoked
+var unavailablePluginVersions map[string]error
+
+// selectPluginVersions replaces the default version of the installed plugins with
+// the versions selected by the user
+func selectPluginVersions(plugins []cli.PluginInfo) ([]cli.PluginInfo, error) {
+	unavailablePluginVersions = nil
+	selections, err := getPluginVersionSelections()
+	if err != nil || len(selections) == 0 {
+		return plugins, err
+	}
+	plugins, unavailablePluginVersions, err = pluginsupplier.SelectPluginVersions(plugins, selections)
+	return plugins, err
+}
+
+// checkSelectedPluginVersion returns an error if the user selected a version of the
+// invoked plugin that is not installed, instead of invoking its default version
+func checkSelectedPluginVersion(cmd *cobra.Command) error {
+	if !isPluginCommand(cmd) {
+		return nil
+	}
+	return unavailablePluginVersions[cmd.Annotations["pluginInstallationPath"]]
+}
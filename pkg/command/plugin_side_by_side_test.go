@@ -0,0 +1,114 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/plugin"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestExtractPluginVersionArgs(t *testing.T) {
+	tests := []struct {
+		args               []string
+		expectedArgs       []string
+		expectedSelections []string
+	}{
+		{
+			args:         []string{"cluster", "list"},
+			expectedArgs: []string{"cluster", "list"},
+		},
+		{
+			args:               []string{"--plugin-version", "cluster=v1.2.0", "cluster", "list"},
+			expectedArgs:       []string{"cluster", "list"},
+			expectedSelections: []string{"cluster=v1.2.0"},
+		},
+		{
+			args:               []string{"--plugin-version=cluster@k8s=v1.2.0", "--plugin-version", "feature=v0.1.0", "cluster", "list"},
+			expectedArgs:       []string{"cluster", "list"},
+			expectedSelections: []string{"cluster@k8s=v1.2.0", "feature=v0.1.0"},
+		},
+		{
+			// Only the flags before the command are extracted
+			args:         []string{"cluster", "list", "--plugin-version", "cluster=v1.2.0"},
+			expectedArgs: []string{"cluster", "list", "--plugin-version", "cluster=v1.2.0"},
+		},
+		{
+			args:               []string{"__complete", "--plugin-version", "cluster=v1.2.0", "cluster", ""},
+			expectedArgs:       []string{"__complete", "cluster", ""},
+			expectedSelections: []string{"cluster=v1.2.0"},
+		},
+	}
+
+	for _, tc := range tests {
+		args, selections := extractPluginVersionArgs(tc.args)
+		assert.Equal(t, tc.expectedArgs, args)
+		assert.Equal(t, tc.expectedSelections, selections)
+	}
+}
+
+func TestGetPluginVersionSelections(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv(constants.PluginVersions, "")
+	selections, err := getPluginVersionSelections()
+	assert.Nil(err)
+	assert.Empty(selections)
+
+	t.Setenv(constants.PluginVersions, "cluster@kubernetes=v1.2.0, feature=v0.1.0")
+	selections, err = getPluginVersionSelections()
+	assert.Nil(err)
+	assert.Equal(map[string]string{"cluster@kubernetes": "v1.2.0", "feature": "v0.1.0"}, selections)
+
+	t.Setenv(constants.PluginVersions, "cluster")
+	_, err = getPluginVersionSelections()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "invalid plugin version selection 'cluster'")
+}
+
+func TestRootCmdWithUnavailablePluginVersion(t *testing.T) {
+	assert := assert.New(t)
+	env := setupTestCLIEnvironment(t)
+	defer tearDownTestCLIEnvironment(env)
+
+	cc, err := catalog.NewContextCatalogUpdater("")
+	assert.Nil(err)
+	for _, name := range []string{"dummy", "other"} {
+		assert.Nil(setupFakePlugin(env.cacheDir, name, "v0.1.0", plugin.SystemCmdGroup, 0, configtypes.TargetK8s, 0, false, nil))
+		assert.Nil(cc.Upsert(&cli.PluginInfo{
+			Name:             name,
+			Description:      name,
+			Group:            plugin.SystemCmdGroup,
+			Version:          "v0.1.0",
+			InstallationPath: filepath.Join(env.cacheDir, name+"_"+string(configtypes.TargetK8s)),
+			Target:           configtypes.TargetK8s,
+		}))
+	}
+	cc.Unlock()
+
+	// A selected version that is not installed does not prevent using the CLI
+	t.Setenv(constants.PluginVersions, "dummy=v9.9.9")
+	for _, args := range [][]string{{"other", "say", "hello"}, {"plugin", "install", "--help"}} {
+		rootCmd, err := NewRootCmd()
+		assert.Nil(err)
+		rootCmd.SetArgs(args)
+		assert.Nil(rootCmd.Execute(), args)
+	}
+
+	// but the plugin is not invoked with its default version instead of the selected one
+	rootCmd, err := NewRootCmd()
+	assert.Nil(err)
+	rootCmd.SetArgs([]string{"dummy", "say", "hello"})
+	err = rootCmd.Execute()
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "version 'v9.9.9' of plugin 'dummy' is not installed")
+}
@@ -89,6 +89,28 @@ func TestPluginList(t *testing.T) {
 			expectedFailure: false,
 			expected:        `[ { "description": "some foo description", "installationpath": "%v", "name": "foo", "status": "installed", "target": "kubernetes", "version": "v0.1.0" } ]`,
 		},
+		{
+			test:            "plugin export with no plugins installed",
+			plugins:         []string{},
+			args:            []string{"plugin", "export"},
+			expectedFailure: false,
+			expected:        "version: v1 plugins: []",
+		},
+		{
+			test:            "plugin export with plugins installed",
+			plugins:         []string{"foo", "bar"},
+			versions:        []string{"v0.1.0", "v0.2.0"},
+			targets:         []configtypes.Target{configtypes.TargetTMC, configtypes.TargetK8s},
+			args:            []string{"plugin", "export"},
+			expectedFailure: false,
+			expected:        "version: v1 plugins: - name: bar target: kubernetes version: v0.2.0 - name: foo target: mission-control version: v0.1.0",
+		},
+		{
+			test:            "plugin export does not accept arguments",
+			args:            []string{"plugin", "export", "foo"},
+			expectedFailure: true,
+			expected:        `unknown command "foo" for "tanzu plugin export"`,
+		},
 	}
 
 	for _, spec := range tests {
@@ -325,6 +347,19 @@ func TestDeletePlugin(t *testing.T) {
 	}
 }
 
+func TestInstallAndUninstallVersionFlags(t *testing.T) {
+	pluginCmd := newPluginCmd()
+
+	installCmd, _, err := pluginCmd.Find([]string{"install"})
+	assert.Nil(t, err)
+	assert.Equal(t, cli.VersionLatest, installCmd.Flags().Lookup("version").DefValue)
+	assert.Equal(t, cli.VersionLatest, version)
+
+	uninstallCmd, _, err := pluginCmd.Find([]string{"uninstall"})
+	assert.Nil(t, err)
+	assert.Equal(t, "", uninstallCmd.Flags().Lookup("version").DefValue)
+}
+
 func TestInstallPlugin(t *testing.T) {
 	tests := []struct {
 		test             string
@@ -368,6 +403,36 @@ func TestInstallPlugin(t *testing.T) {
 			expectedFailure:  true,
 			expectedErrorMsg: "if any flags in the group [group version] are set none of the others can be",
 		},
+		{
+			test:             "--image must be referenced by digest",
+			args:             []string{"plugin", "install", "--image", "example.com/plugins/myplugin:v1.0.0"},
+			expectedFailure:  true,
+			expectedErrorMsg: "must be referenced by its digest, e.g., REPOSITORY@sha256:DIGEST",
+		},
+		{
+			test:             "--image must be from a trusted registry",
+			args:             []string{"plugin", "install", "--image", "example.com/plugins/myplugin@sha256:" + strings.Repeat("a", 64)},
+			expectedFailure:  true,
+			expectedErrorMsg: "untrusted registry detected with image",
+		},
+		{
+			test:             "no --image and --url together",
+			args:             []string{"plugin", "install", "--image", "example.com/plugins/myplugin@sha256:" + strings.Repeat("a", 64), "--url", "https://example.com/myplugin", "--sha256", strings.Repeat("a", 64)},
+			expectedFailure:  true,
+			expectedErrorMsg: "if any flags in the group [image url] are set none of the others can be",
+		},
+		{
+			test:             "--url requires --sha256",
+			args:             []string{"plugin", "install", "--url", "https://example.com/myplugin"},
+			expectedFailure:  true,
+			expectedErrorMsg: "if any flags in the group [url sha256] are set they must all be set",
+		},
+		{
+			test:             "--url must be a trusted location",
+			args:             []string{"plugin", "install", "--url", "https://example.com/myplugin", "--sha256", strings.Repeat("a", 64)},
+			expectedFailure:  true,
+			expectedErrorMsg: "untrusted artifact location detected with URI",
+		},
 	}
 
 	assert := assert.New(t)
@@ -419,10 +484,34 @@ func TestUpgradePlugin(t *testing.T) {
 			expectedFailure:  true,
 			expectedErrorMsg: invalidTargetMsg,
 		},
+		{
+			test:             "no plugin name",
+			args:             []string{"plugin", "upgrade"},
+			expectedFailure:  true,
+			expectedErrorMsg: "must provide plugin name as positional argument",
+		},
+		{
+			test:             "plugin name with --all",
+			args:             []string{"plugin", "upgrade", "--all", "myplugin"},
+			expectedFailure:  true,
+			expectedErrorMsg: "the plugin name cannot be specified when using the '--all' flag",
+		},
+		{
+			test:            "no plugin name with --all",
+			args:            []string{"plugin", "upgrade", "--all"},
+			expectedFailure: false,
+		},
 	}
 
 	assert := assert.New(t)
 
+	// Without any installed plugin, there is nothing to upgrade with --all
+	dir := t.TempDir()
+	t.Setenv("TEST_CUSTOM_CATALOG_CACHE_DIR", dir)
+	defaultCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = filepath.Join(dir, "cache")
+	defer func() { common.DefaultCacheDir = defaultCacheDir }()
+
 	tkgConfigFile, err := os.CreateTemp("", "config")
 	assert.Nil(err)
 	os.Setenv("TANZU_CONFIG", tkgConfigFile.Name())
@@ -504,6 +593,146 @@ func TestCompletionPlugin(t *testing.T) {
 			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
 		},
 		// =====================
+		// tanzu plugin export
+		// =====================
+		{
+			test: "no completions for the plugin export command",
+			args: []string{"__complete", "plugin", "export", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		// =====================
+		// tanzu plugin outdated
+		// =====================
+		{
+			test: "no completions for the plugin outdated command",
+			args: []string{"__complete", "plugin", "outdated", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		{
+			test: "completion for the --output flag value of the plugin outdated command",
+			args: []string{"__complete", "plugin", "outdated", "--output", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: expectedOutForOutputFlag + ":4\n",
+		},
+		// =====================
+		// tanzu plugin pin
+		// =====================
+		{
+			test: "completion for the plugin pin command",
+			args: []string{"__complete", "plugin", "pin", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "cluster\tMultiple entries for plugin cluster. You will need to use the --target flag.\n" +
+				"feature\tTarget: kubernetes for feature\n" +
+				"management-cluster\tMultiple entries for plugin management-cluster. You will need to use the --target flag.\n" +
+				"secret\tTarget: kubernetes for secret\n" +
+				":4\n",
+		},
+		{
+			test: "no more completions for the plugin pin command after the plugin name",
+			args: []string{"__complete", "plugin", "pin", "feature", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		// =====================
+		// tanzu plugin unpin
+		// =====================
+		{
+			test: "no completions for the plugin unpin command when no plugin is pinned",
+			args: []string{"__complete", "plugin", "unpin", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: ":4\n",
+		},
+		{
+			test: "no more completions for the plugin unpin command after the plugin name",
+			args: []string{"__complete", "plugin", "unpin", "feature@kubernetes", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		// =====================
+		// tanzu plugin set-default
+		// =====================
+		{
+			test: "completion for the plugin set-default command",
+			args: []string{"__complete", "plugin", "set-default", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "cluster\tMultiple entries for plugin cluster. You will need to use the --target flag.\n" +
+				"feature\tTarget: kubernetes for feature\n" +
+				"management-cluster\tMultiple entries for plugin management-cluster. You will need to use the --target flag.\n" +
+				"secret\tTarget: kubernetes for secret\n" +
+				":4\n",
+		},
+		{
+			test: "no version completions for the plugin set-default command without versions installed side by side",
+			args: []string{"__complete", "plugin", "set-default", "feature", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ Plugin 'feature' has no version installed side by side\n:4\n",
+		},
+		{
+			test: "no more completions for the plugin set-default command after the version",
+			args: []string{"__complete", "plugin", "set-default", "feature", "v0.0.1", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		// =====================
+		// tanzu plugin gc
+		// =====================
+		{
+			test: "no completions for the plugin gc command",
+			args: []string{"__complete", "plugin", "gc", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		{
+			test: "completion for the --output flag value of the plugin gc command",
+			args: []string{"__complete", "plugin", "gc", "--output", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: expectedOutForOutputFlag + ":4\n",
+		},
+		// =====================
+		// tanzu plugin verify
+		// =====================
+		{
+			test: "no completions for the plugin verify command",
+			args: []string{"__complete", "plugin", "verify", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		{
+			test: "completion for the --output flag value of the plugin verify command",
+			args: []string{"__complete", "plugin", "verify", "--output", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: expectedOutForOutputFlag + ":4\n",
+		},
+		// =====================
+		// tanzu plugin cache
+		// =====================
+		{
+			test: "no completions for the plugin cache list command",
+			args: []string{"__complete", "plugin", "cache", "list", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		{
+			test: "completion for the --output flag value of the plugin cache list command",
+			args: []string{"__complete", "plugin", "cache", "list", "--output", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: expectedOutForOutputFlag + ":4\n",
+		},
+		{
+			test: "no completions for the plugin cache prune command",
+			args: []string{"__complete", "plugin", "cache", "prune", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		{
+			test: "no completions for the plugin cache size command",
+			args: []string{"__complete", "plugin", "cache", "size", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+		},
+		// =====================
 		// tanzu plugin install
 		// =====================
 		{
@@ -575,6 +804,27 @@ func TestCompletionPlugin(t *testing.T) {
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: ":4\n",
 		},
+		{
+			test: "completion for the --image flag value of the plugin install command",
+			args: []string{"__complete", "plugin", "install", "--image", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ Please enter the URI of the plugin image, referenced by its digest\n" +
+				":4\n",
+		},
+		{
+			test: "completion for the --url flag value of the plugin install command",
+			args: []string{"__complete", "plugin", "install", "--url", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ Please enter the URL of the plugin binary\n" +
+				":4\n",
+		},
+		{
+			test: "completion for the --sha256 flag value of the plugin install command",
+			args: []string{"__complete", "plugin", "install", "--url", "https://example.com/myplugin", "--sha256", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "_activeHelp_ Please enter the SHA256 digest of the plugin binary\n" +
+				":4\n",
+		},
 		{
 			test: "completion for the --group flag value for the group name part of the plugin install command",
 			args: []string{"__complete", "plugin", "install", "--group", ""},
@@ -870,6 +1120,26 @@ func TestCompletionPlugin(t *testing.T) {
 				":4\n",
 		},
 		// =====================
+		// tanzu plugin rollback
+		// =====================
+		{
+			test: "completion for the plugin rollback command",
+			args: []string{"__complete", "plugin", "rollback", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "cluster\tMultiple entries for plugin cluster. You will need to use the --target flag.\n" +
+				"feature\tTarget: kubernetes for feature\n" +
+				"management-cluster\tMultiple entries for plugin management-cluster. You will need to use the --target flag.\n" +
+				"secret\tTarget: kubernetes for secret\n" +
+				":4\n",
+		},
+		{
+			test: "completion for the plugin rollback command when the specified plugin name is not unique",
+			args: []string{"__complete", "plugin", "rollback", "cluster", ""},
+			// ":4" is the value of the ShellCompDirectiveNoFileComp
+			expected: "--target\n" +
+				":4\n",
+		},
+		// =====================
 		// tanzu plugin describe
 		// =====================
 		{
@@ -971,6 +1241,7 @@ func resetPluginCommandFlags() {
 	local = ""
 	version = ""
 	forceDelete = false
+	forceUninstall = false
 	outputFormat = ""
 	targetStr = ""
 	group = ""
@@ -978,4 +1249,17 @@ func resetPluginCommandFlags() {
 	groupID = ""
 	showDetails = false
 	pluginName = ""
+	searchPublisher = ""
+	searchVendor = ""
+	searchOSArch = ""
+	searchIncludeHidden = false
+	searchSortBy = ""
+	refreshContextPlugins = false
+	uri = ""
+	priority = 0
+	publicKeys = nil
+	certificateIdentity = ""
+	certificateOIDCIssuer = ""
+	certificateChain = ""
+	skipTransparencyLog = false
 }
@@ -0,0 +1,81 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginmanager"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+var repairPlugins bool
+
+func newVerifyPluginCmd() *cobra.Command {
+	var verifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the integrity of the installed plugin binaries",
+		Long: `Verify the integrity of the installed plugin binaries by comparing the digest of each binary
+with the digest recorded when the plugin was installed and with the digest published by the
+discovery sources. Missing or modified binaries are reported and can be installed again using --repair.`,
+		Example: `
+    # Verify the installed plugins
+    tanzu plugin verify
+
+    # Verify the installed plugins and install again the ones that are missing or modified
+    tanzu plugin verify --repair`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: noMoreCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verifications, err := pluginmanager.VerifyInstalledPlugins()
+			if err != nil {
+				return err
+			}
+			displayPluginVerifications(verifications, cmd.OutOrStdout())
+
+			var failed int
+			for i := range verifications {
+				if verifications[i].Status != pluginmanager.PluginVerificationOK {
+					failed++
+				}
+			}
+			if failed == 0 {
+				log.Success("All installed plugins were verified successfully")
+				return nil
+			}
+			if !repairPlugins {
+				return fmt.Errorf("%d plugin(s) failed the verification. Run 'tanzu plugin verify --repair' to install them again", failed)
+			}
+
+			results := pluginmanager.RepairPlugins(verifications)
+			pluginmanager.DisplayPluginInstallResults(results, cmd.ErrOrStderr())
+			if err := pluginmanager.PluginInstallResultsError(results); err != nil {
+				return err
+			}
+			log.Successf("successfully repaired %d plugin(s)", failed)
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().BoolVar(&repairPlugins, "repair", false, "install again the plugins which binary is missing or modified")
+	verifyCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format (yaml|json|table)")
+	utils.PanicOnErr(verifyCmd.RegisterFlagCompletionFunc("output", completionGetOutputFormats))
+
+	return verifyCmd
+}
+
+// displayPluginVerifications displays the verification results in the requested output format
+func displayPluginVerifications(verifications []pluginmanager.PluginVerification, writer io.Writer) {
+	output := component.NewOutputWriterWithOptions(writer, outputFormat, []component.OutputWriterOption{}, "Name", "Target", "Version", "Status", "Details")
+	for i := range verifications {
+		output.AddRow(verifications[i].Name, verifications[i].Target, verifications[i].Version, verifications[i].Status, verifications[i].Details)
+	}
+	output.Render()
+}
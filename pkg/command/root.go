@@ -104,6 +104,7 @@ func NewRootCmd() (*cobra.Command, error) { //nolint: gocyclo,funlen
 		//       If we decide to fold this functionality into existing 'tanzu telemetry' plugin
 		newCEIPParticipationCmd(),
 		newGenAllDocsCmd(),
+		newAliasCmd(),
 	)
 	if _, err := ensureCLIInstanceID(); err != nil {
 		return nil, errors.Wrap(err, "failed to ensure CLI ID")
@@ -119,6 +120,11 @@ func NewRootCmd() (*cobra.Command, error) { //nolint: gocyclo,funlen
 		return nil, err
 	}
 
+	// Invoke the versions installed side by side that the user selected instead of the default versions
+	if plugins, err = selectPluginVersions(plugins); err != nil {
+		return nil, err
+	}
+
 	convertInvokedAs(plugins)
 
 	telemetry.Client().SetInstalledPlugins(plugins)
@@ -183,6 +189,7 @@ func NewRootCmd() (*cobra.Command, error) { //nolint: gocyclo,funlen
 	}
 
 	remapCommandTree(rootCmd, plugins)
+	addAliasCommands(rootCmd)
 	updateTargetCommandGroupVisibility()
 	updateConfigWithTanzuCSPIssuer(csp.GetIssuerUpdateFlagFromCentralConfig, datastore.GetDataStoreValue)
 
@@ -311,6 +318,11 @@ func setupTargetPlugins() error {
 		return fmt.Errorf("unable to find installed plugins: %w", err)
 	}
 
+	// Invoke the versions installed side by side that the user selected instead of the default versions
+	if plugins, err = selectPluginVersions(plugins); err != nil {
+		return err
+	}
+
 	convertInvokedAs(plugins)
 
 	// Insert the plugin commands under the appropriate target command
@@ -398,6 +410,11 @@ func newRootCmd() *cobra.Command {
 		// silencing usage for now as we are getting double usage from plugins on errors
 		SilenceUsage: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if offline, _ := cmd.Flags().GetBool(strings.TrimPrefix(offlineFlag, "--")); offline {
+				if err := os.Setenv(constants.OfflineMode, "true"); err != nil {
+					return err
+				}
+			}
 			// Sets the verbosity of the logger if TANZU_CLI_LOG_LEVEL is set
 			setLoggerVerbosity()
 
@@ -452,6 +469,15 @@ func newRootCmd() *cobra.Command {
 				installEssentialPlugins()
 			}
 
+			if err := checkSelectedPluginVersion(cmd); err != nil {
+				return err
+			}
+
+			// Warn the user if the version of the invoked plugin is deprecated
+			if isPluginCommand(cmd) {
+				pluginmanager.WarnIfDeprecatedPlugin(cmd.Annotations["pluginInstallationPath"])
+			}
+
 			setupActiveHelp(cmd, args)
 
 			return nil
@@ -466,6 +492,15 @@ func newRootCmd() *cobra.Command {
 			return utils.EnsureMutualExclusiveCurrentContexts()
 		},
 	}
+
+	// The --plugin-version flags are extracted from the arguments before the command tree is built,
+	// see extractPluginVersionArgs(). The flag is declared so that it is documented.
+	rootCmd.Flags().StringArray(strings.TrimPrefix(pluginVersionFlag, "--"), nil,
+		"invoke a version of a plugin installed side by side instead of its default version, using the format NAME[@TARGET]=VERSION")
+	// The --offline flag is also extracted from the arguments before the command tree is built,
+	// see extractOfflineArg(), so that it applies to the plugin commands
+	rootCmd.PersistentFlags().Bool(strings.TrimPrefix(offlineFlag, "--"), false,
+		"only use the local cache and fail the commands requiring network access")
 	return rootCmd
 }
 
@@ -737,6 +772,10 @@ func shouldSkipPrompts(cmd *cobra.Command) bool {
 }
 
 func shouldSkipEssentialPlugins(cmd *cobra.Command) bool {
+	if cliconfig.IsOfflineMode() {
+		// The essential plugins cannot be checked without network access
+		return true
+	}
 	skipCommandsForEssentials := []string{
 		// The shell completion logic is not interactive, so it should not trigger
 		// the installation of essential plugins which would print messages to the user
@@ -774,6 +813,9 @@ func shouldSkipEssentialPlugins(cmd *cobra.Command) bool {
 // shouldSkipVersionCheck checks if the CLI recommended version check should be skipped
 // for the specified command
 func shouldSkipVersionCheck(cmd *cobra.Command) bool {
+	if cliconfig.IsOfflineMode() {
+		return true
+	}
 	skipVersionCheckCommands := []string{
 		// The shell completion logic is not interactive, so it should not trigger
 		// extra printouts to the user for recommending a new version of the CLI
@@ -814,10 +856,35 @@ func shouldSkipGlobalInit(cmd *cobra.Command) bool {
 
 // Execute executes the CLI.
 func Execute() error {
+	args, offline := extractOfflineArg(os.Args[1:])
+	if offline {
+		// The offline mode is passed through the environment so that it also applies
+		// to the plugins and to the CLI commands they invoke
+		if err := os.Setenv(constants.OfflineMode, "true"); err != nil {
+			return err
+		}
+	}
+	args, pluginVersionSelections := extractPluginVersionArgs(args)
+	if len(pluginVersionSelections) > 0 {
+		// The selections are passed through the environment so that they apply when
+		// the command tree is built, and to the CLI commands invoked by the plugins
+		if current := os.Getenv(constants.PluginVersions); current != "" {
+			pluginVersionSelections = append([]string{current}, pluginVersionSelections...)
+		}
+		if err := os.Setenv(constants.PluginVersions, strings.Join(pluginVersionSelections, ",")); err != nil {
+			return err
+		}
+	}
+
 	root, err := NewRootCmd()
 	if err != nil {
 		return err
 	}
+	args, err = expandAliasArgs(root, args)
+	if err != nil {
+		return err
+	}
+	root.SetArgs(args)
 	executionErr := root.Execute()
 	exitCode := 0
 	if executionErr != nil {
@@ -834,6 +901,9 @@ func Execute() error {
 		telemetry.LogError(updateErr, "")
 	} else if saveErr := telemetry.Client().SaveMetrics(); saveErr != nil {
 		telemetry.LogError(saveErr, "")
+	} else if cliconfig.IsOfflineMode() {
+		// The metrics are kept in the local database and will be sent once online
+		log.V(6).Info("Not sending the telemetry metrics in offline mode")
 	} else if sendErr := telemetry.Client().SendMetrics(context.Background(), 0); sendErr != nil {
 		telemetry.LogError(sendErr, "")
 	}
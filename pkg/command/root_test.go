@@ -1021,18 +1021,27 @@ func TestCompletionShortHelpInActiveHelp(t *testing.T) {
 			test: "short help as active help at level 1",
 			args: []string{"__complete", "plugin", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
-			expected: "clean\tClean the plugins\n" +
+			expected: "cache\tManage the cache of downloaded plugin binaries\n" +
+				"clean\tClean the plugins\n" +
 				"describe\tDescribe a plugin\n" +
 				"download-bundle\tDownload plugin bundle to the local system\n" +
+				"export\tExport the installed plugins to a lockfile\n" +
+				"gc\tRemove plugin binaries that are no longer used\n" +
 				"group\tManage plugin-groups\n" +
 				"install\tInstall a plugin\n" +
 				"list\tList installed plugins\n" +
+				"outdated\tList installed plugins for which a newer version is available\n" +
+				"pin\tPin an installed plugin to its installed version\n" +
+				"rollback\tRollback a plugin to its previous version\n" +
 				"search\tSearch for available plugins\n" +
+				"set-default\tSet the default version of a plugin installed side by side\n" +
 				"source\tManage plugin discovery sources\n" +
 				"sync\tInstalls all plugins recommended by the active contexts\n" +
 				"uninstall\tUninstall a plugin\n" +
+				"unpin\tUnpin a plugin to allow it to be upgraded\n" +
 				"upgrade\tUpgrade a plugin\n" +
 				"upload-bundle\tUpload plugin bundle to a repository\n" +
+				"verify\tVerify the integrity of the installed plugin binaries\n" +
 				"_activeHelp_ Command help: Manage CLI plugins\n" +
 				":4\n",
 		},
@@ -1041,7 +1050,7 @@ func TestCompletionShortHelpInActiveHelp(t *testing.T) {
 			args: []string{"__complete", "plugin", "search", ""},
 			// ":4" is the value of the ShellCompDirectiveNoFileComp
 			expected: "_activeHelp_ Command help: Search for available plugins\n" +
-				"_activeHelp_ " + compNoMoreArgsMsg + "\n:4\n",
+				"_activeHelp_ You can optionally enter keywords to search for in the plugin names and descriptions\n:4\n",
 		},
 	}
 
@@ -9,6 +9,8 @@ const (
 	PluginStatusInstalled       = "installed"
 	PluginStatusNotInstalled    = "not installed"
 	PluginStatusUpdateAvailable = "update available"
+	PluginStatusPinned          = "pinned"
+	PluginStatusSideBySide      = "side-by-side"
 	PluginScopeStandalone       = "Standalone"
 	PluginScopeContext          = "Context"
 )
@@ -37,3 +39,13 @@ const CoreName = "core"
 
 // CommandTypePlugin represents the command type is plugin
 const CommandTypePlugin = "plugin"
+
+// CommandTypeAlias represents the command type is a user-defined command alias
+const CommandTypeAlias = "alias"
+
+// AliasCmdGroup is the command group of the user-defined command aliases
+const AliasCmdGroup = "Alias"
+
+// AdHocPluginSourcePrefix prefixes the discovery source recorded for plugins installed
+// directly from an OCI image or a URL instead of from a discovery source
+const AdHocPluginSourcePrefix = "ad-hoc:"
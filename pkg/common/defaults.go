@@ -30,4 +30,9 @@ const (
 	// the inventory of the discovery will be downloaded and stored.
 	// It should be used as a sub-directory of the cache directory (DefaultCacheDir).
 	PluginInventoryDirName = "plugin_inventory"
+
+	// PluginDownloadCacheDirName is the name of the directory where the downloaded plugin
+	// binaries are cached by digest so that they can be installed again without downloading them.
+	// It should be used as a sub-directory of the cache directory (DefaultCacheDir).
+	PluginDownloadCacheDirName = "plugin_downloads"
 )
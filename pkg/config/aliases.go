@@ -0,0 +1,149 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+// dataStoreCommandAliasesKey is the data store key under which the user-defined
+// command aliases are stored, as a map of alias names to commands
+const dataStoreCommandAliasesKey = "commandAliases"
+
+// CommandAlias is a user-defined shortcut for a command of the CLI
+type CommandAlias struct {
+	// Name is the name of the alias as typed by the user
+	Name string `json:"name" yaml:"name"`
+	// Command is the command, along with its arguments and flags, that the alias stands for
+	Command string `json:"command" yaml:"command"`
+}
+
+// Args returns the arguments of the command the alias stands for
+func (a *CommandAlias) Args() []string {
+	args, _ := SplitCommandLine(a.Command)
+	return args
+}
+
+// getCommandAliasMap returns the commands of the user-defined command aliases, by name
+func getCommandAliasMap() map[string]string {
+	commands := map[string]string{}
+	// An error means no alias was ever set
+	_ = datastore.GetDataStoreValue(dataStoreCommandAliasesKey, &commands)
+	return commands
+}
+
+// GetCommandAliases returns the user-defined command aliases sorted by name
+func GetCommandAliases() []CommandAlias {
+	commands := getCommandAliasMap()
+	aliases := make([]CommandAlias, 0, len(commands))
+	for name, command := range commands {
+		aliases = append(aliases, CommandAlias{Name: name, Command: command})
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Name < aliases[j].Name
+	})
+	return aliases
+}
+
+// GetCommandAlias returns the user-defined command alias with the given name, if any
+func GetCommandAlias(name string) *CommandAlias {
+	aliases := GetCommandAliases()
+	for i := range aliases {
+		if aliases[i].Name == name {
+			return &aliases[i]
+		}
+	}
+	return nil
+}
+
+// SetCommandAlias creates or replaces the command alias with the given name
+func SetCommandAlias(name, command string) error {
+	if err := ValidateCommandAliasName(name); err != nil {
+		return err
+	}
+	args, err := SplitCommandLine(command)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.Errorf("the command of alias '%s' cannot be empty", name)
+	}
+
+	commands := getCommandAliasMap()
+	commands[name] = command
+	return errors.Wrap(datastore.SetDataStoreValue(dataStoreCommandAliasesKey, commands), "unable to save the command alias")
+}
+
+// DeleteCommandAlias deletes the command alias with the given name
+func DeleteCommandAlias(name string) error {
+	commands := getCommandAliasMap()
+	if _, exists := commands[name]; !exists {
+		return errors.Errorf("alias '%s' does not exist", name)
+	}
+	delete(commands, name)
+	if len(commands) == 0 {
+		return errors.Wrap(datastore.DeleteDataStoreValue(dataStoreCommandAliasesKey), "unable to delete the command alias")
+	}
+	return errors.Wrap(datastore.SetDataStoreValue(dataStoreCommandAliasesKey, commands), "unable to delete the command alias")
+}
+
+// ValidateCommandAliasName checks that the name can be used as a command name
+func ValidateCommandAliasName(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return errors.Errorf("invalid alias name '%s'. An alias name cannot be empty, start with '-' or contain spaces", name)
+	}
+	return nil
+}
+
+// SplitCommandLine splits a command line into arguments the way a shell would,
+// supporting single quotes, double quotes and backslash escapes.
+func SplitCommandLine(commandLine string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range commandLine {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.Errorf("unterminated quote or escape in '%s'", commandLine)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+func TestCommandAliases(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	t.Setenv("TANZU_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("TANZU_CONFIG_NEXT_GEN", filepath.Join(dir, "config-ng.yaml"))
+	t.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(dir, ".data-store.yaml"))
+
+	assert.Empty(GetCommandAliases())
+	assert.Nil(GetCommandAlias("kcl"))
+
+	assert.Nil(SetCommandAlias("kcl", "kubernetes cluster list --all-namespaces"))
+	assert.Nil(SetCommandAlias("ctx", "context use"))
+	assert.Equal([]CommandAlias{
+		{Name: "ctx", Command: "context use"},
+		{Name: "kcl", Command: "kubernetes cluster list --all-namespaces"},
+	}, GetCommandAliases())
+	assert.Equal([]string{"kubernetes", "cluster", "list", "--all-namespaces"}, GetCommandAlias("kcl").Args())
+
+	// The aliases are stored in the data store and not with the feature flags of the CLI configuration
+	var commands map[string]string
+	assert.Nil(datastore.GetDataStoreValue(dataStoreCommandAliasesKey, &commands))
+	assert.Equal("context use", commands["ctx"])
+	features, _ := config.GetAllFeatureFlags()
+	assert.Empty(features["command-aliases"])
+
+	// An existing alias is replaced
+	assert.Nil(SetCommandAlias("kcl", "kubernetes cluster list"))
+	assert.Equal("kubernetes cluster list", GetCommandAlias("kcl").Command)
+
+	assert.NotNil(SetCommandAlias("-kcl", "kubernetes cluster list"))
+	assert.NotNil(SetCommandAlias("k cl", "kubernetes cluster list"))
+	assert.NotNil(SetCommandAlias("empty", "  "))
+
+	assert.Nil(DeleteCommandAlias("kcl"))
+	assert.NotNil(DeleteCommandAlias("kcl"))
+	assert.Nil(DeleteCommandAlias("ctx"))
+	assert.Empty(GetCommandAliases())
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		commandLine string
+		expected    []string
+		err         bool
+	}{
+		{commandLine: "", expected: nil},
+		{commandLine: "plugin list", expected: []string{"plugin", "list"}},
+		{commandLine: "  plugin   list  ", expected: []string{"plugin", "list"}},
+		{commandLine: `context create --endpoint "https://my server"`, expected: []string{"context", "create", "--endpoint", "https://my server"}},
+		{commandLine: `config set env.NAME 'a "quoted" value'`, expected: []string{"config", "set", "env.NAME", `a "quoted" value`}},
+		{commandLine: `plugin search --name a\ b ""`, expected: []string{"plugin", "search", "--name", "a b", ""}},
+		{commandLine: `plugin "list`, err: true},
+		{commandLine: `plugin list\`, err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.commandLine, func(t *testing.T) {
+			args, err := SplitCommandLine(tc.commandLine)
+			if tc.err {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, args)
+		})
+	}
+}
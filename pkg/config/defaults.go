@@ -143,13 +143,32 @@ func GetAdditionalTestDiscoveryImages() []string {
 }
 
 // GetTrustedArtifactLocations returns the list of trusted URI prefixes that can
-// be trusted for downloading the CLIPlugins. Currently, this includes only the
-// "tanzu-cli-advanced-plugins" GCP bucket where TMC plugins are stored. Other
-// exceptions can be added as and when necessary.
+// be trusted for downloading the CLIPlugins. This includes the
+// "tanzu-cli-advanced-plugins" GCP bucket where TMC plugins are stored and the
+// servers of the configured HTTPS discovery sources. The servers of HTTP discovery
+// sources are not trusted as the plugin binaries could be tampered with in transit.
+// Other exceptions can be added as and when necessary.
 func GetTrustedArtifactLocations() []string {
 	trustedLocations := []string{
 		DefaultTMCPluginsArtifactRepository,
 	}
 
+	// The plugins of an HTTPS discovery source can be downloaded from the same server
+	discoveries, err := configlib.GetCLIDiscoverySources()
+	if err == nil {
+		for _, discovery := range discoveries {
+			if discovery.REST == nil {
+				continue
+			}
+			endpoint := discovery.REST.Endpoint
+			if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+				endpoint = "https://" + endpoint
+			}
+			if u, err := url.ParseRequestURI(endpoint); err == nil && u.Scheme == "https" && u.Host != "" {
+				trustedLocations = append(trustedLocations, u.Scheme+"://"+u.Host+"/")
+			}
+		}
+	}
+
 	return trustedLocations
 }
@@ -93,6 +93,39 @@ var _ = Describe("defaults test cases", func() {
 				Expect(trustedRegis).Should(ContainElement(testHost1))
 				Expect(trustedRegis).Should(ContainElement(testHost2))
 			})
+			It("trusted artifact locations should include the server of each configured HTTPS discovery source", func() {
+				err = configlib.SetCLIDiscoverySources([]types.PluginDiscovery{
+					{
+						REST: &types.GenericRESTDiscovery{
+							Name:     "rest1",
+							Endpoint: "https://plugins.example.com:8443",
+							BasePath: "/v1alpha1/cli/plugins",
+						},
+					},
+					{
+						REST: &types.GenericRESTDiscovery{
+							Name:     "rest2",
+							Endpoint: "api.example.org",
+							BasePath: "inventory.json",
+						},
+					},
+					{
+						REST: &types.GenericRESTDiscovery{
+							Name:     "rest3",
+							Endpoint: "http://insecure.example.com",
+							BasePath: "inventory.json",
+						},
+					},
+				})
+				Expect(err).To(BeNil())
+
+				artLocations := GetTrustedArtifactLocations()
+				Expect(artLocations).Should(ContainElement(DefaultTMCPluginsArtifactRepository))
+				Expect(artLocations).Should(ContainElement("https://plugins.example.com:8443/"))
+				Expect(artLocations).Should(ContainElement("https://api.example.org/"))
+				Expect(artLocations).ShouldNot(ContainElement("http://insecure.example.com/"))
+				Expect(artLocations).ShouldNot(ContainElement("https://insecure.example.com/"))
+			})
 		})
 		It("trusted registries should include hostname of additional discoveries for test if provided", func() {
 			oldValue := os.Getenv(constants.ConfigVariableAdditionalDiscoveryForTesting)
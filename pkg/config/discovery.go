@@ -4,10 +4,17 @@
 package config
 
 import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
 )
 
 func PopulateDefaultCentralDiscovery(force bool) error {
@@ -29,3 +36,100 @@ func PopulateDefaultCentralDiscovery(force bool) error {
 	}
 	return nil
 }
+
+// dataStoreDiscoverySourcePrioritiesKey is the data store key under which the priorities
+// of the discovery sources are stored
+const dataStoreDiscoverySourcePrioritiesKey = "discoverySourcePriorities"
+
+// DiscoveryConflictPolicy specifies how the entries of a plugin or plugin group
+// found in more than one discovery source are combined
+type DiscoveryConflictPolicy string
+
+const (
+	// DiscoveryConflictPolicyMerge merges the versions found in all the discovery sources.
+	// When the same version is found in several sources, the one of the source with the
+	// highest priority is used.
+	DiscoveryConflictPolicyMerge DiscoveryConflictPolicy = "merge"
+	// DiscoveryConflictPolicyFirstWins only uses the entry of the discovery source with the
+	// highest priority and ignores the entries of the other sources
+	DiscoveryConflictPolicyFirstWins DiscoveryConflictPolicy = "first-wins"
+	// DiscoveryConflictPolicyError reports an error when an entry is found in several discovery sources
+	DiscoveryConflictPolicyError DiscoveryConflictPolicy = "error"
+)
+
+// GetDiscoveryConflictPolicy returns the policy to use for plugins and plugin groups found in more
+// than one discovery source, as specified by the TANZU_CLI_PLUGIN_DISCOVERY_CONFLICT_POLICY variable.
+// The default policy is to merge the entries.
+func GetDiscoveryConflictPolicy() (DiscoveryConflictPolicy, error) {
+	policy := DiscoveryConflictPolicy(strings.ToLower(strings.TrimSpace(os.Getenv(constants.PluginDiscoveryConflictPolicy))))
+	switch policy {
+	case "":
+		return DiscoveryConflictPolicyMerge, nil
+	case DiscoveryConflictPolicyMerge, DiscoveryConflictPolicyFirstWins, DiscoveryConflictPolicyError:
+		return policy, nil
+	}
+	return "", errors.Errorf("invalid value '%s' for %s. Valid values are %s, %s and %s", policy, constants.PluginDiscoveryConflictPolicy,
+		DiscoveryConflictPolicyMerge, DiscoveryConflictPolicyFirstWins, DiscoveryConflictPolicyError)
+}
+
+// GetDiscoverySourcePriorities returns the priorities set for the discovery sources, by name
+func GetDiscoverySourcePriorities() map[string]int {
+	priorities := map[string]int{}
+	// An error means no priority was ever set
+	_ = datastore.GetDataStoreValue(dataStoreDiscoverySourcePrioritiesKey, &priorities)
+	return priorities
+}
+
+// GetDiscoverySourcePriority returns the priority of the discovery source with the given name.
+// A discovery source without an explicit priority has a priority of 0.
+func GetDiscoverySourcePriority(name string) int {
+	return GetDiscoverySourcePriorities()[name]
+}
+
+// SetDiscoverySourcePriority sets the priority of the discovery source with the given name.
+// The discovery sources with a higher priority are searched first.
+func SetDiscoverySourcePriority(name string, priority int) error {
+	priorities := GetDiscoverySourcePriorities()
+	if priority == 0 {
+		delete(priorities, name)
+	} else {
+		priorities[name] = priority
+	}
+	return datastore.SetDataStoreValue(dataStoreDiscoverySourcePrioritiesKey, priorities)
+}
+
+// DeleteDiscoverySourcePriority removes the priority of the discovery source with the given name
+func DeleteDiscoverySourcePriority(name string) error {
+	priorities := GetDiscoverySourcePriorities()
+	if _, exists := priorities[name]; !exists {
+		return nil
+	}
+	delete(priorities, name)
+	return datastore.SetDataStoreValue(dataStoreDiscoverySourcePrioritiesKey, priorities)
+}
+
+// SortDiscoverySourcesByPriority sorts the discovery sources from the highest to the lowest priority.
+// Discovery sources of the same priority keep their relative order.
+func SortDiscoverySourcesByPriority(sources []configtypes.PluginDiscovery) {
+	priorities := GetDiscoverySourcePriorities()
+	if len(priorities) == 0 {
+		return
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return priorities[discoverySourceName(sources[i])] > priorities[discoverySourceName(sources[j])]
+	})
+}
+
+func discoverySourceName(source configtypes.PluginDiscovery) string {
+	switch {
+	case source.OCI != nil:
+		return source.OCI.Name
+	case source.REST != nil:
+		return source.REST.Name
+	case source.Local != nil:
+		return source.Local.Name
+	case source.Kubernetes != nil:
+		return source.Kubernetes.Name
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestDiscoverySourcePriorities(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(t.TempDir(), ".data-store.yaml"))
+
+	assert.Empty(GetDiscoverySourcePriorities())
+	assert.Equal(0, GetDiscoverySourcePriority("default"))
+
+	assert.Nil(SetDiscoverySourcePriority("mirror", 10))
+	assert.Nil(SetDiscoverySourcePriority("fallback", -5))
+	assert.Equal(10, GetDiscoverySourcePriority("mirror"))
+	assert.Equal(map[string]int{"mirror": 10, "fallback": -5}, GetDiscoverySourcePriorities())
+
+	sources := []configtypes.PluginDiscovery{
+		{OCI: &configtypes.OCIDiscovery{Name: "fallback"}},
+		{OCI: &configtypes.OCIDiscovery{Name: "default"}},
+		{REST: &configtypes.GenericRESTDiscovery{Name: "other"}},
+		{OCI: &configtypes.OCIDiscovery{Name: "mirror"}},
+	}
+	SortDiscoverySourcesByPriority(sources)
+	var names []string
+	for _, s := range sources {
+		names = append(names, discoverySourceName(s))
+	}
+	// Sources of the same priority keep their order
+	assert.Equal([]string{"mirror", "default", "other", "fallback"}, names)
+
+	// A priority of 0 is the default and is not stored
+	assert.Nil(SetDiscoverySourcePriority("fallback", 0))
+	assert.Equal(map[string]int{"mirror": 10}, GetDiscoverySourcePriorities())
+
+	assert.Nil(DeleteDiscoverySourcePriority("mirror"))
+	assert.Nil(DeleteDiscoverySourcePriority("mirror"))
+	assert.Empty(GetDiscoverySourcePriorities())
+}
+
+func TestGetDiscoveryConflictPolicy(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected DiscoveryConflictPolicy
+		err      bool
+	}{
+		{value: "", expected: DiscoveryConflictPolicyMerge},
+		{value: "merge", expected: DiscoveryConflictPolicyMerge},
+		{value: "First-Wins", expected: DiscoveryConflictPolicyFirstWins},
+		{value: " error ", expected: DiscoveryConflictPolicyError},
+		{value: "last-wins", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Setenv(constants.PluginDiscoveryConflictPolicy, tc.value)
+			policy, err := GetDiscoveryConflictPolicy()
+			if tc.err {
+				assert.ErrorContains(t, err, "invalid value 'last-wins'")
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, policy)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+// dataStoreDiscoverySourceTrustKey is the data store key under which the signature
+// verification configuration of the discovery sources is stored
+const dataStoreDiscoverySourceTrustKey = "discoverySourceTrust"
+
+// DiscoverySourceTrust specifies how the signature of the plugin inventory image of a
+// discovery source is verified. The signature can be verified using one of a list of
+// public keys, or using the certificate issued to a specific identity. When no trust
+// configuration is set for a discovery source, the public key specified by
+// TANZU_CLI_PLUGIN_DISCOVERY_IMAGE_SIGNATURE_PUBLIC_KEY_PATH or the public keys
+// embedded in the CLI are used.
+type DiscoverySourceTrust struct {
+	// PublicKeys are the paths or key references of the public keys accepted for
+	// the signature; the signature is valid if it can be verified with any of them
+	PublicKeys []string `json:"publicKeys,omitempty" yaml:"publicKeys,omitempty"`
+	// CertificateIdentity is the identity, e.g., an email address or a URI,
+	// expected in the certificate of a keyless signature
+	CertificateIdentity string `json:"certificateIdentity,omitempty" yaml:"certificateIdentity,omitempty"`
+	// CertificateOIDCIssuer is the OIDC issuer expected in the certificate of a keyless signature
+	CertificateOIDCIssuer string `json:"certificateOIDCIssuer,omitempty" yaml:"certificateOIDCIssuer,omitempty"`
+	// CertificateChain is the path to the PEM encoded root, and optionally intermediate,
+	// CA certificates used to verify the certificate of a keyless signature
+	CertificateChain string `json:"certificateChain,omitempty" yaml:"certificateChain,omitempty"`
+	// SkipTransparencyLog disables the verification of a keyless signature against the Rekor
+	// transparency log and of the certificate against the certificate transparency log, e.g.,
+	// for a discovery source signed by a private certificate authority in an air-gapped environment
+	SkipTransparencyLog bool `json:"skipTransparencyLog,omitempty" yaml:"skipTransparencyLog,omitempty"`
+}
+
+// IsEmpty returns true if the trust configuration does not specify anything
+func (t *DiscoverySourceTrust) IsEmpty() bool {
+	return len(t.PublicKeys) == 0 && !t.UsesCertificate() && !t.SkipTransparencyLog
+}
+
+// UsesCertificate returns true if the signature is to be verified using a certificate
+func (t *DiscoverySourceTrust) UsesCertificate() bool {
+	return t.CertificateIdentity != "" || t.CertificateOIDCIssuer != "" || t.CertificateChain != ""
+}
+
+// Validate checks that the trust configuration is complete and consistent
+func (t *DiscoverySourceTrust) Validate() error {
+	if !t.UsesCertificate() {
+		if t.SkipTransparencyLog {
+			return errors.New("the transparency log can only be skipped for the certificate of a keyless signature")
+		}
+		return nil
+	}
+	if len(t.PublicKeys) > 0 {
+		return errors.New("public keys and certificate requirements cannot be specified together")
+	}
+	if t.CertificateIdentity == "" || t.CertificateOIDCIssuer == "" || t.CertificateChain == "" {
+		return errors.New("the certificate identity, the certificate OIDC issuer and the certificate chain must all be specified")
+	}
+	return nil
+}
+
+// discoverySourceTrustEntry binds a signature verification configuration to the location
+// of the discovery source it was configured for, so that it does not apply to another
+// discovery source configured later with the same name
+type discoverySourceTrustEntry struct {
+	Location string               `json:"location" yaml:"location"`
+	Trust    DiscoverySourceTrust `json:"trust" yaml:"trust"`
+}
+
+func getDiscoverySourceTrusts() map[string]discoverySourceTrustEntry {
+	trusts := map[string]discoverySourceTrustEntry{}
+	// An error means no trust configuration was ever set
+	_ = datastore.GetDataStoreValue(dataStoreDiscoverySourceTrustKey, &trusts)
+	return trusts
+}
+
+// GetDiscoverySourceTrust returns the signature verification configuration of the discovery
+// source, or nil if the discovery source does not have one. A configuration set for another
+// location of a discovery source with the same name is ignored.
+func GetDiscoverySourceTrust(source configtypes.PluginDiscovery) *DiscoverySourceTrust {
+	entry, exists := getDiscoverySourceTrusts()[discoverySourceName(source)]
+	if !exists || entry.Location != discoverySourceLocation(source) {
+		return nil
+	}
+	return &entry.Trust
+}
+
+// SetDiscoverySourceTrust sets the signature verification configuration of the discovery
+// source. An empty configuration removes the existing one.
+func SetDiscoverySourceTrust(source configtypes.PluginDiscovery, trust *DiscoverySourceTrust) error {
+	name := discoverySourceName(source)
+	if trust == nil || trust.IsEmpty() {
+		return DeleteDiscoverySourceTrust(name)
+	}
+	if err := trust.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid signature verification configuration for discovery source %q", name)
+	}
+	trusts := getDiscoverySourceTrusts()
+	trusts[name] = discoverySourceTrustEntry{Location: discoverySourceLocation(source), Trust: *trust}
+	return datastore.SetDataStoreValue(dataStoreDiscoverySourceTrustKey, trusts)
+}
+
+// DeleteDiscoverySourceTrust removes the signature verification configuration of the
+// discovery source with the given name
+func DeleteDiscoverySourceTrust(name string) error {
+	trusts := getDiscoverySourceTrusts()
+	if _, exists := trusts[name]; !exists {
+		return nil
+	}
+	delete(trusts, name)
+	return datastore.SetDataStoreValue(dataStoreDiscoverySourceTrustKey, trusts)
+}
+
+func discoverySourceLocation(source configtypes.PluginDiscovery) string {
+	switch {
+	case source.OCI != nil:
+		return source.OCI.Image
+	case source.REST != nil:
+		return source.REST.Endpoint + "/" + source.REST.BasePath
+	case source.Local != nil:
+		return source.Local.Path
+	}
+	return ""
+}
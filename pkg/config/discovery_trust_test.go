@@ -0,0 +1,61 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestDiscoverySourceTrust(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(t.TempDir(), ".data-store.yaml"))
+
+	defaultSource := configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "default", Image: "registry.example.com/tanzu/plugin-inventory:latest"}}
+	internalSource := configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "internal", Image: "registry.example.com/internal/plugin-inventory:latest"}}
+	assert.Nil(GetDiscoverySourceTrust(defaultSource))
+
+	keys := &DiscoverySourceTrust{PublicKeys: []string{"/keys/cosign.pub", "/keys/cosign-next.pub"}}
+	assert.Nil(SetDiscoverySourceTrust(defaultSource, keys))
+	cert := &DiscoverySourceTrust{
+		CertificateIdentity:   "release@example.com",
+		CertificateOIDCIssuer: "https://issuer.example.com",
+		CertificateChain:      "/certs/chain.pem",
+	}
+	assert.Nil(SetDiscoverySourceTrust(internalSource, cert))
+	assert.Equal(keys, GetDiscoverySourceTrust(defaultSource))
+	assert.Equal(cert, GetDiscoverySourceTrust(internalSource))
+	assert.True(cert.UsesCertificate())
+	assert.False(keys.UsesCertificate())
+
+	// The configuration does not apply to another discovery source with the same name
+	otherSource := configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "internal", Image: "registry.example.com/other/plugin-inventory:latest"}}
+	assert.Nil(GetDiscoverySourceTrust(otherSource))
+
+	// Invalid configurations are not saved
+	err := SetDiscoverySourceTrust(internalSource, &DiscoverySourceTrust{CertificateIdentity: "release@example.com"})
+	assert.ErrorContains(err, "must all be specified")
+	err = SetDiscoverySourceTrust(internalSource, &DiscoverySourceTrust{PublicKeys: []string{"/keys/cosign.pub"}, CertificateIdentity: "release@example.com"})
+	assert.ErrorContains(err, "cannot be specified together")
+	err = SetDiscoverySourceTrust(internalSource, &DiscoverySourceTrust{PublicKeys: []string{"/keys/cosign.pub"}, SkipTransparencyLog: true})
+	assert.ErrorContains(err, "can only be skipped for the certificate of a keyless signature")
+	assert.Equal(cert, GetDiscoverySourceTrust(internalSource))
+
+	// The transparency log can be explicitly skipped for a keyless signature
+	cert.SkipTransparencyLog = true
+	assert.Nil(SetDiscoverySourceTrust(internalSource, cert))
+	assert.True(GetDiscoverySourceTrust(internalSource).SkipTransparencyLog)
+
+	// An empty configuration removes the existing one
+	assert.Nil(SetDiscoverySourceTrust(defaultSource, &DiscoverySourceTrust{}))
+	assert.Nil(GetDiscoverySourceTrust(defaultSource))
+
+	assert.Nil(DeleteDiscoverySourceTrust("internal"))
+	assert.Nil(DeleteDiscoverySourceTrust("internal"))
+	assert.Nil(GetDiscoverySourceTrust(internalSource))
+}
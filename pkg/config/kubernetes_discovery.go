@@ -0,0 +1,86 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+// dataStoreKubernetesDiscoveryScopeKey is the data store key under which the scope
+// of the kubernetes plugin discovery of the contexts is stored
+const dataStoreKubernetesDiscoveryScopeKey = "kubernetesDiscoveryScopes"
+
+// KubernetesDiscoveryScope restricts the CLIPlugin resources that the kubernetes
+// discovery of a context looks for in the cluster. Without a scope, the CLIPlugin
+// resources of all namespaces are discovered.
+type KubernetesDiscoveryScope struct {
+	// Namespaces are the namespaces in which to look for CLIPlugin resources
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	// LabelSelector is the label selector the CLIPlugin resources must match, e.g., "env=prod,tier!=test"
+	LabelSelector string `json:"labelSelector,omitempty" yaml:"labelSelector,omitempty"`
+}
+
+// IsEmpty returns true if the scope does not restrict the discovery
+func (s *KubernetesDiscoveryScope) IsEmpty() bool {
+	return s == nil || (len(s.Namespaces) == 0 && s.LabelSelector == "")
+}
+
+// Validate checks that the namespaces and the label selector of the scope are valid
+func (s *KubernetesDiscoveryScope) Validate() error {
+	for _, namespace := range s.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return errors.Errorf("invalid namespace %q: %s", namespace, errs[0])
+		}
+	}
+	if _, err := labels.Parse(s.LabelSelector); err != nil {
+		return errors.Wrapf(err, "invalid label selector %q", s.LabelSelector)
+	}
+	return nil
+}
+
+func getKubernetesDiscoveryScopes() map[string]KubernetesDiscoveryScope {
+	scopes := map[string]KubernetesDiscoveryScope{}
+	// An error means no scope was ever set
+	_ = datastore.GetDataStoreValue(dataStoreKubernetesDiscoveryScopeKey, &scopes)
+	return scopes
+}
+
+// GetKubernetesDiscoveryScope returns the scope of the kubernetes plugin discovery of the
+// context with the given name, or nil if the discovery of the context is not restricted
+func GetKubernetesDiscoveryScope(contextName string) *KubernetesDiscoveryScope {
+	scope, exists := getKubernetesDiscoveryScopes()[contextName]
+	if !exists {
+		return nil
+	}
+	return &scope
+}
+
+// SetKubernetesDiscoveryScope sets the scope of the kubernetes plugin discovery of the
+// context with the given name. An empty scope removes the existing one.
+func SetKubernetesDiscoveryScope(contextName string, scope *KubernetesDiscoveryScope) error {
+	if scope.IsEmpty() {
+		return DeleteKubernetesDiscoveryScope(contextName)
+	}
+	if err := scope.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid plugin discovery scope for context %q", contextName)
+	}
+	scopes := getKubernetesDiscoveryScopes()
+	scopes[contextName] = *scope
+	return datastore.SetDataStoreValue(dataStoreKubernetesDiscoveryScopeKey, scopes)
+}
+
+// DeleteKubernetesDiscoveryScope removes the scope of the kubernetes plugin discovery
+// of the context with the given name
+func DeleteKubernetesDiscoveryScope(contextName string) error {
+	scopes := getKubernetesDiscoveryScopes()
+	if _, exists := scopes[contextName]; !exists {
+		return nil
+	}
+	delete(scopes, contextName)
+	return datastore.SetDataStoreValue(dataStoreKubernetesDiscoveryScopeKey, scopes)
+}
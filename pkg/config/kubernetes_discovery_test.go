@@ -0,0 +1,41 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKubernetesDiscoveryScope(t *testing.T) {
+	assert := assert.New(t)
+	t.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(t.TempDir(), ".data-store.yaml"))
+
+	assert.Nil(GetKubernetesDiscoveryScope("mgmt"))
+	assert.True(GetKubernetesDiscoveryScope("mgmt").IsEmpty())
+
+	scope := &KubernetesDiscoveryScope{Namespaces: []string{"tanzu-cli-system", "tkg-system"}, LabelSelector: "env=prod,tier!=test"}
+	assert.Nil(SetKubernetesDiscoveryScope("mgmt", scope))
+	labelsOnly := &KubernetesDiscoveryScope{LabelSelector: "app in (tkg, tap)"}
+	assert.Nil(SetKubernetesDiscoveryScope("workload", labelsOnly))
+	assert.Equal(scope, GetKubernetesDiscoveryScope("mgmt"))
+	assert.Equal(labelsOnly, GetKubernetesDiscoveryScope("workload"))
+
+	// Invalid scopes are not saved
+	err := SetKubernetesDiscoveryScope("mgmt", &KubernetesDiscoveryScope{Namespaces: []string{"Invalid_Namespace"}})
+	assert.ErrorContains(err, `invalid namespace "Invalid_Namespace"`)
+	err = SetKubernetesDiscoveryScope("mgmt", &KubernetesDiscoveryScope{LabelSelector: "env in prod"})
+	assert.ErrorContains(err, `invalid label selector "env in prod"`)
+	assert.Equal(scope, GetKubernetesDiscoveryScope("mgmt"))
+
+	// An empty scope removes the existing one
+	assert.Nil(SetKubernetesDiscoveryScope("mgmt", &KubernetesDiscoveryScope{}))
+	assert.Nil(GetKubernetesDiscoveryScope("mgmt"))
+
+	assert.Nil(DeleteKubernetesDiscoveryScope("workload"))
+	assert.Nil(DeleteKubernetesDiscoveryScope("workload"))
+	assert.Nil(GetKubernetesDiscoveryScope("workload"))
+}
@@ -0,0 +1,18 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// IsOfflineMode returns true if the CLI must not access the network, as requested
+// using the --offline global flag or the TANZU_CLI_OFFLINE variable
+func IsOfflineMode() bool {
+	offline, _ := strconv.ParseBool(os.Getenv(constants.OfflineMode))
+	return offline
+}
@@ -35,6 +35,15 @@ const (
 	// For testing, it can be overridden using the environment variable TANZU_CLI_PLUGIN_DB_CACHE_TTL_SECONDS.
 	DefaultInventoryRefreshTTLSeconds = 30 * 60 // 30 minutes
 
+	// DefaultKubernetesDiscoveryCacheTTLSeconds is the interval in seconds during which the CLIPlugin resources
+	// cached by a kubernetes discovery are used without checking the cluster for changes.
+	// It can be overridden using the environment variable TANZU_CLI_KUBERNETES_DISCOVERY_CACHE_TTL_SECONDS.
+	DefaultKubernetesDiscoveryCacheTTLSeconds = 5 * 60 // 5 minutes
+
+	// DefaultDeprecatedPluginWarningDelayHours is the minimum delay in hours between two deprecation
+	// checks done when the same plugin binary is invoked
+	DefaultDeprecatedPluginWarningDelayHours = 24
+
 	// TanzuContextPluginDiscoveryEndpointPath specifies the default plugin discovery endpoint path
 	// Note: This path value needs to be updated once the Tanzu context backend support the context-scoped
 	// plugin discovery and the endpoint value gets finalized
@@ -44,4 +53,16 @@ const (
 
 	// CentralConfigFileName is the name of the central config file
 	CentralConfigFileName = "central_config.yaml"
+
+	// DefaultPluginInstallConcurrency is the default maximum number of plugins downloaded in parallel.
+	// It can be overridden using the environment variable TANZU_CLI_PLUGIN_INSTALL_CONCURRENCY.
+	DefaultPluginInstallConcurrency = 4
+
+	// DefaultPluginDownloadCacheMaxSizeMB is the default maximum size in megabytes of the cache of downloaded plugin binaries.
+	// It can be overridden using the environment variable TANZU_CLI_PLUGIN_DOWNLOAD_CACHE_MAX_SIZE_MB.
+	DefaultPluginDownloadCacheMaxSizeMB = 1024
+
+	// DefaultPluginHookTimeoutSeconds is the default maximum number of seconds a lifecycle hook of a plugin is allowed to run.
+	// It can be overridden using the environment variable TANZU_CLI_PLUGIN_HOOK_TIMEOUT_SECONDS.
+	DefaultPluginHookTimeoutSeconds = 30
 )
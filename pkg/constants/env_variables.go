@@ -47,9 +47,16 @@ const (
 	// Change the default value of the plugin inventory cache TTL
 	ConfigVariablePluginDBCacheTTLSeconds = "TANZU_CLI_PLUGIN_DB_CACHE_TTL_SECONDS"
 
+	// Change the default value of the TTL of the CLIPlugin resources cached by the kubernetes discoveries
+	ConfigVariableKubernetesDiscoveryCacheTTLSeconds = "TANZU_CLI_KUBERNETES_DISCOVERY_CACHE_TTL_SECONDS"
+
 	// ConfigVariablePluginDBCacheRefreshThresholdSeconds Change the default value of db cache refresh threshold
 	ConfigVariablePluginDBCacheRefreshThresholdSeconds = "TANZU_CLI_PLUGIN_DB_CACHE_REFRESH_THRESHOLD_SECONDS"
 
+	// ConfigVariableDeprecatedPluginWarningDelayHours Change the default value of the delay between the warnings
+	// printed when a deprecated plugin version is invoked
+	ConfigVariableDeprecatedPluginWarningDelayHours = "TANZU_CLI_DEPRECATED_PLUGIN_WARNING_DELAY_HOURS"
+
 	// ConfigVariableRecommendVersionDelayDays Change the default value of the delay between printing a recommended version message
 	ConfigVariableRecommendVersionDelayDays = "TANZU_CLI_RECOMMEND_VERSION_DELAY_DAYS"
 
@@ -102,4 +109,35 @@ const (
 	// TPHubEndpoint specifies hub endpoint for the Tanzu Platform
 	// This will be used as part of `tanzu login`
 	TPHubEndpoint = "TANZU_CLI_HUB_ENDPOINT"
+
+	// PluginInstallConcurrency specifies the maximum number of plugins that are downloaded and
+	// verified in parallel when installing multiple plugins, e.g., from a plugin group or a context
+	PluginInstallConcurrency = "TANZU_CLI_PLUGIN_INSTALL_CONCURRENCY"
+
+	// PluginDownloadCacheMaxSize specifies the maximum size in megabytes of the cache of downloaded
+	// plugin binaries. Setting it to 0 disables the cache.
+	PluginDownloadCacheMaxSize = "TANZU_CLI_PLUGIN_DOWNLOAD_CACHE_MAX_SIZE_MB"
+
+	// PluginHookTimeout specifies the maximum number of seconds a lifecycle hook of a plugin,
+	// e.g., pre-uninstall or pre-upgrade, is allowed to run before it is stopped
+	PluginHookTimeout = "TANZU_CLI_PLUGIN_HOOK_TIMEOUT_SECONDS"
+
+	// PluginVersions selects the versions of the plugins installed side by side to invoke instead of
+	// their default version, as a comma-separated list of NAME[@TARGET]=VERSION, e.g., "cluster=v1.2.0"
+	PluginVersions = "TANZU_CLI_PLUGIN_VERSIONS"
+
+	// RESTDiscoverySourceAuthPrefix is the prefix of the variables providing the credentials used to access
+	// an HTTP(S) discovery source. For a source named "my-repo", the bearer token is read from
+	// TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_TOKEN, or the basic authentication credentials from
+	// TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_USERNAME and TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_PASSWORD
+	RESTDiscoverySourceAuthPrefix = "TANZU_CLI_DISCOVERY_SOURCE_"
+
+	// PluginDiscoveryConflictPolicy specifies how a plugin or plugin group found in more than one
+	// discovery source is handled: "merge" (default), "first-wins" or "error"
+	PluginDiscoveryConflictPolicy = "TANZU_CLI_PLUGIN_DISCOVERY_CONFLICT_POLICY"
+
+	// OfflineMode prevents the CLI from accessing the network when set to true. The plugin inventories
+	// are only read from the cache and the operations requiring network access fail.
+	// It is also set by the --offline global flag.
+	OfflineMode = "TANZU_CLI_OFFLINE"
 )
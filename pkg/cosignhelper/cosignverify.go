@@ -5,15 +5,18 @@
 package cosignhelper
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -22,6 +25,7 @@ import (
 	"github.com/sigstore/cosign/v2/pkg/cosign/pkcs11key"
 	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
 	sigs "github.com/sigstore/cosign/v2/pkg/signature"
+	rekorclient "github.com/sigstore/rekor/pkg/generated/client"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -30,6 +34,9 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
+// defaultRekorURL is the URL of the public Rekor transparency log instance
+const defaultRekorURL = "https://rekor.sigstore.dev"
+
 // RegistryOptions registry options used while interacting with registry
 type RegistryOptions struct {
 	// CACertPaths is the path to CA certs for the registry endpoint.
@@ -46,6 +53,24 @@ type CosignVerifyOptions struct {
 	// PublicKeyPath is the path to custom public key to be used to verify the signature
 	// of the OCI image. If the path is empty, the CLI embedded public key would be used
 	PublicKeyPath string
+	// PublicKeyPaths are the paths to custom public keys to be used to verify the signature
+	// of the OCI image. The signature is valid if it can be verified using any of the keys.
+	// If set, PublicKeyPath is ignored.
+	PublicKeyPaths []string
+	// CertIdentity and CertOIDCIssuer are the identity and the OIDC issuer expected in the
+	// certificate of a keyless signature. If set, the signature is verified using the
+	// certificate attached to it instead of public keys.
+	CertIdentity   string
+	CertOIDCIssuer string
+	// CertChainPath is the path to the PEM encoded root, and optionally intermediate, CA
+	// certificates used to verify the certificate of a keyless signature
+	CertChainPath string
+	// IgnoreTransparencyLog disables the verification of a keyless signature against the
+	// Rekor transparency log and of its certificate against the certificate transparency log
+	IgnoreTransparencyLog bool
+	// RekorURL is the URL of the Rekor transparency log instance used to verify
+	// a keyless signature. If empty, the public Rekor instance is used.
+	RekorURL string
 	// RegistryOpts registry options used while interacting with registry
 	RegistryOpts *RegistryOptions
 }
@@ -70,17 +95,43 @@ func (vo *CosignVerifyOptions) Verify(ctx context.Context, images []string) erro
 	// Using Rekor Default URL and Rekor public Keys (downloaded from online by default) not be feasible for air-gapped environment
 	ignoreTlog := true
 
+	var rootCerts, intermediateCerts *x509.CertPool
+	var transparencyLogOpts *cosign.CheckOpts
 	switch {
-	// If PublicKeyPath is provided(custom public key) use it, else use the embedded public key
-	case vo.PublicKeyPath != "":
-		pubKey, err := sigs.PublicKeyFromKeyRefWithHashAlgo(ctx, vo.PublicKeyPath, crypto.SHA256)
+	// If certificate requirements are provided, the certificate attached to the signature is
+	// verified instead of using public keys, which is represented by a nil verifier
+	case vo.CertIdentity != "":
+		rootCerts, intermediateCerts, err = loadCertificateChain(vo.CertChainPath)
 		if err != nil {
-			return fmt.Errorf("loading custom public key: %w", err)
+			return err
 		}
-		pubKeys = append(pubKeys, pubKey)
-		pkcs11Key, ok := pubKey.(*pkcs11key.Key)
-		if ok {
-			defer pkcs11Key.Close()
+		// Unlike signatures made with a long-lived key, a keyless signature is only
+		// trustworthy if it was recorded in the transparency logs while its short-lived
+		// certificate was valid, unless the discovery source explicitly opts out
+		if !vo.IgnoreTransparencyLog {
+			transparencyLogOpts, err = newTransparencyLogCheckOpts(ctx, vo.RekorURL)
+			if err != nil {
+				return err
+			}
+		}
+		pubKeys = append(pubKeys, nil)
+
+	// If PublicKeyPaths or PublicKeyPath are provided(custom public keys) use them, else use the embedded public key
+	case len(vo.PublicKeyPaths) > 0 || vo.PublicKeyPath != "":
+		keyPaths := vo.PublicKeyPaths
+		if len(keyPaths) == 0 {
+			keyPaths = []string{vo.PublicKeyPath}
+		}
+		for _, keyPath := range keyPaths {
+			pubKey, err := sigs.PublicKeyFromKeyRefWithHashAlgo(ctx, keyPath, crypto.SHA256)
+			if err != nil {
+				return fmt.Errorf("loading custom public key %q: %w", keyPath, err)
+			}
+			pubKeys = append(pubKeys, pubKey)
+			pkcs11Key, ok := pubKey.(*pkcs11key.Key)
+			if ok {
+				defer pkcs11Key.Close()
+			}
 		}
 
 	default:
@@ -126,6 +177,19 @@ func (vo *CosignVerifyOptions) Verify(ctx context.Context, images []string) erro
 				IgnoreTlog:  ignoreTlog,
 				SigVerifier: verifier,
 			}
+			if verifier == nil {
+				co.RootCerts = rootCerts
+				co.IntermediateCerts = intermediateCerts
+				co.Identities = []cosign.Identity{{Subject: vo.CertIdentity, Issuer: vo.CertOIDCIssuer}}
+				if transparencyLogOpts != nil {
+					co.IgnoreTlog = false
+					co.RekorClient = transparencyLogOpts.RekorClient
+					co.RekorPubKeys = transparencyLogOpts.RekorPubKeys
+					co.CTLogPubKeys = transparencyLogOpts.CTLogPubKeys
+				} else {
+					co.IgnoreSCT = true
+				}
+			}
 
 			_, _, err = cosign.VerifyImageSignatures(ctx, ref, co)
 			if err == nil {
@@ -143,6 +207,66 @@ func (vo *CosignVerifyOptions) Verify(ctx context.Context, images []string) erro
 	return nil
 }
 
+// newTransparencyLogCheckOpts returns the options to verify a keyless signature against the
+// Rekor transparency log and its certificate against the certificate transparency log.
+// The public keys of the logs are obtained from the Sigstore TUF repository, or from the files
+// specified by the SIGSTORE_REKOR_PUBLIC_KEY and SIGSTORE_CT_LOG_PUBLIC_KEY_FILE environment variables.
+func newTransparencyLogCheckOpts(ctx context.Context, rekorURL string) (*cosign.CheckOpts, error) {
+	if rekorURL == "" {
+		rekorURL = defaultRekorURL
+	}
+	u, err := url.Parse(rekorURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid Rekor URL %q", rekorURL)
+	}
+	basePath := u.Path
+	if basePath == "" {
+		basePath = rekorclient.DefaultBasePath
+	}
+	rekorClient := rekorclient.NewHTTPClientWithConfig(strfmt.Default,
+		rekorclient.DefaultTransportConfig().WithHost(u.Host).WithBasePath(basePath).WithSchemes([]string{u.Scheme}))
+	rekorPubKeys, err := cosign.GetRekorPubs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting the Rekor public keys: %w", err)
+	}
+	ctLogPubKeys, err := cosign.GetCTLogPubs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting the certificate transparency log public keys: %w", err)
+	}
+	return &cosign.CheckOpts{
+		RekorClient:  rekorClient,
+		RekorPubKeys: rekorPubKeys,
+		CTLogPubKeys: ctLogPubKeys,
+	}, nil
+}
+
+// loadCertificateChain loads the root and intermediate CA certificates of the PEM encoded
+// certificate chain file. Self-signed certificates are considered roots.
+func loadCertificateChain(chainPath string) (*x509.CertPool, *x509.CertPool, error) {
+	pemCerts, err := os.ReadFile(chainPath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading the certificate chain from '%s'", chainPath)
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(pemCerts)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed parsing the certificate chain from '%s'", chainPath)
+	}
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("no certificate found in '%s'", chainPath)
+	}
+
+	rootCerts := x509.NewCertPool()
+	intermediateCerts := x509.NewCertPool()
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+			rootCerts.AddCert(cert)
+		} else {
+			intermediateCerts.AddCert(cert)
+		}
+	}
+	return rootCerts, intermediateCerts, nil
+}
+
 func (vo *CosignVerifyOptions) newHTTPTransport() (*http.Transport, error) {
 	var pool *x509.CertPool
 
@@ -0,0 +1,166 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cosignhelper
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testCertIdentity   = "release@example.com"
+	testCertOIDCIssuer = "https://issuer.example.com"
+)
+
+// oidcIssuerExtensionOID is the certificate extension used by Fulcio for the OIDC issuer
+var oidcIssuerExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+
+// signImageKeyless pushes a random image to the registry and signs it with a short-lived
+// certificate issued by a test CA to the identity, without any transparency log entry.
+// It returns the image reference and the path to the certificate chain of the CA.
+func signImageKeyless(t *testing.T, registryHost, identity string) (string, string) {
+	ref, err := name.ParseReference(registryHost+"/tanzu/plugin-inventory:latest", name.Insecure)
+	assert.Nil(t, err)
+	img, err := random.Image(1024, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, remote.Write(ref, img))
+	imgDigest, err := img.Digest()
+	assert.Nil(t, err)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	assert.Nil(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	assert.Nil(t, err)
+
+	signerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	signerTemplate := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       time.Now().Add(-time.Minute),
+		NotAfter:        time.Now().Add(10 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		EmailAddresses:  []string{identity},
+		ExtraExtensions: []pkix.Extension{{Id: oidcIssuerExtensionOID, Value: []byte(testCertOIDCIssuer)}},
+	}
+	signerDER, err := x509.CreateCertificate(rand.Reader, signerTemplate, caCert, signerKey.Public(), caKey)
+	assert.Nil(t, err)
+	signerCert, err := x509.ParseCertificate(signerDER)
+	assert.Nil(t, err)
+
+	digestRef := ref.Context().Digest(imgDigest.String())
+	sigPayload, err := payload.Cosign{Image: digestRef}.MarshalJSON()
+	assert.Nil(t, err)
+	hash := sha256.Sum256(sigPayload)
+	sig, err := ecdsa.SignASN1(rand.Reader, signerKey, hash[:])
+	assert.Nil(t, err)
+
+	certPEM, err := cryptoutils.MarshalCertificateToPEM(signerCert)
+	assert.Nil(t, err)
+	chainPEM, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{caCert})
+	assert.Nil(t, err)
+	ociSig, err := static.NewSignature(sigPayload, base64.StdEncoding.EncodeToString(sig), static.WithCertChain(certPEM, chainPEM))
+	assert.Nil(t, err)
+	se, err := ociremote.SignedEntity(digestRef)
+	assert.Nil(t, err)
+	se, err = mutate.AttachSignatureToEntity(se, ociSig)
+	assert.Nil(t, err)
+	assert.Nil(t, ociremote.WriteSignatures(digestRef.Repository, se))
+
+	chainPath := filepath.Join(t.TempDir(), "chain.pem")
+	assert.Nil(t, os.WriteFile(chainPath, chainPEM, 0600))
+	return ref.String(), chainPath
+}
+
+// writeTransparencyLogPublicKeys makes the public keys of the transparency logs available
+// without accessing the Sigstore TUF repository
+func writeTransparencyLogPublicKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(key.Public())
+	assert.Nil(t, err)
+	pubPath := filepath.Join(t.TempDir(), "tlog.pub")
+	assert.Nil(t, os.WriteFile(pubPath, pubPEM, 0600))
+	t.Setenv("SIGSTORE_REKOR_PUBLIC_KEY", pubPath)
+	t.Setenv("SIGSTORE_CT_LOG_PUBLIC_KEY_FILE", pubPath)
+}
+
+func TestVerifyKeylessSignature(t *testing.T) {
+	s := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer s.Close()
+	// The transparency log does not contain any entry
+	rekor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer rekor.Close()
+	u, err := url.Parse(s.URL)
+	assert.Nil(t, err)
+	image, chainPath := signImageKeyless(t, u.Host, testCertIdentity)
+	writeTransparencyLogPublicKeys(t)
+
+	newVerifier := func(identity string, ignoreTransparencyLog bool) *CosignVerifyOptions {
+		return &CosignVerifyOptions{
+			CertIdentity:          identity,
+			CertOIDCIssuer:        testCertOIDCIssuer,
+			CertChainPath:         chainPath,
+			IgnoreTransparencyLog: ignoreTransparencyLog,
+			RekorURL:              rekor.URL,
+			RegistryOpts:          &RegistryOptions{AllowInsecure: true},
+		}
+	}
+
+	// By default, a keyless signature must have been recorded in the transparency logs
+	err = newVerifier(testCertIdentity, false).Verify(context.Background(), []string{image})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "signature not found in transparency log")
+
+	// The transparency logs are only skipped when explicitly requested
+	err = newVerifier(testCertIdentity, true).Verify(context.Background(), []string{image})
+	assert.Nil(t, err)
+
+	// The certificate must be issued to the expected identity
+	err = newVerifier("someone@example.com", true).Verify(context.Background(), []string{image})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "none of the expected identities matched")
+}
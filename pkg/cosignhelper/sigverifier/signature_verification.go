@@ -13,14 +13,25 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/registry"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
+// VerifyInventoryImageSignature verifies the signature of the plugin inventory image using the
+// public key specified by TANZU_CLI_PLUGIN_DISCOVERY_IMAGE_SIGNATURE_PUBLIC_KEY_PATH, if any,
+// or the public keys embedded in the CLI
 func VerifyInventoryImageSignature(image string) error {
-	cosignVerifier, err := getCosignVerifier(image)
+	return VerifyInventoryImageSignatureWithTrust(image, nil)
+}
+
+// VerifyInventoryImageSignatureWithTrust verifies the signature of the plugin inventory image of a
+// discovery source using the trust configuration of that discovery source. If the trust configuration
+// is nil or empty, the signature is verified as done by VerifyInventoryImageSignature().
+func VerifyInventoryImageSignatureWithTrust(image string, trust *config.DiscoverySourceTrust) error {
+	cosignVerifier, err := getCosignVerifier(image, trust)
 	if err != nil {
 		return errors.Wrapf(err, "failed to initialize the cosign verifier")
 	}
@@ -47,7 +58,7 @@ func VerifyInventoryImageSignature(image string) error {
 	return nil
 }
 
-func getCosignVerifier(image string) (cosignhelper.Cosignhelper, error) {
+func getCosignVerifier(image string, trust *config.DiscoverySourceTrust) (cosignhelper.Cosignhelper, error) {
 	// Get the custom public key path and prepare cosign verifier, if empty, cosign verifier would use embedded public key for verification
 	customPublicKeyPath := os.Getenv(constants.PublicKeyPathForPluginDiscoveryImageSignature)
 
@@ -55,7 +66,23 @@ func getCosignVerifier(image string) (cosignhelper.Cosignhelper, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to prepare the registry options for cosign verification")
 	}
-	return cosignhelper.NewCosignVerifier(customPublicKeyPath, registryOptions), nil
+	if trust == nil || trust.IsEmpty() {
+		return cosignhelper.NewCosignVerifier(customPublicKeyPath, registryOptions), nil
+	}
+
+	// The trust configuration of the discovery source takes precedence over the global public key
+	if err := trust.Validate(); err != nil {
+		return nil, err
+	}
+	return &cosignhelper.CosignVerifyOptions{
+		PublicKeyPaths: trust.PublicKeys,
+		CertIdentity:   trust.CertificateIdentity,
+		CertOIDCIssuer: trust.CertificateOIDCIssuer,
+		CertChainPath:  trust.CertificateChain,
+		// Skipping the transparency logs must be explicitly configured for the discovery source
+		IgnoreTransparencyLog: trust.SkipTransparencyLog,
+		RegistryOpts:          registryOptions,
+	}, nil
 }
 
 // getCosignVerifierRegistryOptions prepares the registry options by including the custom certificate configuration if any
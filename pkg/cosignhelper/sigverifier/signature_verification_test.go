@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/configpaths"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper"
@@ -120,7 +121,7 @@ var _ = Describe("Unit tests for discovery image signature verification", func()
 				Expect(err).To(BeNil())
 			})
 			It("should create cosign verifier successfully with registryOptions updated with configured custom cert data", func() {
-				cosignVerifier, err = getCosignVerifier(image)
+				cosignVerifier, err = getCosignVerifier(image, nil)
 				Expect(err).ToNot(HaveOccurred())
 				cvo, ok := cosignVerifier.(*cosignhelper.CosignVerifyOptions)
 				Expect(ok).To(BeTrue())
@@ -134,7 +135,7 @@ var _ = Describe("Unit tests for discovery image signature verification", func()
 			It("should create cosign verifier successfully with Image signature custom public key path if provided using the environment variable", func() {
 				keyPath := "fake/path/to/publickey"
 				os.Setenv(constants.PublicKeyPathForPluginDiscoveryImageSignature, keyPath)
-				cosignVerifier, err = getCosignVerifier(image)
+				cosignVerifier, err = getCosignVerifier(image, nil)
 				Expect(err).ToNot(HaveOccurred())
 				cvo, ok := cosignVerifier.(*cosignhelper.CosignVerifyOptions)
 				Expect(ok).To(BeTrue())
@@ -142,10 +143,49 @@ var _ = Describe("Unit tests for discovery image signature verification", func()
 				Expect(cvo.PublicKeyPath).To(Equal(keyPath))
 
 			})
+			It("should create cosign verifier using the public keys of the discovery source trust configuration over the environment variable", func() {
+				os.Setenv(constants.PublicKeyPathForPluginDiscoveryImageSignature, "fake/path/to/publickey")
+				trust := &config.DiscoverySourceTrust{PublicKeys: []string{"fake/path/to/key1", "fake/path/to/key2"}}
+				cosignVerifier, err = getCosignVerifier(image, trust)
+				Expect(err).ToNot(HaveOccurred())
+				cvo, ok := cosignVerifier.(*cosignhelper.CosignVerifyOptions)
+				Expect(ok).To(BeTrue())
+
+				Expect(cvo.PublicKeyPath).To(BeEmpty())
+				Expect(cvo.PublicKeyPaths).To(Equal(trust.PublicKeys))
+				Expect(cvo.RegistryOpts.SkipCertVerify).To(BeTrue())
+			})
+			It("should create cosign verifier using the certificate requirements of the discovery source trust configuration", func() {
+				trust := &config.DiscoverySourceTrust{
+					CertificateIdentity:   "release@example.com",
+					CertificateOIDCIssuer: "https://issuer.example.com",
+					CertificateChain:      "fake/path/to/chain.pem",
+				}
+				cosignVerifier, err = getCosignVerifier(image, trust)
+				Expect(err).ToNot(HaveOccurred())
+				cvo, ok := cosignVerifier.(*cosignhelper.CosignVerifyOptions)
+				Expect(ok).To(BeTrue())
+
+				Expect(cvo.CertIdentity).To(Equal(trust.CertificateIdentity))
+				Expect(cvo.CertOIDCIssuer).To(Equal(trust.CertificateOIDCIssuer))
+				Expect(cvo.CertChainPath).To(Equal(trust.CertificateChain))
+				Expect(cvo.IgnoreTransparencyLog).To(BeFalse())
+
+				trust.SkipTransparencyLog = true
+				cosignVerifier, err = getCosignVerifier(image, trust)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cosignVerifier.(*cosignhelper.CosignVerifyOptions).IgnoreTransparencyLog).To(BeTrue())
+			})
+			It("should fail to create cosign verifier if the certificate requirements of the discovery source trust configuration are incomplete", func() {
+				trust := &config.DiscoverySourceTrust{CertificateIdentity: "release@example.com"}
+				_, err = getCosignVerifier(image, trust)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("must all be specified"))
+			})
 		})
 		Context("When custom cert data is not provided for registry endpoint/host in the config file", func() {
 			It("cosign verifier should be created successfully with default registryOptions", func() {
-				cosignVerifier, err = getCosignVerifier(image)
+				cosignVerifier, err = getCosignVerifier(image, nil)
 				Expect(err).ToNot(HaveOccurred())
 				cvo, ok := cosignVerifier.(*cosignhelper.CosignVerifyOptions)
 				Expect(ok).To(BeTrue())
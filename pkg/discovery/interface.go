@@ -12,6 +12,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 )
@@ -45,6 +46,10 @@ type DiscoveryOpts struct {
 	ForceInvalidation       bool // ForceInvalidation used to force invalidation of the plugin data
 	PluginDiscoveryCriteria *PluginDiscoveryCriteria
 	GroupDiscoveryCriteria  *GroupDiscoveryCriteria
+	// SignatureTrust overrides the signature verification configuration of the discovery source
+	SignatureTrust *config.DiscoverySourceTrust
+	// KubernetesDiscoveryScope restricts the CLIPlugin resources found by the kubernetes discoveries
+	KubernetesDiscoveryScope *config.KubernetesDiscoveryScope
 }
 
 type DiscoveryOptions func(options *DiscoveryOpts)
@@ -87,8 +92,26 @@ func WithGroupDiscoveryCriteria(criteria *GroupDiscoveryCriteria) DiscoveryOptio
 	}
 }
 
+// WithSignatureTrust used to specify the signature verification configuration to use
+// for the discovery source instead of the one stored in the configuration, e.g., to
+// check a discovery source before its configuration is saved
+func WithSignatureTrust(trust *config.DiscoverySourceTrust) DiscoveryOptions {
+	return func(o *DiscoveryOpts) {
+		o.SignatureTrust = trust
+	}
+}
+
+// WithKubernetesDiscoveryScope used to restrict the namespaces and labels of the
+// CLIPlugin resources found by the kubernetes discoveries
+func WithKubernetesDiscoveryScope(scope *config.KubernetesDiscoveryScope) DiscoveryOptions {
+	return func(o *DiscoveryOpts) {
+		o.KubernetesDiscoveryScope = scope
+	}
+}
+
 func NewDiscoveryOpts() *DiscoveryOpts {
-	return &DiscoveryOpts{}
+	// In offline mode, the plugin inventory data is always read from the cache
+	return &DiscoveryOpts{UseLocalCacheOnly: config.IsOfflineMode()}
 }
 
 // PluginDiscoveryCriteria provides criteria to look for plugins
@@ -104,6 +127,15 @@ type PluginDiscoveryCriteria struct {
 	OS string
 	// Arch of the plugin binary in `GOARCH` format.
 	Arch string
+	// Publisher of the plugin
+	Publisher string
+	// Vendor of the plugin
+	Vendor string
+	// Keywords to look for in the name and description of the plugin,
+	// separated by spaces
+	Keywords string
+	// IncludeHidden indicates if hidden plugins should be included
+	IncludeHidden bool
 }
 
 // GroupDiscoveryCriteria provides criteria to look for
@@ -123,14 +155,15 @@ type GroupDiscoveryCriteria struct {
 func CreateDiscoveryFromV1alpha1(pd configtypes.PluginDiscovery, options ...DiscoveryOptions) (Discovery, error) {
 	switch {
 	case pd.OCI != nil:
-		// Only the OCI Discovery currently supports a criteria
+		// Only the OCI and REST Discoveries currently support a criteria,
+		// the Kubernetes Discovery supports the scope and cache options
 		return NewOCIDiscovery(pd.OCI.Name, pd.OCI.Image, options...), nil
 	case pd.Local != nil:
 		return NewLocalDiscovery(pd.Local.Name, pd.Local.Path), nil
 	case pd.Kubernetes != nil:
-		return NewKubernetesDiscovery(pd.Kubernetes.Name, pd.Kubernetes.Path, pd.Kubernetes.Context, pd.Kubernetes.KubeConfigBytes), nil
+		return NewKubernetesDiscovery(pd.Kubernetes.Name, pd.Kubernetes.Path, pd.Kubernetes.Context, pd.Kubernetes.KubeConfigBytes, options...), nil
 	case pd.REST != nil:
-		return NewRESTDiscovery(pd.REST.Name, pd.REST.Endpoint, pd.REST.BasePath), nil
+		return NewRESTDiscovery(pd.REST.Name, pd.REST.Endpoint, pd.REST.BasePath, options...), nil
 	}
 	return nil, errors.New("unknown plugin discovery source")
 }
@@ -139,5 +172,8 @@ func CreateGroupDiscovery(pd configtypes.PluginDiscovery, options ...DiscoveryOp
 	if pd.OCI != nil {
 		return NewOCIGroupDiscovery(pd.OCI.Name, pd.OCI.Image, options...), nil
 	}
+	if pd.REST != nil {
+		return NewRESTGroupDiscovery(pd.REST.Name, pd.REST.Endpoint, pd.REST.BasePath, options...), nil
+	}
 	return nil, errors.New("unknown group discovery source")
 }
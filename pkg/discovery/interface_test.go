@@ -114,7 +114,12 @@ func Test_CreateGroupDiscovery(t *testing.T) {
 	pd = configtypes.PluginDiscovery{
 		REST: &configtypes.GenericRESTDiscovery{Name: "fake-rest"},
 	}
-	_, err = CreateGroupDiscovery(pd, nil)
-	assert.NotNil(err)
-	assert.Equal(err.Error(), "unknown group discovery source")
+	discovery, err = CreateGroupDiscovery(pd, WithGroupDiscoveryCriteria(criteria))
+	assert.Nil(err)
+	assert.Equal("fake-rest", discovery.Name())
+
+	restGroupDisc, ok := discovery.(*RESTDiscovery)
+	assert.True(ok)
+	assert.Equal(criteria, restGroupDisc.groupCriteria)
+	assert.Nil(restGroupDisc.pluginCriteria)
 }
@@ -4,35 +4,67 @@
 package discovery
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
 
-	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
-
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	cliv1alpha2 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cluster"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
-	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
-	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
+// KubernetesInventoryFileName is the name of the file storing the CLIPlugin resources cached by a kubernetes discovery
+const KubernetesInventoryFileName = "kubernetes_inventory.json"
+
 // KubernetesDiscovery is an artifact discovery utilizing CLIPlugin API in kubernetes cluster
 type KubernetesDiscovery struct {
 	name            string
 	kubeconfigPath  string
 	kubecontext     string
 	kubeconfigBytes []byte
+	// scope restricts the namespaces and labels of the discovered CLIPlugin resources
+	scope             *config.KubernetesDiscoveryScope
+	useLocalCacheOnly bool
+	forceRefresh      bool
+	// pluginDataDir is the directory where the CLIPlugin resources are cached.
+	// The resources are not cached if it is empty.
+	pluginDataDir string
+}
+
+// kubernetesInventory is the content of the cache of a kubernetes discovery
+type kubernetesInventory struct {
+	// Fingerprint identifies the cached CLIPlugin resources through their resourceVersion
+	Fingerprint             string                  `json:"fingerprint"`
+	ImageRepositoryOverride map[string]string       `json:"imageRepositoryOverride,omitempty"`
+	CLIPlugins              []cliv1alpha2.CLIPlugin `json:"cliPlugins"`
 }
 
 // NewKubernetesDiscovery returns a new kubernetes repository
-func NewKubernetesDiscovery(name, kubeconfigPath, kubecontext string, kubeconfigBytes []byte) Discovery {
+func NewKubernetesDiscovery(name, kubeconfigPath, kubecontext string, kubeconfigBytes []byte, options ...DiscoveryOptions) Discovery {
+	opts := NewDiscoveryOpts()
+	for _, option := range options {
+		option(opts)
+	}
+
 	return &KubernetesDiscovery{
-		name:            name,
-		kubeconfigPath:  kubeconfigPath,
-		kubecontext:     kubecontext,
-		kubeconfigBytes: kubeconfigBytes,
+		name:              name,
+		kubeconfigPath:    kubeconfigPath,
+		kubecontext:       kubecontext,
+		kubeconfigBytes:   kubeconfigBytes,
+		scope:             opts.KubernetesDiscoveryScope,
+		useLocalCacheOnly: opts.UseLocalCacheOnly,
+		forceRefresh:      opts.ForceRefresh || opts.ForceInvalidation,
+		pluginDataDir:     filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, name),
 	}
 }
 
@@ -48,6 +80,24 @@ func (k *KubernetesDiscovery) Name() string {
 
 // Manifest returns the manifest for a kubernetes repository.
 func (k *KubernetesDiscovery) Manifest() ([]Discovered, error) {
+	if k.useLocalCacheOnly || config.IsOfflineMode() {
+		inventory, err := k.readCachedInventory()
+		if err != nil {
+			if config.IsOfflineMode() {
+				return nil, NewOfflineError(fmt.Sprintf("discovering the plugins of kubernetes discovery '%s'", k.name))
+			}
+			return nil, err
+		}
+		return k.discoveredFromCLIPlugins(inventory.CLIPlugins, inventory.ImageRepositoryOverride)
+	}
+	if k.pluginDataDir != "" && !k.forceRefresh && !cacheTTLExpiredAfter(k.pluginDataDir, k.cacheKey(), getKubernetesCacheTTLValue()) {
+		// Listing the CLIPlugin resources of large clusters is slow, so they
+		// are not checked again for commands that are run close together.
+		if inventory, err := k.readCachedInventory(); err == nil {
+			log.V(6).Infof("using the cached CLIPlugin resources of kubernetes discovery '%s'", k.name)
+			return k.discoveredFromCLIPlugins(inventory.CLIPlugins, inventory.ImageRepositoryOverride)
+		}
+	}
 	log.V(6).Infof("creating kubernetes client with kubeconfig %q, kubecontext %q", k.kubeconfigPath, k.kubecontext)
 
 	// Create cluster client
@@ -61,8 +111,6 @@ func (k *KubernetesDiscovery) Manifest() ([]Discovered, error) {
 
 // GetDiscoveredPlugins returns the list of discovered plugin from a kubernetes cluster
 func (k *KubernetesDiscovery) GetDiscoveredPlugins(clusterClient cluster.Client) ([]Discovered, error) {
-	plugins := make([]Discovered, 0)
-
 	logMsg := "Skipping context-aware plugin discovery because CLIPlugin CRD not present on the logged in cluster. "
 
 	crdExists, errVerifyCRD := clusterClient.VerifyCLIPluginCRD()
@@ -77,7 +125,7 @@ func (k *KubernetesDiscovery) GetDiscoveredPlugins(clusterClient cluster.Client)
 	}
 
 	// Try to get all cliplugins resources available on the cluster
-	cliplugins, errListCLIPlugins := clusterClient.ListCLIPluginResources()
+	cliplugins, errListCLIPlugins := k.listCLIPluginResources(clusterClient)
 	if errListCLIPlugins != nil {
 		// If there was an earlier error while verifying CRD, assuming that it was a legitimate
 		// error and will just log a warning and return without error
@@ -100,9 +148,56 @@ func (k *KubernetesDiscovery) GetDiscoveredPlugins(clusterClient cluster.Client)
 		log.Infof("unable to get image repository override information for some of the plugins. Error: %v", err)
 	}
 
-	// Convert all CLIPlugin resources to Discovered object
+	// The conversion applies the image repository override to the resources,
+	// so the cache must be written before
+	if k.pluginDataDir != "" {
+		inventory := &kubernetesInventory{Fingerprint: k.fingerprint(cliplugins), ImageRepositoryOverride: imageRepositoryOverride, CLIPlugins: cliplugins}
+		if err := k.writeCachedInventory(inventory); err != nil {
+			// The cache is an optimization, don't fail
+			log.V(6).Warningf("unable to cache the CLIPlugin resources of kubernetes discovery '%s': %v", k.name, err)
+		}
+	}
+
+	return k.discoveredFromCLIPlugins(cliplugins, imageRepositoryOverride)
+}
+
+// listCLIPluginResources lists the CLIPlugin resources in the scope of the discovery.
+// If some resources are cached, only the metadata of the resources is first fetched and,
+// if no resource was added, removed or modified, the cached resources are returned.
+func (k *KubernetesDiscovery) listCLIPluginResources(clusterClient cluster.Client) ([]cliv1alpha2.CLIPlugin, error) {
+	if k.pluginDataDir != "" && !k.forceRefresh {
+		if inventory, err := k.readCachedInventory(); err == nil {
+			metadata, err := clusterClient.ListCLIPluginResourcesWithOptions(k.listOptions(true))
+			if err != nil {
+				return nil, err
+			}
+			if k.fingerprint(metadata) == inventory.Fingerprint {
+				log.V(6).Infof("the CLIPlugin resources of kubernetes discovery '%s' have not changed", k.name)
+				return inventory.CLIPlugins, nil
+			}
+		}
+	}
+
+	if k.scope.IsEmpty() {
+		return clusterClient.ListCLIPluginResources()
+	}
+	return clusterClient.ListCLIPluginResourcesWithOptions(k.listOptions(false))
+}
+
+func (k *KubernetesDiscovery) listOptions(metadataOnly bool) cluster.CLIPluginListOptions {
+	options := cluster.CLIPluginListOptions{MetadataOnly: metadataOnly}
+	if k.scope != nil {
+		options.Namespaces = k.scope.Namespaces
+		options.LabelSelector = k.scope.LabelSelector
+	}
+	return options
+}
+
+// discoveredFromCLIPlugins converts the CLIPlugin resources to Discovered objects
+func (k *KubernetesDiscovery) discoveredFromCLIPlugins(cliplugins []cliv1alpha2.CLIPlugin, imageRepositoryOverride map[string]string) ([]Discovered, error) {
+	plugins := make([]Discovered, 0)
 	for i := range cliplugins {
-		dp, err := DiscoveredFromK8sV1alpha1WithImageRepositoryOverride(&cliplugins[i], imageRepositoryOverride)
+		dp, err := DiscoveredFromK8sV1alpha2WithImageRepositoryOverride(&cliplugins[i], imageRepositoryOverride)
 		if err != nil {
 			return nil, err
 		}
@@ -111,10 +206,76 @@ func (k *KubernetesDiscovery) GetDiscoveredPlugins(clusterClient cluster.Client)
 		dp.DiscoveryType = k.Type()
 		plugins = append(plugins, dp)
 	}
-
 	return plugins, nil
 }
 
+// cacheKey identifies the cluster and the scope of the discovery. It is stored in the
+// digest file of the cache so that the cache is not used once any of them changes.
+func (k *KubernetesDiscovery) cacheKey() string {
+	key := fmt.Sprintf("kubernetes:%s#%s#%x", k.kubeconfigPath, k.kubecontext, sha256.Sum256(k.kubeconfigBytes))
+	if !k.scope.IsEmpty() {
+		key += fmt.Sprintf("?namespaces=%s&labelSelector=%s", strings.Join(k.scope.Namespaces, ","), k.scope.LabelSelector)
+	}
+	return key
+}
+
+// fingerprint returns a hash of the name and resourceVersion of the CLIPlugin resources.
+// As the resourceVersion of a resource changes every time the resource is modified,
+// the fingerprint changes whenever a resource is added, removed or modified.
+func (k *KubernetesDiscovery) fingerprint(cliplugins []cliv1alpha2.CLIPlugin) string {
+	versions := make([]string, 0, len(cliplugins))
+	for i := range cliplugins {
+		versions = append(versions, fmt.Sprintf("%s/%s@%s", cliplugins[i].Namespace, cliplugins[i].Name, cliplugins[i].ResourceVersion))
+	}
+	sort.Strings(versions)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(k.cacheKey()+"\n"+strings.Join(versions, "\n"))))
+}
+
+func (k *KubernetesDiscovery) readCachedInventory() (*kubernetesInventory, error) {
+	if k.pluginDataDir == "" {
+		return nil, errors.Errorf("the CLIPlugin resources of kubernetes discovery '%s' are not cached", k.name)
+	}
+	// The cache of another cluster or scope must not be used
+	matches, _ := filepath.Glob(filepath.Join(k.pluginDataDir, "digest.*"))
+	if len(matches) != 1 {
+		return nil, errors.Errorf("the CLIPlugin resources of kubernetes discovery '%s' are not in the cache", k.name)
+	}
+	if key, err := os.ReadFile(matches[0]); err != nil || string(key) != k.cacheKey() {
+		return nil, errors.Errorf("the cached CLIPlugin resources of kubernetes discovery '%s' are for another cluster or scope", k.name)
+	}
+
+	b, err := os.ReadFile(filepath.Join(k.pluginDataDir, KubernetesInventoryFileName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "the CLIPlugin resources of kubernetes discovery '%s' are not in the cache", k.name)
+	}
+	var inventory kubernetesInventory
+	if err := json.Unmarshal(b, &inventory); err != nil {
+		return nil, errors.Wrapf(err, "invalid cached CLIPlugin resources for kubernetes discovery '%s'", k.name)
+	}
+	return &inventory, nil
+}
+
+// writeCachedInventory stores the CLIPlugin resources in the cache along with a digest file.
+// Like for the other discoveries, the modification time of the digest file is used to know
+// when the cache TTL has expired.
+func (k *KubernetesDiscovery) writeCachedInventory(inventory *kubernetesInventory) error {
+	b, err := json.Marshal(inventory)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(k.pluginDataDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(k.pluginDataDir, KubernetesInventoryFileName), b, 0644); err != nil {
+		return err
+	}
+	return writeCacheDigest(k.pluginDataDir, b, k.cacheKey())
+}
+
+func getKubernetesCacheTTLValue() int {
+	return getTTLValue(constants.DefaultKubernetesDiscoveryCacheTTLSeconds, constants.ConfigVariableKubernetesDiscoveryCacheTTLSeconds)
+}
+
 // Type of the repository.
 func (k *KubernetesDiscovery) Type() string {
 	return common.DiscoveryTypeKubernetes
@@ -124,40 +285,43 @@ func (k *KubernetesDiscovery) Type() string {
 func DiscoveredFromK8sV1alpha1WithImageRepositoryOverride(p *cliv1alpha1.CLIPlugin, imageRepoOverride map[string]string) (Discovered, error) {
 	// Update artifacts based on image repository override if applicable
 	UpdateArtifactsBasedOnImageRepositoryOverride(p, imageRepoOverride)
+	return DiscoveredFromK8sV1alpha1(p)
+}
 
-	dp := Discovered{
-		Name:               p.Name,
-		Description:        p.Spec.Description,
-		RecommendedVersion: p.Spec.RecommendedVersion,
-		Optional:           p.Spec.Optional,
-		Target:             configtypes.StringToTarget(string(p.Spec.Target)),
-	}
-	dp.SupportedVersions = make([]string, 0)
-	for v := range p.Spec.Artifacts {
-		dp.SupportedVersions = append(dp.SupportedVersions, v)
-	}
-	if err := utils.SortVersions(dp.SupportedVersions); err != nil {
-		return dp, errors.Wrapf(err, "error parsing supported versions for plugin %s", p.Name)
-	}
-
-	dp.Distribution = distribution.ArtifactsFromK8sV1alpha1(p.Spec.Artifacts)
-	return dp, nil
+// DiscoveredFromK8sV1alpha2WithImageRepositoryOverride returns discovered plugin object from k8sV1alpha2
+func DiscoveredFromK8sV1alpha2WithImageRepositoryOverride(p *cliv1alpha2.CLIPlugin, imageRepoOverride map[string]string) (Discovered, error) {
+	// Update artifacts based on image repository override if applicable
+	UpdateV1alpha2ArtifactsBasedOnImageRepositoryOverride(p, imageRepoOverride)
+	return DiscoveredFromK8sV1alpha2(p)
 }
 
 // UpdateArtifactsBasedOnImageRepositoryOverride updates artifacts based on image repository override
 func UpdateArtifactsBasedOnImageRepositoryOverride(p *cliv1alpha1.CLIPlugin, imageRepoOverride map[string]string) {
-	replaceImageRepository := func(a *cliv1alpha1.Artifact) {
-		if a.Image != "" {
-			for originalRepo, overrideRepo := range imageRepoOverride {
-				if strings.HasPrefix(a.Image, originalRepo) {
-					a.Image = strings.Replace(a.Image, originalRepo, overrideRepo, 1)
-				}
-			}
+	for i := range p.Spec.Artifacts {
+		for j := range p.Spec.Artifacts[i] {
+			p.Spec.Artifacts[i][j].Image = overrideImageRepository(p.Spec.Artifacts[i][j].Image, imageRepoOverride)
 		}
 	}
+}
+
+// UpdateV1alpha2ArtifactsBasedOnImageRepositoryOverride updates the artifacts of a v1alpha2 CLIPlugin
+// based on image repository override
+func UpdateV1alpha2ArtifactsBasedOnImageRepositoryOverride(p *cliv1alpha2.CLIPlugin, imageRepoOverride map[string]string) {
 	for i := range p.Spec.Artifacts {
 		for j := range p.Spec.Artifacts[i] {
-			replaceImageRepository(&p.Spec.Artifacts[i][j])
+			p.Spec.Artifacts[i][j].Image = overrideImageRepository(p.Spec.Artifacts[i][j].Image, imageRepoOverride)
+		}
+	}
+}
+
+func overrideImageRepository(image string, imageRepoOverride map[string]string) string {
+	if image == "" {
+		return image
+	}
+	for originalRepo, overrideRepo := range imageRepoOverride {
+		if strings.HasPrefix(image, originalRepo) {
+			image = strings.Replace(image, originalRepo, overrideRepo, 1)
 		}
 	}
+	return image
 }
@@ -6,12 +6,16 @@ package discovery_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
-	"github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
 
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/fakes"
 	fakehelper "github.com/vmware-tanzu/tanzu-cli/pkg/fakes/helper"
@@ -28,7 +32,7 @@ var _ = Describe("Unit tests for kubernetes discovery", func() {
 		currentClusterClient *fakes.ClusterClient
 		kd                   *discovery.KubernetesDiscovery
 		plugins              []discovery.Discovered
-		cliplugins           []v1alpha1.CLIPlugin
+		cliplugins           []v1alpha2.CLIPlugin
 	)
 
 	Describe("When Getting Discovered plugins from k8s cluster", func() {
@@ -84,7 +88,7 @@ var _ = Describe("Unit tests for kubernetes discovery", func() {
 			BeforeEach(func() {
 				cliplugin1 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin1", Description: "plugin1 desc", RecommendedVersion: "v0.0.1"})
 				cliplugin2 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin2", Description: "plugin2 desc", RecommendedVersion: "v0.0.2"})
-				cliplugins = []v1alpha1.CLIPlugin{}
+				cliplugins = []v1alpha2.CLIPlugin{}
 				cliplugins = append(cliplugins, cliplugin1, cliplugin2)
 				currentClusterClient.VerifyCLIPluginCRDReturns(false, errors.New("fake error verify CRD"))
 				currentClusterClient.ListCLIPluginResourcesReturns(cliplugins, nil)
@@ -103,7 +107,7 @@ var _ = Describe("Unit tests for kubernetes discovery", func() {
 			BeforeEach(func() {
 				cliplugin1 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin1", Description: "plugin1 desc", RecommendedVersion: "v0.0.1"})
 				cliplugin2 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin2", Description: "plugin2 desc", RecommendedVersion: "v0.0.2"})
-				cliplugins = []v1alpha1.CLIPlugin{}
+				cliplugins = []v1alpha2.CLIPlugin{}
 				cliplugins = append(cliplugins, cliplugin1, cliplugin2)
 				currentClusterClient.VerifyCLIPluginCRDReturns(true, nil)
 				currentClusterClient.ListCLIPluginResourcesReturns(cliplugins, nil)
@@ -119,11 +123,35 @@ var _ = Describe("Unit tests for kubernetes discovery", func() {
 			})
 		})
 
+		Context("When ListCLIPluginResources returns a deprecated CLIPlugin with a minimum CLI version and release notes", func() {
+			BeforeEach(func() {
+				cliplugin1 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin1", Description: "plugin1 desc", RecommendedVersion: "v1.0.0"})
+				cliplugin1.Spec.Deprecated = true
+				cliplugin1.Spec.DeprecationMessage = "plugin1 is no longer maintained"
+				cliplugin1.Spec.ReplacedBy = "plugin2"
+				cliplugin1.Spec.MinCLIVersion = "v1.3.0"
+				cliplugin1.Spec.ReleaseNotes = map[string]string{"v1.0.0": "first release", "v9.9.9": "unknown version"}
+				currentClusterClient.VerifyCLIPluginCRDReturns(true, nil)
+				currentClusterClient.ListCLIPluginResourcesReturns([]v1alpha2.CLIPlugin{cliplugin1}, nil)
+				currentClusterClient.GetCLIPluginImageRepositoryOverrideReturns(map[string]string{}, nil)
+			})
+			It("should return the deprecation, the minimum CLI version and the release notes of the plugin", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(plugins)).To(Equal(1))
+				Expect(plugins[0].Deprecated).To(BeTrue())
+				Expect(plugins[0].DeprecationMessage).To(Equal("plugin1 is no longer maintained"))
+				Expect(plugins[0].ReplacedBy).To(Equal("plugin2"))
+				Expect(plugins[0].Requirements).To(HaveKey("v1.0.0"))
+				Expect(plugins[0].Requirements["v1.0.0"].MinCLIVersion).To(Equal("v1.3.0"))
+				Expect(plugins[0].ReleaseNotes).To(Equal(map[string]string{"v1.0.0": "first release"}))
+			})
+		})
+
 		Context("When ListCLIPluginResources list of CLIPlugin resources but GetCLIPluginImageRepositoryOverrideReturns return error", func() {
 			BeforeEach(func() {
 				cliplugin1 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin1", Description: "plugin1 desc", RecommendedVersion: "v0.0.1"})
 				cliplugin2 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin2", Description: "plugin2 desc", RecommendedVersion: "v0.0.2"})
-				cliplugins = []v1alpha1.CLIPlugin{}
+				cliplugins = []v1alpha2.CLIPlugin{}
 				cliplugins = append(cliplugins, cliplugin1, cliplugin2)
 				currentClusterClient.VerifyCLIPluginCRDReturns(true, nil)
 				currentClusterClient.ListCLIPluginResourcesReturns(cliplugins, nil)
@@ -145,7 +173,7 @@ var _ = Describe("Unit tests for kubernetes discovery", func() {
 			BeforeEach(func() {
 				cliplugin1 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin1", Description: "plugin1 desc", RecommendedVersion: "v0.0.1"})
 				cliplugin2 := fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin2", Description: "plugin2 desc", RecommendedVersion: "v0.0.2"})
-				cliplugins = []v1alpha1.CLIPlugin{}
+				cliplugins = []v1alpha2.CLIPlugin{}
 				cliplugins = append(cliplugins, cliplugin1, cliplugin2)
 				imageOverrideMap := map[string]string{
 					"fake.image.repo.com": "custom.repo.com",
@@ -173,4 +201,115 @@ var _ = Describe("Unit tests for kubernetes discovery", func() {
 			})
 		})
 	})
+
+	Describe("When Getting Discovered plugins with a scope and a cache", func() {
+		var (
+			cacheDir   string
+			scope      *config.KubernetesDiscoveryScope
+			newKD      func(options ...discovery.DiscoveryOptions) *discovery.KubernetesDiscovery
+			withRV     func(p v1alpha2.CLIPlugin, rv string) v1alpha2.CLIPlugin
+			cliplugin1 v1alpha2.CLIPlugin
+			cliplugin2 v1alpha2.CLIPlugin
+		)
+
+		BeforeEach(func() {
+			cacheDir = common.DefaultCacheDir
+			common.DefaultCacheDir = GinkgoT().TempDir()
+			currentClusterClient = &fakes.ClusterClient{}
+			currentClusterClient.VerifyCLIPluginCRDReturns(true, nil)
+			currentClusterClient.GetCLIPluginImageRepositoryOverrideReturns(map[string]string{"fake.image.repo.com": "custom.repo.com"}, nil)
+			scope = &config.KubernetesDiscoveryScope{Namespaces: []string{"ns1", "ns2"}, LabelSelector: "env=prod"}
+			newKD = func(options ...discovery.DiscoveryOptions) *discovery.KubernetesDiscovery {
+				// The kubeconfig does not exist, so the cluster can only be reached through the fake client
+				return discovery.NewKubernetesDiscovery("default-mgmt", "/does/not/exist", "mgmt", nil, options...).(*discovery.KubernetesDiscovery)
+			}
+			withRV = func(p v1alpha2.CLIPlugin, rv string) v1alpha2.CLIPlugin {
+				p.ResourceVersion = rv
+				return p
+			}
+			cliplugin1 = withRV(fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin1", Description: "plugin1 desc", RecommendedVersion: "v0.0.1"}), "1")
+			cliplugin2 = withRV(fakehelper.NewCLIPlugin(fakehelper.TestCLIPluginOption{Name: "plugin2", Description: "plugin2 desc", RecommendedVersion: "v0.0.2"}), "2")
+		})
+		AfterEach(func() {
+			common.DefaultCacheDir = cacheDir
+		})
+
+		It("should only list the CLIPlugin resources of the scope", func() {
+			currentClusterClient.ListCLIPluginResourcesWithOptionsReturns([]v1alpha2.CLIPlugin{cliplugin1}, nil)
+			plugins, err = newKD(discovery.WithKubernetesDiscoveryScope(scope)).GetDiscoveredPlugins(currentClusterClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plugins).To(HaveLen(1))
+			Expect(plugins[0].Name).To(Equal("plugin1"))
+			Expect(currentClusterClient.ListCLIPluginResourcesCallCount()).To(Equal(0))
+			Expect(currentClusterClient.ListCLIPluginResourcesWithOptionsCallCount()).To(Equal(1))
+			options := currentClusterClient.ListCLIPluginResourcesWithOptionsArgsForCall(0)
+			Expect(options.Namespaces).To(Equal([]string{"ns1", "ns2"}))
+			Expect(options.LabelSelector).To(Equal("env=prod"))
+			Expect(options.MetadataOnly).To(BeFalse())
+		})
+
+		It("should use the cache until the TTL expires or a refresh is forced", func() {
+			currentClusterClient.ListCLIPluginResourcesReturns([]v1alpha2.CLIPlugin{cliplugin1, cliplugin2}, nil)
+			_, err = newKD().GetDiscoveredPlugins(currentClusterClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			// The cluster cannot be reached, so the plugins come from the cache
+			plugins, err = newKD().List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plugins).To(HaveLen(2))
+			Expect(plugins[0].Source).To(Equal("default-mgmt"))
+			artifact, errArtifact := plugins[0].Distribution.DescribeArtifact("v1.0.0", "darwin", "amd64")
+			Expect(errArtifact).NotTo(HaveOccurred())
+			Expect(artifact.Image).To(Equal("custom.repo.com/tkg/plugin/test-darwin-plugin:v1.4.0"))
+
+			// The cache of another scope is not used
+			_, err = newKD(discovery.WithKubernetesDiscoveryScope(scope)).List()
+			Expect(err).To(HaveOccurred())
+
+			_, err = newKD(discovery.WithForceRefresh()).List()
+			Expect(err).To(HaveOccurred())
+
+			GinkgoT().Setenv(constants.ConfigVariableKubernetesDiscoveryCacheTTLSeconds, "0")
+			time.Sleep(10 * time.Millisecond)
+			_, err = newKD().List()
+			Expect(err).To(HaveOccurred())
+
+			// The cache can still be used explicitly
+			plugins, err = newKD(discovery.WithUseLocalCacheOnly()).List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plugins).To(HaveLen(2))
+		})
+
+		It("should only fetch the metadata of the CLIPlugin resources if the cache is up-to-date", func() {
+			currentClusterClient.ListCLIPluginResourcesReturns([]v1alpha2.CLIPlugin{cliplugin1, cliplugin2}, nil)
+			_, err = newKD().GetDiscoveredPlugins(currentClusterClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(currentClusterClient.ListCLIPluginResourcesCallCount()).To(Equal(1))
+
+			metadata := []v1alpha2.CLIPlugin{{ObjectMeta: cliplugin2.ObjectMeta}, {ObjectMeta: cliplugin1.ObjectMeta}}
+			currentClusterClient.ListCLIPluginResourcesWithOptionsReturns(metadata, nil)
+			plugins, err = newKD().GetDiscoveredPlugins(currentClusterClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plugins).To(HaveLen(2))
+			Expect(plugins[1].RecommendedVersion).To(Equal("v0.0.2"))
+			Expect(currentClusterClient.ListCLIPluginResourcesCallCount()).To(Equal(1))
+			Expect(currentClusterClient.ListCLIPluginResourcesWithOptionsArgsForCall(0).MetadataOnly).To(BeTrue())
+
+			// A modified resource invalidates the cache
+			cliplugin2.Spec.RecommendedVersion = "v0.0.3"
+			cliplugin2 = withRV(cliplugin2, "3")
+			currentClusterClient.ListCLIPluginResourcesReturns([]v1alpha2.CLIPlugin{cliplugin1, cliplugin2}, nil)
+			currentClusterClient.ListCLIPluginResourcesWithOptionsReturns([]v1alpha2.CLIPlugin{{ObjectMeta: cliplugin1.ObjectMeta}, {ObjectMeta: cliplugin2.ObjectMeta}}, nil)
+			plugins, err = newKD().GetDiscoveredPlugins(currentClusterClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plugins[1].RecommendedVersion).To(Equal("v0.0.3"))
+			Expect(currentClusterClient.ListCLIPluginResourcesCallCount()).To(Equal(2))
+
+			// A forced refresh does not check the metadata
+			_, err = newKD(discovery.WithForceRefresh()).GetDiscoveredPlugins(currentClusterClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(currentClusterClient.ListCLIPluginResourcesCallCount()).To(Equal(3))
+			Expect(currentClusterClient.ListCLIPluginResourcesWithOptionsCallCount()).To(Equal(2))
+		})
+	})
 })
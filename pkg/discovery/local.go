@@ -8,13 +8,16 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	apimachineryjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
 
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	cliv1alpha2 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
@@ -71,19 +74,7 @@ func (l *LocalDiscovery) Manifest() ([]Discovered, error) {
 			return nil, errors.Wrapf(err, "error while reading manifest file")
 		}
 
-		scheme, err := cliv1alpha1.SchemeBuilder.Build()
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to create scheme")
-		}
-		s := apimachineryjson.NewSerializerWithOptions(apimachineryjson.DefaultMetaFactory, scheme, scheme,
-			apimachineryjson.SerializerOptions{Yaml: true, Pretty: false, Strict: false})
-		var p cliv1alpha1.CLIPlugin
-		_, _, err = s.Decode(b, nil, &p)
-		if err != nil {
-			return nil, errors.Wrap(err, "could not decode catalog file")
-		}
-
-		dp, err := DiscoveredFromK8sV1alpha1(&p)
+		dp, err := discoveredFromManifest(b)
 		if err != nil {
 			return nil, err
 		}
@@ -102,14 +93,56 @@ func (l *LocalDiscovery) Type() string {
 	return common.DiscoveryTypeLocal
 }
 
+// discoveredFromManifest decodes a v1alpha1 or v1alpha2 CLIPlugin manifest
+// and returns the corresponding discovered plugin object
+func discoveredFromManifest(b []byte) (Discovered, error) {
+	scheme := runtime.NewScheme()
+	if err := cliv1alpha1.AddToScheme(scheme); err != nil {
+		return Discovered{}, errors.Wrap(err, "failed to create scheme")
+	}
+	if err := cliv1alpha2.AddToScheme(scheme); err != nil {
+		return Discovered{}, errors.Wrap(err, "failed to create scheme")
+	}
+	s := apimachineryjson.NewSerializerWithOptions(apimachineryjson.DefaultMetaFactory, scheme, scheme,
+		apimachineryjson.SerializerOptions{Yaml: true, Pretty: false, Strict: false})
+
+	// Manifests without apiVersion and kind are considered v1alpha1 CLIPlugins
+	defaultGVK := cliv1alpha1.GroupVersion.WithKind("CLIPlugin")
+	obj, _, err := s.Decode(b, &defaultGVK, nil)
+	if err != nil {
+		return Discovered{}, errors.Wrap(err, "could not decode catalog file")
+	}
+
+	switch p := obj.(type) {
+	case *cliv1alpha1.CLIPlugin:
+		return DiscoveredFromK8sV1alpha1(p)
+	case *cliv1alpha2.CLIPlugin:
+		return DiscoveredFromK8sV1alpha2(p)
+	default:
+		return Discovered{}, errors.Errorf("could not decode catalog file: unexpected object %T", obj)
+	}
+}
+
 // DiscoveredFromK8sV1alpha1 returns discovered plugin object from k8sV1alpha1
 func DiscoveredFromK8sV1alpha1(p *cliv1alpha1.CLIPlugin) (Discovered, error) {
+	var hub cliv1alpha2.CLIPlugin
+	if err := p.ConvertTo(&hub); err != nil {
+		return Discovered{}, err
+	}
+	return DiscoveredFromK8sV1alpha2(&hub)
+}
+
+// DiscoveredFromK8sV1alpha2 returns discovered plugin object from k8sV1alpha2
+func DiscoveredFromK8sV1alpha2(p *cliv1alpha2.CLIPlugin) (Discovered, error) {
 	dp := Discovered{
 		Name:               p.Name,
 		Description:        p.Spec.Description,
 		RecommendedVersion: p.Spec.RecommendedVersion,
 		Optional:           p.Spec.Optional,
 		Target:             configtypes.StringToTarget(string(p.Spec.Target)),
+		Deprecated:         p.Spec.Deprecated,
+		DeprecationMessage: p.Spec.DeprecationMessage,
+		ReplacedBy:         p.Spec.ReplacedBy,
 	}
 	dp.SupportedVersions = make([]string, 0)
 	for v := range p.Spec.Artifacts {
@@ -118,6 +151,23 @@ func DiscoveredFromK8sV1alpha1(p *cliv1alpha1.CLIPlugin) (Discovered, error) {
 	if err := utils.SortVersions(dp.SupportedVersions); err != nil {
 		return dp, errors.Wrapf(err, "error parsing supported versions for plugin %s", p.Name)
 	}
-	dp.Distribution = distribution.ArtifactsFromK8sV1alpha1(p.Spec.Artifacts)
+	dp.Distribution = distribution.ArtifactsFromK8sV1alpha2(p.Spec.Artifacts)
+
+	// The minimum CLI version applies to all the versions of the plugin
+	if p.Spec.MinCLIVersion != "" {
+		dp.Requirements = make(map[string]plugininventory.PluginRequirements, len(dp.SupportedVersions))
+		for _, v := range dp.SupportedVersions {
+			dp.Requirements[v] = plugininventory.PluginRequirements{MinCLIVersion: p.Spec.MinCLIVersion}
+		}
+	}
+	for v, notes := range p.Spec.ReleaseNotes {
+		if _, exists := p.Spec.Artifacts[v]; !exists || notes == "" {
+			continue
+		}
+		if dp.ReleaseNotes == nil {
+			dp.ReleaseNotes = make(map[string]string)
+		}
+		dp.ReleaseNotes[v] = notes
+	}
 	return dp, nil
 }
@@ -49,17 +49,50 @@ var _ = Describe("Unit tests for local discovery", func() {
 		Expect(plugins[0].RecommendedVersion).To(Equal(expectedPlugin.RecommendedVersion))
 		Expect(plugins[0].Optional).To(Equal(expectedPlugin.Optional))
 	})
+
+	It("test v1alpha2 manifest deserialization", func() {
+		createTestLocalPluginFileWithContents(`
+apiVersion: cli.tanzu.vmware.com/v1alpha2
+kind: CLIPlugin
+metadata:
+  name: test-plugin
+spec:
+  description: test-plugin
+  recommendedVersion: v1.0.0
+  deprecated: true
+  replacedBy: new-plugin
+  minCLIVersion: v1.3.0
+  artifacts:
+    v1.0.0:
+    - type: oci
+      image: example.com/test-plugin:v1.0.0
+      os: linux
+      arch: amd64`)
+		plugins, err := discovery.Manifest()
+		Expect(err).ToNot(HaveOccurred())
+
+		deleteLocalPluginFile()
+		Expect(len(plugins)).To(Equal(1))
+		Expect(plugins[0].Name).To(Equal("test-plugin"))
+		Expect(plugins[0].Deprecated).To(BeTrue())
+		Expect(plugins[0].ReplacedBy).To(Equal("new-plugin"))
+		Expect(plugins[0].SupportedVersions).To(Equal([]string{"v1.0.0"}))
+		Expect(plugins[0].Requirements["v1.0.0"].MinCLIVersion).To(Equal("v1.3.0"))
+	})
 })
 
 func createTestLocalPluginFile() {
-	contents := `
+	createTestLocalPluginFileWithContents(`
 inline:
 metadata:
   name: test-plugin
 spec:
   description: test-plugin
   recommendedVersion: 1.0.0
-  optional: true`
+  optional: true`)
+}
+
+func createTestLocalPluginFileWithContents(contents string) {
 	_, err := os.Stat(pluginPath)
 	if err != nil && os.IsNotExist(err) {
 		err := os.Mkdir(pluginPath, 0777)
@@ -30,6 +30,7 @@ func NewOCIDiscovery(name, image string, options ...DiscoveryOptions) Discovery
 	}
 	discovery.forceRefresh = opts.ForceRefresh
 	discovery.forceInvalidation = opts.ForceInvalidation
+	discovery.signatureTrust = opts.SignatureTrust
 
 	return discovery
 }
@@ -51,6 +52,7 @@ func NewOCIGroupDiscovery(name, image string, options ...DiscoveryOptions) Group
 	}
 	discovery.forceRefresh = opts.ForceRefresh
 	discovery.forceInvalidation = opts.ForceInvalidation
+	discovery.signatureTrust = opts.SignatureTrust
 
 	return discovery
 }
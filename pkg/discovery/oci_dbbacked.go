@@ -5,6 +5,7 @@ package discovery
 
 import (
 	"bufio"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,10 +17,12 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/airgapped"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/carvelhelpers"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cosignhelper/sigverifier"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
@@ -48,6 +51,9 @@ type DBBackedOCIDiscovery struct {
 	// forceInvalidation enables to force the invalidation of the cache which will
 	// in turn trigger a full download of the inventory data
 	forceInvalidation bool
+	// signatureTrust is the signature verification configuration to use instead of
+	// the one stored in the configuration for this discovery source
+	signatureTrust *config.DiscoverySourceTrust
 	// pluginDataDir is the location where the plugin data will be stored once
 	// extracted from the OCI image
 	pluginDataDir string
@@ -80,6 +86,8 @@ func (od *DBBackedOCIDiscovery) List() ([]Discovered, error) {
 			// Return an error if unable to fetch the inventory image for plugins
 			return nil, errors.Wrapf(err, "unable to fetch the inventory of discovery '%s' for plugins", od.Name())
 		}
+	} else if err := od.checkCacheInOfflineMode(); err != nil {
+		return nil, err
 	}
 
 	// List and return the plugins from the inventory
@@ -97,12 +105,23 @@ func (od *DBBackedOCIDiscovery) GetGroups() ([]*plugininventory.PluginGroup, err
 			// Return an error if unable to fetch the inventory image for groups
 			return nil, errors.Wrapf(err, "unable to fetch the inventory of discovery '%s' for groups", od.Name())
 		}
+	} else if err := od.checkCacheInOfflineMode(); err != nil {
+		return nil, err
 	}
 
 	// List and return the groups from the inventory
 	return od.listGroupsFromInventory()
 }
 
+// checkCacheInOfflineMode returns an error if the CLI is in offline mode and the plugin
+// inventory of the discovery is not in the cache, since it cannot be downloaded
+func (od *DBBackedOCIDiscovery) checkCacheInOfflineMode() error {
+	if !config.IsOfflineMode() || utils.PathExists(filepath.Join(od.pluginDataDir, plugininventory.SQliteDBFileName)) {
+		return nil
+	}
+	return NewOfflineError(fmt.Sprintf("reading the inventory of discovery '%s'", od.Name()))
+}
+
 func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error) {
 	var pluginEntries []*plugininventory.PluginInventoryEntry
 	var err error
@@ -122,7 +141,10 @@ func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error)
 			Version:       od.pluginCriteria.Version,
 			OS:            od.pluginCriteria.OS,
 			Arch:          od.pluginCriteria.Arch,
-			IncludeHidden: shouldIncludeHidden,
+			Publisher:     od.pluginCriteria.Publisher,
+			Vendor:        od.pluginCriteria.Vendor,
+			Keywords:      od.pluginCriteria.Keywords,
+			IncludeHidden: shouldIncludeHidden || od.pluginCriteria.IncludeHidden,
 		})
 		if err != nil {
 			return nil, err
@@ -147,6 +169,8 @@ func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error)
 			InstalledVersion:   "", // Not set when discovered, but later.
 			SupportedVersions:  versions,
 			Distribution:       entry.Artifacts,
+			Requirements:       entry.Requirements,
+			Deprecations:       entry.Deprecations,
 			Optional:           false,
 			Scope:              common.PluginScopeStandalone,
 			Source:             od.name,
@@ -154,6 +178,7 @@ func (od *DBBackedOCIDiscovery) listPluginsFromInventory() ([]Discovered, error)
 			DiscoveryType:      common.DiscoveryTypeOCI,
 			Target:             entry.Target,
 			Status:             common.PluginStatusNotInstalled, // Not set yet
+			Relevance:          entry.Relevance,
 		}
 		discoveredPlugins = append(discoveredPlugins, plugin)
 	}
@@ -208,8 +233,13 @@ func (od *DBBackedOCIDiscovery) fetchInventoryImage() error {
 	// The DB has changed and needs to be updated in the cache.
 	log.Infof("Reading plugin inventory for %q, this will take a few seconds.", od.image)
 
-	// Verify the inventory image signature before downloading the plugin inventory database
-	err = sigverifier.VerifyInventoryImageSignature(od.image)
+	// Verify the inventory image signature before downloading the plugin inventory database.
+	// Each discovery source is verified against its own trust configuration, if it has one.
+	trust := od.signatureTrust
+	if trust == nil {
+		trust = config.GetDiscoverySourceTrust(configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: od.name, Image: od.image}})
+	}
+	err = sigverifier.VerifyInventoryImageSignatureWithTrust(od.image, trust)
 	if err != nil {
 		return err
 	}
@@ -324,7 +354,17 @@ func (od *DBBackedOCIDiscovery) setupPluginInventory(inventoryDir, metadataDir s
 	}
 
 	// Copy the inventory database file from temp directory to pluginDataDir
-	return utils.CopyFile(inventoryDBFilePath, filepath.Join(od.pluginDataDir, plugininventory.SQliteDBFileName))
+	cachedDBFilePath := filepath.Join(od.pluginDataDir, plugininventory.SQliteDBFileName)
+	if err := utils.CopyFile(inventoryDBFilePath, cachedDBFilePath); err != nil {
+		return err
+	}
+
+	// Build the index used to search the plugins by keywords.
+	// Searching by keywords still works without it, so only warn on failure.
+	if err := plugininventory.CreateSearchIndex(cachedDBFilePath); err != nil {
+		log.Warningf("%v", err)
+	}
+	return nil
 }
 
 // checkImageCache will get the plugin inventory image digest as well as
@@ -419,8 +459,14 @@ func (od *DBBackedOCIDiscovery) checkDigestFileExistence(hashHexVal, digestPrefi
 }
 
 func getCacheTTLValue() int {
-	cacheTTL := constants.DefaultInventoryRefreshTTLSeconds
-	cacheTTLOverride := os.Getenv(constants.ConfigVariablePluginDBCacheTTLSeconds)
+	return getTTLValue(constants.DefaultInventoryRefreshTTLSeconds, constants.ConfigVariablePluginDBCacheTTLSeconds)
+}
+
+// getTTLValue returns the TTL in seconds set by the environment variable 'envVar',
+// or 'defaultTTL' if the variable is not set to a valid value
+func getTTLValue(defaultTTL int, envVar string) int {
+	cacheTTL := defaultTTL
+	cacheTTLOverride := os.Getenv(envVar)
 	if cacheTTLOverride != "" {
 		cacheTTLOverrideValue, err := strconv.Atoi(cacheTTLOverride)
 		if err == nil && cacheTTLOverrideValue >= 0 {
@@ -432,7 +478,25 @@ func getCacheTTLValue() int {
 
 // cacheTTLExpired checks if the last time the cache was refreshed has passed its TTL.
 func (od *DBBackedOCIDiscovery) cacheTTLExpired() bool {
-	matches, _ := filepath.Glob(filepath.Join(od.pluginDataDir, "digest.*"))
+	return cacheTTLExpired(od.pluginDataDir, od.image)
+}
+
+// resetCacheTTL resets the modification timestamp of the digest file to the current time.
+// This is used to avoid checking the inventory image digest too often.
+func (od *DBBackedOCIDiscovery) resetCacheTTL() {
+	resetCacheTTL(od.pluginDataDir)
+}
+
+// cacheTTLExpired checks if the last time the cache of the discovery with the specified
+// URI was refreshed has passed its TTL. The time of the last refresh is the modification
+// time of the digest file stored in the cache directory of the discovery.
+func cacheTTLExpired(pluginDataDir, uri string) bool {
+	return cacheTTLExpiredAfter(pluginDataDir, uri, getCacheTTLValue())
+}
+
+// cacheTTLExpiredAfter is like cacheTTLExpired() but with a TTL of 'ttlSeconds'.
+func cacheTTLExpiredAfter(pluginDataDir, uri string, ttlSeconds int) bool {
+	matches, _ := filepath.Glob(filepath.Join(pluginDataDir, "digest.*"))
 	if len(matches) == 1 {
 		file, err := os.Open(matches[0])
 		if err != nil {
@@ -442,16 +506,16 @@ func (od *DBBackedOCIDiscovery) cacheTTLExpired() bool {
 
 		scanner := bufio.NewScanner(file)
 		if scanner.Scan() {
-			// Check that the discovery image URI matches what is in the digest file.
+			// Check that the discovery URI matches what is in the digest file.
 			// This is important for test discoveries which can be changed by setting
 			// the TANZU_CLI_ADDITIONAL_PLUGIN_DISCOVERY_IMAGES_TEST_ONLY variable.
-			// If the image URI is not correct then the cache must be refreshed.
+			// If the URI is not correct then the cache must be refreshed.
 			cachedURI := scanner.Text()
-			if cachedURI == od.image {
+			if cachedURI == uri {
 				// The URI matches.  Now check the modification time of the digest file to see
 				// if the TTL is expired.
 				if stat, err := os.Stat(matches[0]); err == nil {
-					return time.Since(stat.ModTime()) > time.Duration(getCacheTTLValue())*time.Second
+					return time.Since(stat.ModTime()) > time.Duration(ttlSeconds)*time.Second
 				}
 			}
 		}
@@ -461,10 +525,20 @@ func (od *DBBackedOCIDiscovery) cacheTTLExpired() bool {
 	return true
 }
 
-// resetCacheTTL resets the modification timestamp of the digest file to the current time.
-// This is used to avoid checking the inventory image digest too often.
-func (od *DBBackedOCIDiscovery) resetCacheTTL() {
-	matches, _ := filepath.Glob(filepath.Join(od.pluginDataDir, "digest.*"))
+// writeCacheDigest replaces the digest file of a discovery by one named after the hash of the
+// cached 'content' and containing the discovery 'uri', which restarts the TTL of the cache.
+func writeCacheDigest(pluginDataDir string, content []byte, uri string) error {
+	matches, _ := filepath.Glob(filepath.Join(pluginDataDir, "digest.*"))
+	for _, filePath := range matches {
+		os.Remove(filePath)
+	}
+	hashFile := filepath.Join(pluginDataDir, fmt.Sprintf("digest.%x", sha256.Sum256(content)))
+	return os.WriteFile(hashFile, []byte(uri), 0644)
+}
+
+// resetCacheTTL resets the modification timestamp of the digest file of a discovery to the current time.
+func resetCacheTTL(pluginDataDir string) {
+	matches, _ := filepath.Glob(filepath.Join(pluginDataDir, "digest.*"))
 	if len(matches) == 1 {
 		var zeroTime time.Time
 		_ = os.Chtimes(matches[0], zeroTime, time.Now())
@@ -57,6 +57,9 @@ func (stub *stubInventory) UpdatePluginActivationState(_ *plugininventory.Plugin
 func (stub *stubInventory) UpdatePluginGroupActivationState(_ *plugininventory.PluginGroup) error {
 	return nil
 }
+func (stub *stubInventory) UpdatePluginDeprecation(_ *plugininventory.PluginInventoryEntry) error {
+	return nil
+}
 
 var _ = Describe("Unit tests for DB-backed OCI discovery", func() {
 	var (
@@ -0,0 +1,41 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+// LastCacheRefreshTime returns the last time the plugin inventory of any discovery
+// source was refreshed, or the zero time if no plugin inventory is in the cache
+func LastCacheRefreshTime() time.Time {
+	var lastRefresh time.Time
+	// The modification time of the digest file of a discovery is reset every time its cache is refreshed
+	matches, _ := filepath.Glob(filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, "*", "digest.*"))
+	for _, match := range matches {
+		if stat, err := os.Stat(match); err == nil && stat.ModTime().After(lastRefresh) {
+			lastRefresh = stat.ModTime()
+		}
+	}
+	return lastRefresh
+}
+
+// NewOfflineError returns the error reported when an operation requires network access
+// while the CLI is in offline mode
+func NewOfflineError(operation string) error {
+	lastRefresh := LastCacheRefreshTime()
+	if lastRefresh.IsZero() {
+		return errors.Errorf("%s requires network; the plugin inventory cache was never refreshed. Run the command without --offline and with %s unset once network access is available", operation, constants.OfflineMode)
+	}
+	return errors.Errorf("%s requires network; last cache refresh was %s ago. Run the command without --offline and with %s unset once network access is available",
+		operation, duration.HumanDuration(time.Since(lastRefresh)), constants.OfflineMode)
+}
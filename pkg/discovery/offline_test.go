@@ -0,0 +1,50 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestOfflineMode(t *testing.T) {
+	defer setupRESTDiscoveryCache(t)()
+	requests := 0
+	s := createTestServerWithGroups(validPlugins, nil, func(r *http.Request) int {
+		requests++
+		return http.StatusOK
+	})
+	defer s.Close()
+
+	t.Setenv(constants.OfflineMode, "true")
+	assert.True(t, LastCacheRefreshTime().IsZero())
+
+	// Without a cache, the discoveries report that network access is required
+	_, err := NewRESTDiscovery(discoveryName, s.URL, basePath, WithForceRefresh()).List()
+	assert.ErrorContains(t, err, "reading the inventory of discovery 'test' requires network; the plugin inventory cache was never refreshed")
+	_, err = NewOCIDiscovery("oci-discovery", "example.com/plugin-inventory:latest").List()
+	assert.ErrorContains(t, err, "reading the inventory of discovery 'oci-discovery' requires network")
+	_, err = NewKubernetesDiscovery("kubernetes-discovery", "", "", nil).List()
+	assert.ErrorContains(t, err, "discovering the plugins of kubernetes discovery 'kubernetes-discovery' requires network")
+	assert.Equal(t, 0, requests)
+
+	t.Setenv(constants.OfflineMode, "false")
+	_, err = NewRESTDiscovery(discoveryName, s.URL, basePath).List()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+	assert.False(t, LastCacheRefreshTime().IsZero())
+
+	// The cache is used even when a refresh is requested
+	t.Setenv(constants.OfflineMode, "true")
+	plugins, err := NewRESTDiscovery(discoveryName, s.URL, basePath, WithForceRefresh()).List()
+	assert.NoError(t, err)
+	assert.Len(t, plugins, len(validPlugins))
+	assert.Equal(t, 1, requests)
+
+	assert.ErrorContains(t, NewOfflineError("downloading plugin"), "downloading plugin requires network; last cache refresh was")
+}
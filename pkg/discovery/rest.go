@@ -7,18 +7,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
 )
 
+// RESTInventoryFileName is the name of the file storing the cached response of a REST discovery
+const RESTInventoryFileName = "rest_inventory.json"
+
 // Plugin contains information about a Tanzu CLI plugin discovered via a REST API.
 type Plugin struct {
 	// Name of the plugin.
@@ -46,12 +60,56 @@ type Plugin struct {
 	Target configtypes.Target `json:"target"`
 }
 
+// PluginGroup contains information about a plugin group discovered via a REST API.
+type PluginGroup struct {
+	// Vendor of the group.
+	Vendor string `json:"vendor"`
+
+	// Publisher of the group.
+	Publisher string `json:"publisher"`
+
+	// Name of the group.
+	Name string `json:"name"`
+
+	// Description of the group.
+	Description string `json:"description"`
+
+	// Hidden tells whether the plugin group should be ignored by the CLI.
+	Hidden bool `json:"hidden,omitempty"`
+
+	// Recommended version of the group that Tanzu CLI should install by default.
+	RecommendedVersion string `json:"recommendedVersion"`
+
+	// Versions contains the list of plugins of every version of the group.
+	Versions map[string][]PluginGroupPlugin `json:"versions"`
+}
+
+// PluginGroupPlugin identifies a plugin that is part of a version of a plugin group.
+type PluginGroupPlugin struct {
+	// Name of the plugin.
+	Name string `json:"name"`
+
+	// Target of the plugin.
+	Target configtypes.Target `json:"target"`
+
+	// Version of the plugin.
+	Version string `json:"version"`
+
+	// Mandatory specifies whether the plugin is always installed with the group.
+	Mandatory bool `json:"mandatory,omitempty"`
+}
+
 // ListPluginsResponse defines the response from List Plugins API.
+// The plugin groups are optional so that a single static JSON document
+// can be served as the inventory of the discovery.
+// The format of the response is described by the JSON schema rest_inventory.schema.json.
 type ListPluginsResponse struct {
-	Plugins []Plugin `json:"plugins"`
+	Plugins []Plugin      `json:"plugins"`
+	Groups  []PluginGroup `json:"groups,omitempty"`
 }
 
-// RESTDiscovery is an artifact discovery utilizing CLIPlugin API in kubernetes cluster
+// RESTDiscovery is an artifact discovery utilizing a REST API, e.g., a JSON
+// document served by a web server.
 type RESTDiscovery struct {
 	// name of the discovery.
 	name string
@@ -63,54 +121,242 @@ type RESTDiscovery struct {
 	basePath string
 	// client is the HTTP client used to make the REST API call.
 	client *http.Client
+	// pluginCriteria specifies different conditions that a plugin must respect to be discovered.
+	pluginCriteria *PluginDiscoveryCriteria
+	// groupCriteria specifies different conditions that a plugin group must respect to be discovered.
+	groupCriteria *GroupDiscoveryCriteria
+	// useLocalCacheOnly enables to get the inventory data from the cache without first refreshing cache
+	useLocalCacheOnly bool
+	// forceRefresh enables to force the refresh of the cached inventory data,
+	// even if the cache TTL has not expired
+	forceRefresh bool
+	// pluginDataDir is the location where the response of the REST API is cached
+	pluginDataDir string
+}
+
+// NewRESTDiscovery returns a new Discovery using the specified REST API.
+func NewRESTDiscovery(name, endpoint, basePath string, options ...DiscoveryOptions) Discovery {
+	opts := NewDiscoveryOpts()
+	for _, option := range options {
+		option(opts)
+	}
+
+	discovery := newRESTDiscovery(name, endpoint, basePath, opts)
+	discovery.pluginCriteria = opts.PluginDiscoveryCriteria
+	return discovery
+}
+
+// NewRESTGroupDiscovery returns a new plugin group Discovery using the specified REST API.
+func NewRESTGroupDiscovery(name, endpoint, basePath string, options ...DiscoveryOptions) GroupDiscovery {
+	opts := NewDiscoveryOpts()
+	for _, option := range options {
+		option(opts)
+	}
+
+	discovery := newRESTDiscovery(name, endpoint, basePath, opts)
+	discovery.groupCriteria = opts.GroupDiscoveryCriteria
+	return discovery
+}
+
+func newRESTDiscovery(name, endpoint, basePath string, opts *DiscoveryOpts) *RESTDiscovery {
+	discovery := &RESTDiscovery{
+		name:              name,
+		endpoint:          endpoint,
+		basePath:          basePath,
+		client:            http.DefaultClient,
+		useLocalCacheOnly: opts.UseLocalCacheOnly,
+		forceRefresh:      opts.ForceRefresh || opts.ForceInvalidation,
+		pluginDataDir:     filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, name),
+	}
+	// NOTE: the use of TEST_TANZU_CLI_USE_DB_CACHE_ONLY is for testing only
+	if useCacheOnlyForTesting, _ := strconv.ParseBool(os.Getenv("TEST_TANZU_CLI_USE_DB_CACHE_ONLY")); useCacheOnlyForTesting {
+		discovery.useLocalCacheOnly = true
+	}
+	return discovery
+}
+
+// RESTDiscoveryURL returns the URL of the REST API of a discovery.
+// The endpoint defaults to the https scheme when it does not specify one.
+func RESTDiscoveryURL(endpoint, basePath string) string {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "https://" + endpoint
+	}
+	if basePath == "" || basePath == "/" {
+		return strings.TrimSuffix(endpoint, "/")
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/" + strings.TrimPrefix(basePath, "/")
+}
+
+var nonAlphanumericRegexp = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// RESTDiscoveryAuthVariable returns the name of the variable providing the specified credential,
+// i.e., TOKEN, USERNAME or PASSWORD, to access the REST discovery with the specified name.
+// The variable can be set in the environment or in the CLI configuration using 'tanzu config set env.<variable>'.
+func RESTDiscoveryAuthVariable(discoveryName, credential string) string {
+	name := strings.Trim(nonAlphanumericRegexp.ReplaceAllString(strings.ToUpper(discoveryName), "_"), "_")
+	return constants.RESTDiscoverySourceAuthPrefix + name + "_" + credential
 }
 
-// NewRESTDiscovery returns a new kubernetes repository
-func NewRESTDiscovery(name, endpoint, basePath string) Discovery {
-	return &RESTDiscovery{
-		name:     name,
-		endpoint: endpoint,
-		basePath: basePath,
-		client:   http.DefaultClient,
+func (d *RESTDiscovery) url() string {
+	return RESTDiscoveryURL(d.endpoint, d.basePath)
+}
+
+// setAuth sets the bearer token or the basic authentication credentials
+// configured for the discovery on the request. The credentials are only
+// sent over https so that they cannot be intercepted.
+func (d *RESTDiscovery) setAuth(req *http.Request) error {
+	token := os.Getenv(RESTDiscoveryAuthVariable(d.name, "TOKEN"))
+	username := os.Getenv(RESTDiscoveryAuthVariable(d.name, "USERNAME"))
+	if token == "" && username == "" {
+		return nil
+	}
+	if req.URL.Scheme != "https" {
+		return errors.Errorf("the credentials of discovery source '%s' are only sent over https but its URL is %q", d.name, d.url())
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
 	}
+	req.SetBasicAuth(username, os.Getenv(RESTDiscoveryAuthVariable(d.name, "PASSWORD")))
+	return nil
 }
-func (d *RESTDiscovery) doRequest(req *http.Request, v interface{}) error {
+
+func (d *RESTDiscovery) doRequest(req *http.Request) ([]byte, error) {
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	req.Header.Set("Accept", "application/json; charset=utf-8")
+	if err := d.setAuth(req); err != nil {
+		return nil, err
+	}
 
 	res, err := d.client.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer res.Body.Close()
 
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("API error, status code: %d", res.StatusCode)
+		return nil, fmt.Errorf("API error, status code: %d", res.StatusCode)
 	}
 
-	return json.NewDecoder(res.Body).Decode(v)
+	return io.ReadAll(res.Body)
 }
 
-// List available plugins.
-func (d *RESTDiscovery) List() ([]Discovered, error) {
+// getInventory returns the inventory of the discovery, from the cache if
+// it was refreshed within the cache TTL or else from the REST API
+func (d *RESTDiscovery) getInventory() (*ListPluginsResponse, error) {
+	if d.useLocalCacheOnly {
+		res, err := d.readCachedInventory()
+		if err != nil && config.IsOfflineMode() {
+			return nil, NewOfflineError(fmt.Sprintf("reading the inventory of discovery '%s'", d.name))
+		}
+		return res, err
+	}
+	if !d.forceRefresh && !cacheTTLExpired(d.pluginDataDir, d.url()) {
+		// The inventory does not need to be up-to-date by the second.
+		// This avoids uselessly refreshing for commands that are run close together.
+		if res, err := d.readCachedInventory(); err == nil {
+			return res, nil
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s", d.endpoint, d.basePath), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, "GET", d.url(), http.NoBody)
 	if err != nil {
 		return nil, err
 	}
+	b, err := d.doRequest(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch the inventory of discovery '%s' from %q", d.name, d.url())
+	}
 
 	var res ListPluginsResponse
-	if err := d.doRequest(req, &res); err != nil {
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, errors.Wrapf(err, "invalid inventory for discovery '%s'", d.name)
+	}
+	if err := validateInventory(&res); err != nil {
+		return nil, errors.Wrapf(err, "invalid inventory for discovery '%s'", d.name)
+	}
+
+	if err := d.writeCachedInventory(b); err != nil {
+		// The cache is an optimization, don't fail
+		log.V(6).Warningf("unable to cache the inventory of discovery '%s': %v", d.name, err)
+	}
+	return &res, nil
+}
+
+// validateInventory checks that the plugin groups of the inventory respect the constraints
+// of the JSON schema rest_inventory.schema.json. Invalid plugins are ignored instead when
+// they are listed, as some servers have been known to return incomplete plugin entries.
+func validateInventory(res *ListPluginsResponse) error {
+	for i := range res.Groups {
+		g := &res.Groups[i]
+		if g.Vendor == "" || g.Publisher == "" || g.Name == "" {
+			return errors.Errorf("plugin group #%d must have a vendor, a publisher and a name", i+1)
+		}
+		groupID := fmt.Sprintf("%s-%s/%s", g.Vendor, g.Publisher, g.Name)
+		if len(g.Versions) == 0 {
+			return errors.Errorf("plugin group '%s' must have at least one version", groupID)
+		}
+		for version, plugins := range g.Versions {
+			if len(plugins) == 0 {
+				return errors.Errorf("version '%s' of plugin group '%s' must have at least one plugin", version, groupID)
+			}
+			for _, p := range plugins {
+				if p.Name == "" || p.Version == "" {
+					return errors.Errorf("the plugins of version '%s' of plugin group '%s' must have a name and a version", version, groupID)
+				}
+				if p.Target != "" && !configtypes.IsValidTarget(string(p.Target), true, false) {
+					return errors.Errorf("plugin '%s' of version '%s' of plugin group '%s' has an invalid target '%s'", p.Name, version, groupID, p.Target)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *RESTDiscovery) readCachedInventory() (*ListPluginsResponse, error) {
+	b, err := os.ReadFile(filepath.Join(d.pluginDataDir, RESTInventoryFileName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "the inventory of discovery '%s' is not in the cache", d.name)
+	}
+	var res ListPluginsResponse
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, errors.Wrapf(err, "invalid cached inventory for discovery '%s'", d.name)
+	}
+	return &res, nil
+}
+
+// writeCachedInventory stores the inventory in the cache along with a digest file.
+// Like for the OCI discoveries, the digest file contains the URL of the inventory
+// and its modification time is used to know when the cache TTL has expired.
+func (d *RESTDiscovery) writeCachedInventory(b []byte) error {
+	if err := os.MkdirAll(d.pluginDataDir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(d.pluginDataDir, RESTInventoryFileName), b, 0644); err != nil {
+		return err
+	}
+	return writeCacheDigest(d.pluginDataDir, b, d.url())
+}
+
+// List available plugins.
+func (d *RESTDiscovery) List() ([]Discovered, error) {
+	res, err := d.getInventory()
+	if err != nil {
 		return nil, err
 	}
 
-	// Convert all CLIPlugin resources to Discovered object
+	// Convert all plugins of the response to Discovered object
 	plugins := make([]Discovered, 0)
 	for i := range res.Plugins {
-		dp, err := DiscoveredFromREST(&res.Plugins[i])
+		p := d.filterPlugin(&res.Plugins[i])
+		if p == nil {
+			continue
+		}
+		dp, err := DiscoveredFromREST(p)
 		if err != nil {
 			return nil, err
 		}
@@ -118,12 +364,108 @@ func (d *RESTDiscovery) List() ([]Discovered, error) {
 			continue
 		}
 		dp.Source = d.name
+		if d.pluginCriteria != nil && d.pluginCriteria.Keywords != "" {
+			dp.Relevance = plugininventory.KeywordRelevance(d.pluginCriteria.Keywords, p.Name, p.Description, "", "")
+		}
 		plugins = append(plugins, dp)
 	}
 
 	return plugins, nil
 }
 
+// filterPlugin returns the plugin restricted to the versions and artifacts matching
+// the plugin criteria of the discovery, or nil if the plugin does not match
+func (d *RESTDiscovery) filterPlugin(p *Plugin) *Plugin {
+	c := d.pluginCriteria
+	if c == nil {
+		return p
+	}
+	// The plugins of a REST discovery don't have a publisher or a vendor
+	if (c.Name != "" && c.Name != p.Name) ||
+		(c.Target != configtypes.TargetUnknown && c.Target != configtypes.StringToTarget(string(p.Target))) ||
+		c.Publisher != "" || c.Vendor != "" ||
+		(c.Keywords != "" && plugininventory.KeywordRelevance(c.Keywords, p.Name, p.Description, "", "") == 0) {
+		return nil
+	}
+
+	filtered := *p
+	filtered.Artifacts = map[string]cliv1alpha1.ArtifactList{}
+	for version, artifacts := range p.Artifacts {
+		if !versionMatches(c.Version, version, p.RecommendedVersion) {
+			continue
+		}
+		var artifactList cliv1alpha1.ArtifactList
+		for _, a := range artifacts {
+			if (c.OS == "" || c.OS == a.OS) && (c.Arch == "" || c.Arch == a.Arch) {
+				artifactList = append(artifactList, a)
+			}
+		}
+		if len(artifactList) > 0 {
+			filtered.Artifacts[version] = artifactList
+		}
+	}
+	if len(filtered.Artifacts) == 0 {
+		return nil
+	}
+	return &filtered
+}
+
+// GetGroups returns the plugin groups defined in the discovery.
+func (d *RESTDiscovery) GetGroups() ([]*plugininventory.PluginGroup, error) {
+	res, err := d.getInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	shouldIncludeHidden, _ := strconv.ParseBool(os.Getenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting))
+	var groups []*plugininventory.PluginGroup
+	for i := range res.Groups {
+		if res.Groups[i].Hidden && !shouldIncludeHidden {
+			continue
+		}
+		if group := d.filterGroup(PluginGroupFromREST(&res.Groups[i])); group != nil {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// filterGroup returns the group restricted to the versions matching the group
+// criteria of the discovery, or nil if the group does not match
+func (d *RESTDiscovery) filterGroup(group *plugininventory.PluginGroup) *plugininventory.PluginGroup {
+	c := d.groupCriteria
+	if c == nil {
+		return group
+	}
+	if (c.Vendor != "" && c.Vendor != group.Vendor) ||
+		(c.Publisher != "" && c.Publisher != group.Publisher) ||
+		(c.Name != "" && c.Name != group.Name) {
+		return nil
+	}
+	for version := range group.Versions {
+		if !versionMatches(c.Version, version, group.RecommendedVersion) {
+			delete(group.Versions, version)
+		}
+	}
+	if len(group.Versions) == 0 {
+		return nil
+	}
+	return group
+}
+
+// versionMatches returns true if the version matches the version of the criteria, which
+// can be empty, "latest" for the recommended version, or a complete or partial version,
+// e.g., v1 or v1.2
+func versionMatches(criteriaVersion, version, recommendedVersion string) bool {
+	switch criteriaVersion {
+	case "":
+		return true
+	case cli.VersionLatest:
+		return version == recommendedVersion
+	}
+	return version == criteriaVersion || strings.HasPrefix(version, criteriaVersion+".")
+}
+
 // Name of the repository.
 func (d *RESTDiscovery) Name() string {
 	return d.name
@@ -142,6 +484,7 @@ func DiscoveredFromREST(p *Plugin) (Discovered, error) {
 		RecommendedVersion: p.RecommendedVersion,
 		Optional:           p.Optional,
 		Target:             configtypes.StringToTarget(string(p.Target)),
+		DiscoveryType:      common.DiscoveryTypeREST,
 	}
 	dp.SupportedVersions = make([]string, 0)
 	for v := range p.Artifacts {
@@ -153,3 +496,29 @@ func DiscoveredFromREST(p *Plugin) (Discovered, error) {
 	dp.Distribution = distribution.ArtifactsFromK8sV1alpha1(p.Artifacts)
 	return dp, nil
 }
+
+// PluginGroupFromREST returns the plugin group object from a plugin group of a REST API.
+func PluginGroupFromREST(g *PluginGroup) *plugininventory.PluginGroup {
+	group := &plugininventory.PluginGroup{
+		Vendor:             g.Vendor,
+		Publisher:          g.Publisher,
+		Name:               g.Name,
+		Description:        g.Description,
+		Hidden:             g.Hidden,
+		RecommendedVersion: g.RecommendedVersion,
+		Versions:           map[string][]*plugininventory.PluginGroupPluginEntry{},
+	}
+	for version, plugins := range g.Versions {
+		for _, p := range plugins {
+			group.Versions[version] = append(group.Versions[version], &plugininventory.PluginGroupPluginEntry{
+				PluginIdentifier: plugininventory.PluginIdentifier{
+					Name:    p.Name,
+					Target:  configtypes.StringToTarget(string(p.Target)),
+					Version: p.Version,
+				},
+				Mandatory: p.Mandatory,
+			})
+		}
+	}
+	return group
+}
@@ -7,12 +7,18 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 )
 
 const (
@@ -119,9 +125,23 @@ var (
 )
 
 func createTestServer(plugins []Plugin) *httptest.Server {
+	return createTestServerWithGroups(plugins, nil, nil)
+}
+
+func createTestServerWithGroups(plugins []Plugin, groups []PluginGroup, handle func(r *http.Request) int) *httptest.Server {
+	return httptest.NewServer(createTestHandler(plugins, groups, handle))
+}
+
+func createTestHandler(plugins []Plugin, groups []PluginGroup, handle func(r *http.Request) int) http.Handler {
 	m := mux.NewRouter()
-	m.HandleFunc(basePath, func(w http.ResponseWriter, _ *http.Request) {
-		res := ListPluginsResponse{plugins}
+	m.HandleFunc(basePath, func(w http.ResponseWriter, r *http.Request) {
+		if handle != nil {
+			if status := handle(r); status != http.StatusOK {
+				w.WriteHeader(status)
+				return
+			}
+		}
+		res := ListPluginsResponse{Plugins: plugins, Groups: groups}
 		b, err := json.Marshal(res)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -132,10 +152,19 @@ func createTestServer(plugins []Plugin) *httptest.Server {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	})
-	return httptest.NewServer(m)
+	return m
+}
+
+func setupRESTDiscoveryCache(t *testing.T) func() {
+	cacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = t.TempDir()
+	return func() {
+		common.DefaultCacheDir = cacheDir
+	}
 }
 
 func TestRESTDiscovery(t *testing.T) {
+	defer setupRESTDiscoveryCache(t)()
 	s := createTestServer(validPlugins)
 	defer s.Close()
 
@@ -154,6 +183,7 @@ func TestRESTDiscovery(t *testing.T) {
 }
 
 func TestRESTDiscoveryWithInvalidPlugins(t *testing.T) {
+	defer setupRESTDiscoveryCache(t)()
 	s := createTestServer(append(validPlugins, invalidPlugins...))
 	defer s.Close()
 
@@ -171,3 +201,280 @@ func TestRESTDiscoveryWithInvalidPlugins(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expList, actList)
 }
+
+func TestRESTDiscoveryWithPluginCriteria(t *testing.T) {
+	defer setupRESTDiscoveryCache(t)()
+	s := createTestServer(validPlugins)
+	defer s.Close()
+
+	d := NewRESTDiscovery(discoveryName, s.URL, basePath, WithPluginDiscoveryCriteria(&PluginDiscoveryCriteria{
+		Name:    "foo",
+		Version: "0.0.1",
+		OS:      "linux",
+		Arch:    "amd64",
+	}))
+	actList, err := d.List()
+	assert.NoError(t, err)
+	assert.Len(t, actList, 1)
+	assert.Equal(t, "foo", actList[0].Name)
+	assert.Equal(t, []string{"0.0.1"}, actList[0].SupportedVersions)
+
+	d = NewRESTDiscovery(discoveryName, s.URL, basePath, WithPluginDiscoveryCriteria(&PluginDiscoveryCriteria{
+		Name: "bar",
+		OS:   "freebsd",
+	}))
+	actList, err = d.List()
+	assert.NoError(t, err)
+	assert.Empty(t, actList)
+
+	d = NewRESTDiscovery(discoveryName, s.URL, basePath, WithPluginDiscoveryCriteria(&PluginDiscoveryCriteria{
+		Keywords: "plugin BAR",
+	}))
+	actList, err = d.List()
+	assert.NoError(t, err)
+	assert.Len(t, actList, 1)
+	assert.Equal(t, "bar", actList[0].Name)
+	assert.Greater(t, actList[0].Relevance, 0.0)
+
+	// The plugins of a REST discovery have no publisher
+	d = NewRESTDiscovery(discoveryName, s.URL, basePath, WithPluginDiscoveryCriteria(&PluginDiscoveryCriteria{
+		Publisher: "tkg",
+	}))
+	actList, err = d.List()
+	assert.NoError(t, err)
+	assert.Empty(t, actList)
+}
+
+func TestRESTDiscoveryCache(t *testing.T) {
+	defer setupRESTDiscoveryCache(t)()
+	requests := 0
+	s := createTestServerWithGroups(validPlugins, nil, func(r *http.Request) int {
+		requests++
+		return http.StatusOK
+	})
+	defer s.Close()
+
+	// The cache is only refreshed once its TTL has expired
+	_, err := NewRESTDiscovery(discoveryName, s.URL, basePath).List()
+	assert.NoError(t, err)
+	actList, err := NewRESTDiscovery(discoveryName, s.URL, basePath).List()
+	assert.NoError(t, err)
+	assert.Len(t, actList, 2)
+	assert.Equal(t, 1, requests)
+
+	_, err = NewRESTDiscovery(discoveryName, s.URL, basePath, WithForceRefresh()).List()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	actList, err = NewRESTDiscovery(discoveryName, s.URL, basePath, WithUseLocalCacheOnly()).List()
+	assert.NoError(t, err)
+	assert.Len(t, actList, 2)
+	assert.Equal(t, 2, requests)
+
+	os.Setenv(constants.ConfigVariablePluginDBCacheTTLSeconds, "0")
+	defer os.Unsetenv(constants.ConfigVariablePluginDBCacheTTLSeconds)
+	_, err = NewRESTDiscovery(discoveryName, s.URL, basePath).List()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, requests)
+
+	// Without a cache, the inventory cannot be read when only the cache must be used
+	_, err = NewRESTDiscovery("other", s.URL, basePath, WithUseLocalCacheOnly()).List()
+	assert.ErrorContains(t, err, "the inventory of discovery 'other' is not in the cache")
+}
+
+func TestRESTDiscoveryAuth(t *testing.T) {
+	defer setupRESTDiscoveryCache(t)()
+	handler := func(r *http.Request) int {
+		if r.Header.Get("Authorization") == "Bearer my-token" {
+			return http.StatusOK
+		}
+		if username, password, ok := r.BasicAuth(); ok && username == "user" && password == "secret" {
+			return http.StatusOK
+		}
+		return http.StatusUnauthorized
+	}
+	s := httptest.NewTLSServer(createTestHandler(validPlugins, nil, handler))
+	defer s.Close()
+
+	// newTestRESTDiscovery returns a discovery which trusts the certificate of the test server
+	newTestRESTDiscovery := func(endpoint string) Discovery {
+		d := NewRESTDiscovery("my-repo", endpoint, basePath, WithForceRefresh())
+		d.(*RESTDiscovery).client = s.Client()
+		return d
+	}
+
+	_, err := newTestRESTDiscovery(s.URL).List()
+	assert.ErrorContains(t, err, "API error, status code: 401")
+
+	assert.Equal(t, "TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_TOKEN", RESTDiscoveryAuthVariable("my-repo", "TOKEN"))
+	os.Setenv("TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_TOKEN", "my-token")
+	actList, err := newTestRESTDiscovery(s.URL).List()
+	assert.NoError(t, err)
+	assert.Len(t, actList, 2)
+
+	// The credentials are not sent over http
+	insecureRequests := 0
+	insecureServer := createTestServerWithGroups(validPlugins, nil, func(r *http.Request) int {
+		insecureRequests++
+		return handler(r)
+	})
+	defer insecureServer.Close()
+	_, err = newTestRESTDiscovery(insecureServer.URL).List()
+	os.Unsetenv("TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_TOKEN")
+	assert.ErrorContains(t, err, "the credentials of discovery source 'my-repo' are only sent over https but its URL is \""+insecureServer.URL+basePath+"\"")
+	assert.Equal(t, 0, insecureRequests)
+
+	os.Setenv("TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_USERNAME", "user")
+	os.Setenv("TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_PASSWORD", "secret")
+	defer os.Unsetenv("TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_USERNAME")
+	defer os.Unsetenv("TANZU_CLI_DISCOVERY_SOURCE_MY_REPO_PASSWORD")
+	actList, err = newTestRESTDiscovery(s.URL).List()
+	assert.NoError(t, err)
+	assert.Len(t, actList, 2)
+
+	_, err = newTestRESTDiscovery(insecureServer.URL).List()
+	assert.ErrorContains(t, err, "are only sent over https")
+	assert.Equal(t, 0, insecureRequests)
+}
+
+func TestRESTGroupDiscovery(t *testing.T) {
+	defer setupRESTDiscoveryCache(t)()
+	groups := []PluginGroup{
+		{
+			Vendor:             "vmware",
+			Publisher:          "tkg",
+			Name:               "default",
+			Description:        "Plugins for TKG",
+			RecommendedVersion: "v2.1.0",
+			Versions: map[string][]PluginGroupPlugin{
+				"v2.1.0": {{Name: "foo", Target: "kubernetes", Version: "1.0.0", Mandatory: true}},
+				"v2.0.0": {{Name: "foo", Target: "kubernetes", Version: "0.0.1", Mandatory: true}, {Name: "bar", Version: "0.0.1"}},
+			},
+		},
+		{
+			Vendor:    "vmware",
+			Publisher: "tkg",
+			Name:      "hidden",
+			Hidden:    true,
+			Versions: map[string][]PluginGroupPlugin{
+				"v1.0.0": {{Name: "bar", Version: "0.0.1"}},
+			},
+		},
+	}
+	s := createTestServerWithGroups(validPlugins, groups, nil)
+	defer s.Close()
+
+	actGroups, err := NewRESTGroupDiscovery(discoveryName, s.URL, basePath).GetGroups()
+	assert.NoError(t, err)
+	assert.Len(t, actGroups, 1)
+	assert.Equal(t, "vmware-tkg/default", plugininventory.PluginGroupToID(actGroups[0]))
+	assert.Len(t, actGroups[0].Versions, 2)
+	assert.Equal(t, configtypes.TargetK8s, actGroups[0].Versions["v2.1.0"][0].Target)
+	assert.True(t, actGroups[0].Versions["v2.1.0"][0].Mandatory)
+
+	actGroups, err = NewRESTGroupDiscovery(discoveryName, s.URL, basePath, WithGroupDiscoveryCriteria(&GroupDiscoveryCriteria{
+		Name:    "default",
+		Version: "latest",
+	})).GetGroups()
+	assert.NoError(t, err)
+	assert.Len(t, actGroups, 1)
+	assert.Len(t, actGroups[0].Versions, 1)
+	assert.Len(t, actGroups[0].Versions["v2.1.0"], 1)
+
+	os.Setenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting, "true")
+	defer os.Unsetenv(constants.ConfigVariableIncludeDeactivatedPluginsForTesting)
+	actGroups, err = NewRESTGroupDiscovery(discoveryName, s.URL, basePath, WithGroupDiscoveryCriteria(&GroupDiscoveryCriteria{
+		Name: "hidden",
+	})).GetGroups()
+	assert.NoError(t, err)
+	assert.Len(t, actGroups, 1)
+}
+
+func TestRESTDiscoveryURL(t *testing.T) {
+	assert.Equal(t, "https://example.com/v1alpha1/cli/plugins", RESTDiscoveryURL("example.com", basePath))
+	assert.Equal(t, "http://example.com/inventory.json", RESTDiscoveryURL("http://example.com/", "inventory.json"))
+	assert.Equal(t, "https://example.com", RESTDiscoveryURL("https://example.com", ""))
+}
+
+func TestRESTGroupDiscoveryWithInvalidGroup(t *testing.T) {
+	defer setupRESTDiscoveryCache(t)()
+	groups := []PluginGroup{
+		{
+			Vendor:    "vmware",
+			Publisher: "tkg",
+			Name:      "default",
+			Versions: map[string][]PluginGroupPlugin{
+				"v1.0.0": {{Name: "foo", Target: "invalid", Version: "1.0.0"}},
+			},
+		},
+	}
+	s := createTestServerWithGroups(validPlugins, groups, nil)
+	defer s.Close()
+
+	_, err := NewRESTGroupDiscovery(discoveryName, s.URL, basePath).GetGroups()
+	assert.ErrorContains(t, err, "invalid inventory for discovery 'test'")
+	assert.ErrorContains(t, err, "plugin 'foo' of version 'v1.0.0' of plugin group 'vmware-tkg/default' has an invalid target 'invalid'")
+}
+
+// TestValidateInventoryMatchesSchema checks that validateInventory() rejects the plugin groups
+// which do not respect the constraints of the JSON schema of the inventory
+func TestValidateInventoryMatchesSchema(t *testing.T) {
+	b, err := os.ReadFile("rest_inventory.schema.json")
+	assert.NoError(t, err)
+	var schema struct {
+		Defs map[string]struct {
+			Required []string `json:"required"`
+			Enum     []string `json:"enum"`
+		} `json:"$defs"`
+	}
+	assert.NoError(t, json.Unmarshal(b, &schema))
+
+	newGroupPlugin := func() map[string]interface{} {
+		return map[string]interface{}{"name": "foo", "target": "kubernetes", "version": "v1.0.0", "mandatory": true}
+	}
+	newGroup := func(plugin map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"vendor":    "vmware",
+			"publisher": "tkg",
+			"name":      "default",
+			"versions":  map[string]interface{}{"v1.0.0": []interface{}{plugin}},
+		}
+	}
+	validate := func(group map[string]interface{}) error {
+		b, err := json.Marshal(map[string]interface{}{"groups": []interface{}{group}})
+		assert.NoError(t, err)
+		var res ListPluginsResponse
+		assert.NoError(t, json.Unmarshal(b, &res))
+		return validateInventory(&res)
+	}
+
+	assert.NoError(t, validate(newGroup(newGroupPlugin())))
+
+	assert.NotEmpty(t, schema.Defs["group"].Required)
+	for _, property := range schema.Defs["group"].Required {
+		group := newGroup(newGroupPlugin())
+		delete(group, property)
+		assert.Error(t, validate(group), "group without %s", property)
+	}
+	assert.NotEmpty(t, schema.Defs["groupPlugin"].Required)
+	for _, property := range schema.Defs["groupPlugin"].Required {
+		plugin := newGroupPlugin()
+		delete(plugin, property)
+		assert.Error(t, validate(newGroup(plugin)), "group plugin without %s", property)
+	}
+
+	// A version of a group must contain at least one plugin
+	group := newGroup(newGroupPlugin())
+	group["versions"] = map[string]interface{}{"v1.0.0": []interface{}{}}
+	assert.Error(t, validate(group))
+
+	assert.NotEmpty(t, schema.Defs["target"].Enum)
+	for _, target := range schema.Defs["target"].Enum {
+		plugin := newGroupPlugin()
+		plugin["target"] = target
+		assert.NoError(t, validate(newGroup(plugin)), "target %q", target)
+	}
+	plugin := newGroupPlugin()
+	plugin["target"] = "invalid"
+	assert.Error(t, validate(newGroup(plugin)))
+}
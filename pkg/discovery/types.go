@@ -7,6 +7,7 @@ import (
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 )
 
 // Discovered defines discovered plugin resource
@@ -32,9 +33,20 @@ type Discovered struct {
 	// https://github.com/Masterminds/semver#sorting-semantic-versions.
 	SupportedVersions []string
 
+	// VersionConstraint is the semver range the RecommendedVersion was selected from, if any.
+	VersionConstraint string
+
 	// Distribution is an interface to download a single plugin binary.
 	Distribution distribution.Distribution
 
+	// Requirements contains the minimum CLI version and the plugin dependencies
+	// of the supported versions that have any, keyed by version.
+	Requirements map[string]plugininventory.PluginRequirements
+
+	// Deprecations contains the deprecation of the supported versions
+	// that are deprecated, keyed by version.
+	Deprecations map[string]plugininventory.PluginDeprecation
+
 	// Optional specifies whether the plugin is mandatory or optional
 	// If optional, the plugin will not get auto-downloaded as part of
 	// `tanzu login` or `tanzu plugin sync` command
@@ -42,6 +54,18 @@ type Discovered struct {
 	// to download a specific plugin run, `tanzu plugin install <plugin-name>`
 	Optional bool
 
+	// Deprecated specifies whether the plugin is deprecated.
+	Deprecated bool
+
+	// DeprecationMessage explains why the plugin is deprecated and what to do about it.
+	DeprecationMessage string
+
+	// ReplacedBy is the name of the plugin replacing this plugin, if any.
+	ReplacedBy string
+
+	// ReleaseNotes contains the release notes of the supported versions that have any, keyed by version.
+	ReleaseNotes map[string]string
+
 	// Scope is the context association level of the plugin.
 	Scope string
 
@@ -61,6 +85,10 @@ type Discovered struct {
 
 	// Status is the installed/uninstalled status of the plugin.
 	Status string
+
+	// Relevance tells how well the plugin matches the keywords of the discovery criteria.
+	// A higher value means a more relevant plugin. It is only set when searching by keywords.
+	Relevance float64
 }
 
 // DiscoveredSorter sorts discovered objects.
@@ -77,6 +77,9 @@ func getDiscoverySourceNameAndURL(source configtypes.PluginDiscovery) (string, s
 	case source.OCI != nil:
 		name = source.OCI.Name
 		url = source.OCI.Image
+	case source.REST != nil:
+		name = source.REST.Name
+		url = RESTDiscoveryURL(source.REST.Endpoint, source.REST.BasePath)
 	default:
 		return "", "", errors.New("unknown discovery source type")
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	cliv1alpha1 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	cliv1alpha2 "github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/artifact"
 )
 
@@ -144,3 +145,26 @@ func ArtifactsFromK8sV1alpha1(m map[string]cliv1alpha1.ArtifactList) Artifacts {
 	}
 	return aMap
 }
+
+// ArtifactFromK8sV1alpha2 returns Artifact from k8sV1alpha2
+func ArtifactFromK8sV1alpha2(a cliv1alpha2.Artifact) Artifact { //nolint:gocritic
+	return ArtifactFromK8sV1alpha1(cliv1alpha1.Artifact(a))
+}
+
+// ArtifactListFromK8sV1alpha2 returns ArtifactList from k8sV1alpha2
+func ArtifactListFromK8sV1alpha2(l cliv1alpha2.ArtifactList) ArtifactList {
+	aList := make(ArtifactList, 0, len(l))
+	for _, a := range l {
+		aList = append(aList, ArtifactFromK8sV1alpha2(a))
+	}
+	return aList
+}
+
+// ArtifactsFromK8sV1alpha2 returns Artifacts from k8sV1alpha2
+func ArtifactsFromK8sV1alpha2(m map[string]cliv1alpha2.ArtifactList) Artifacts {
+	aMap := make(Artifacts, len(m))
+	for v, l := range m {
+		aMap[v] = ArtifactListFromK8sV1alpha2(l)
+	}
+	return aMap
+}
@@ -0,0 +1,270 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package downloadcache implements a content-addressed cache of the downloaded
+// plugin binaries so that a plugin binary does not need to be downloaded again
+// when it is re-installed or when switching between versions of a plugin.
+package downloadcache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+const (
+	// binaryFileName is the name of the cached plugin binary within the directory of an entry
+	binaryFileName = "plugin"
+	// infoFileName is the name of the file describing the cached plugin binary within the directory of an entry
+	infoFileName = "info.yaml"
+
+	bytesPerMB = 1024 * 1024
+)
+
+// digestRegexp matches a hex-encoded SHA256 digest. The digest is used as the name of
+// the directory of an entry so anything else could escape the download cache directory.
+var digestRegexp = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// isValidDigest returns true if the digest is a hex-encoded SHA256 digest
+func isValidDigest(digest string) bool {
+	return digestRegexp.MatchString(digest)
+}
+
+// Entry describes a plugin binary stored in the download cache.
+type Entry struct {
+	// Digest is the SHA256 digest of the plugin binary which identifies the entry
+	Digest string `yaml:"digest" json:"digest"`
+	// Name of the plugin
+	Name string `yaml:"name" json:"name"`
+	// Target of the plugin
+	Target configtypes.Target `yaml:"target" json:"target"`
+	// Version of the plugin
+	Version string `yaml:"version" json:"version"`
+	// OS of the plugin binary
+	OS string `yaml:"os" json:"os"`
+	// Arch of the plugin binary
+	Arch string `yaml:"arch" json:"arch"`
+	// Size of the plugin binary in bytes
+	Size int64 `yaml:"-" json:"size"`
+	// LastUsed is the last time the plugin binary was stored in or read from the cache
+	LastUsed time.Time `yaml:"-" json:"lastUsed"`
+}
+
+// Dir returns the directory of the download cache.
+func Dir() string {
+	return filepath.Join(common.DefaultCacheDir, common.PluginDownloadCacheDirName)
+}
+
+// MaxSize returns the maximum size in bytes of the download cache.
+// A value of 0 means the download cache is disabled.
+func MaxSize() int64 {
+	maxSizeMB := int64(constants.DefaultPluginDownloadCacheMaxSizeMB)
+	if maxSizeStr := os.Getenv(constants.PluginDownloadCacheMaxSize); maxSizeStr != "" {
+		size, err := strconv.ParseInt(maxSizeStr, 10, 64)
+		if err != nil || size < 0 {
+			log.Warningf("Invalid value '%s' for %s, using the default of %d MB", maxSizeStr, constants.PluginDownloadCacheMaxSize, maxSizeMB)
+		} else {
+			maxSizeMB = size
+		}
+	}
+	return maxSizeMB * bytesPerMB
+}
+
+// IsEnabled returns true if plugin binaries should be stored in the download cache.
+func IsEnabled() bool {
+	return MaxSize() > 0
+}
+
+// Get returns the plugin binary with the given digest if it is present in the
+// download cache. The content of the binary is verified against the digest and
+// a corrupted entry is removed from the cache.
+// Nothing is found when the download cache is disabled, even if it still has entries.
+func Get(digest string) ([]byte, bool) {
+	if !isValidDigest(digest) || !IsEnabled() {
+		return nil, false
+	}
+
+	binaryPath := filepath.Join(Dir(), digest, binaryFileName)
+	b, err := os.ReadFile(binaryPath)
+	if err != nil {
+		return nil, false
+	}
+	if fmt.Sprintf("%x", sha256.Sum256(b)) != digest {
+		log.V(6).Warningf("Removing corrupted plugin binary '%s' from the download cache", digest)
+		_ = os.RemoveAll(filepath.Join(Dir(), digest))
+		return nil, false
+	}
+
+	// Keep track of the usage of the entry so that the least recently used entries are pruned first
+	now := time.Now()
+	_ = os.Chtimes(binaryPath, now, now)
+	return b, true
+}
+
+// Put stores the plugin binary described by the entry in the download cache.
+// The digest of the entry must match the content of the binary.
+// Once the binary is stored, the cache is pruned to respect its maximum size.
+func Put(entry *Entry, binary []byte) error {
+	if !IsEnabled() {
+		return nil
+	}
+	if !isValidDigest(entry.Digest) {
+		return errors.Errorf("invalid digest '%s' for plugin '%s'", entry.Digest, entry.Name)
+	}
+	if fmt.Sprintf("%x", sha256.Sum256(binary)) != entry.Digest {
+		return errors.Errorf("the digest of plugin '%s' does not match its binary", entry.Name)
+	}
+
+	entryDir := filepath.Join(Dir(), entry.Digest)
+	if err := os.MkdirAll(entryDir, 0755); err != nil {
+		return errors.Wrap(err, "unable to create the download cache directory")
+	}
+
+	info, err := yaml.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomically(filepath.Join(entryDir, infoFileName), info); err != nil {
+		return err
+	}
+	if err := writeFileAtomically(filepath.Join(entryDir, binaryFileName), binary); err != nil {
+		return err
+	}
+
+	_, err = Prune(MaxSize())
+	return err
+}
+
+// List returns the entries of the download cache, the most recently used first.
+func List() ([]Entry, error) {
+	dirEntries, err := os.ReadDir(Dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "unable to read the download cache directory")
+	}
+
+	var entries []Entry
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+		entry, err := readEntry(dirEntry.Name())
+		if err != nil {
+			// Ignore incomplete entries, they are removed when the cache is emptied
+			log.V(6).Infof("Ignoring invalid download cache entry '%s': %v", dirEntry.Name(), err)
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastUsed.After(entries[j].LastUsed)
+	})
+	return entries, nil
+}
+
+// Size returns the total size in bytes of the plugin binaries in the download cache.
+func Size() (int64, error) {
+	entries, err := List()
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for i := range entries {
+		size += entries[i].Size
+	}
+	return size, nil
+}
+
+// Prune removes the least recently used entries of the download cache until its
+// total size is not more than maxSize bytes. A maxSize of 0 empties the cache.
+// The removed entries are returned.
+func Prune(maxSize int64) ([]Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	var removed []Entry
+	for i := range entries {
+		if size+entries[i].Size <= maxSize {
+			size += entries[i].Size
+			continue
+		}
+		if !isValidDigest(entries[i].Digest) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(Dir(), entries[i].Digest)); err != nil {
+			return removed, errors.Wrapf(err, "unable to remove '%s' from the download cache", entries[i].Digest)
+		}
+		removed = append(removed, entries[i])
+	}
+
+	if maxSize == 0 {
+		// Also remove any incomplete entries
+		if err := os.RemoveAll(Dir()); err != nil {
+			return removed, errors.Wrap(err, "unable to remove the download cache directory")
+		}
+	}
+	return removed, nil
+}
+
+func readEntry(digest string) (*Entry, error) {
+	if !isValidDigest(digest) {
+		return nil, errors.Errorf("invalid digest '%s'", digest)
+	}
+	entryDir := filepath.Join(Dir(), digest)
+	stat, err := os.Stat(filepath.Join(entryDir, binaryFileName))
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(filepath.Join(entryDir, infoFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := yaml.Unmarshal(b, &entry); err != nil {
+		return nil, err
+	}
+	entry.Digest = digest
+	entry.Size = stat.Size()
+	entry.LastUsed = stat.ModTime()
+	return &entry, nil
+}
+
+// writeFileAtomically writes the file through a temporary file so that
+// a concurrent reader never sees a partially written file
+func writeFileAtomically(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "unable to write to the download cache")
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return errors.Wrap(err, "unable to write to the download cache")
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrap(err, "unable to write to the download cache")
+	}
+	return os.Rename(f.Name(), path)
+}
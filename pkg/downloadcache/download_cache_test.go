@@ -0,0 +1,200 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package downloadcache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func setupCacheDirForTesting(t *testing.T) {
+	currentCacheDir := common.DefaultCacheDir
+	common.DefaultCacheDir = t.TempDir()
+	t.Cleanup(func() { common.DefaultCacheDir = currentCacheDir })
+}
+
+func newTestEntry(name, version string, size int) (*Entry, []byte) {
+	binary := []byte(strings.Repeat(name+version, size/len(name+version)+1)[:size])
+	return &Entry{
+		Digest:  fmt.Sprintf("%x", sha256.Sum256(binary)),
+		Name:    name,
+		Target:  configtypes.TargetK8s,
+		Version: version,
+		OS:      "linux",
+		Arch:    "amd64",
+	}, binary
+}
+
+func TestPutAndGet(t *testing.T) {
+	assertions := assert.New(t)
+	setupCacheDirForTesting(t)
+
+	entry, binary := newTestEntry("cluster", "v1.0.0", 100)
+
+	_, found := Get(entry.Digest)
+	assertions.False(found)
+
+	assertions.Nil(Put(entry, binary))
+	b, found := Get(entry.Digest)
+	assertions.True(found)
+	assertions.Equal(binary, b)
+
+	entries, err := List()
+	assertions.Nil(err)
+	assertions.Equal(1, len(entries))
+	assertions.Equal("cluster", entries[0].Name)
+	assertions.Equal("v1.0.0", entries[0].Version)
+	assertions.Equal(configtypes.TargetK8s, entries[0].Target)
+	assertions.Equal(int64(100), entries[0].Size)
+
+	size, err := Size()
+	assertions.Nil(err)
+	assertions.Equal(int64(100), size)
+
+	// A binary which does not match its digest is rejected
+	other, _ := newTestEntry("other", "v1.0.0", 10)
+	assertions.NotNil(Put(other, binary))
+}
+
+func TestInvalidDigest(t *testing.T) {
+	assertions := assert.New(t)
+	setupCacheDirForTesting(t)
+
+	entry, binary := newTestEntry("cluster", "v1.0.0", 100)
+	outsideFile := filepath.Join(common.DefaultCacheDir, binaryFileName)
+	assertions.Nil(os.WriteFile(outsideFile, binary, 0755))
+
+	// A digest which is not a SHA256 digest is never used to build a path
+	for _, digest := range []string{"", "..", "../" + entry.Digest, strings.ToUpper(entry.Digest), entry.Digest[:63]} {
+		_, found := Get(digest)
+		assertions.False(found, digest)
+
+		invalid := *entry
+		invalid.Digest = digest
+		err := Put(&invalid, binary)
+		assertions.NotNil(err, digest)
+		assertions.Contains(err.Error(), "invalid digest")
+	}
+
+	// A directory of the download cache which is not named after a digest is not an entry and is not pruned
+	invalidDir := filepath.Join(Dir(), "invalid")
+	assertions.Nil(os.MkdirAll(invalidDir, 0755))
+	assertions.Nil(os.WriteFile(filepath.Join(invalidDir, binaryFileName), binary, 0755))
+	assertions.Nil(os.WriteFile(filepath.Join(invalidDir, infoFileName), []byte("name: cluster\n"), 0644))
+	entries, err := List()
+	assertions.Nil(err)
+	assertions.Empty(entries)
+	_, err = Prune(1)
+	assertions.Nil(err)
+	assertions.DirExists(invalidDir)
+	assertions.FileExists(outsideFile)
+}
+
+func TestGetCorruptedEntry(t *testing.T) {
+	assertions := assert.New(t)
+	setupCacheDirForTesting(t)
+
+	entry, binary := newTestEntry("cluster", "v1.0.0", 100)
+	assertions.Nil(Put(entry, binary))
+
+	err := os.WriteFile(filepath.Join(Dir(), entry.Digest, binaryFileName), []byte("corrupted"), 0755)
+	assertions.Nil(err)
+
+	_, found := Get(entry.Digest)
+	assertions.False(found)
+	_, err = os.Stat(filepath.Join(Dir(), entry.Digest))
+	assertions.True(os.IsNotExist(err))
+}
+
+func TestPrune(t *testing.T) {
+	assertions := assert.New(t)
+	setupCacheDirForTesting(t)
+
+	oldest, oldestBinary := newTestEntry("cluster", "v1.0.0", 100)
+	middle, middleBinary := newTestEntry("cluster", "v1.1.0", 100)
+	newest, newestBinary := newTestEntry("cluster", "v1.2.0", 100)
+	assertions.Nil(Put(oldest, oldestBinary))
+	assertions.Nil(Put(middle, middleBinary))
+	assertions.Nil(Put(newest, newestBinary))
+
+	// Set the usage times explicitly to avoid depending on the timestamp resolution of the filesystem
+	for i, e := range []*Entry{oldest, middle, newest} {
+		usedAt := time.Now().Add(time.Duration(i-3) * time.Hour)
+		assertions.Nil(os.Chtimes(filepath.Join(Dir(), e.Digest, binaryFileName), usedAt, usedAt))
+	}
+
+	// Reading an entry makes it the most recently used
+	_, found := Get(oldest.Digest)
+	assertions.True(found)
+
+	removed, err := Prune(200)
+	assertions.Nil(err)
+	assertions.Equal(1, len(removed))
+	assertions.Equal(middle.Digest, removed[0].Digest)
+
+	entries, err := List()
+	assertions.Nil(err)
+	assertions.Equal(2, len(entries))
+	assertions.Equal(oldest.Digest, entries[0].Digest)
+	assertions.Equal(newest.Digest, entries[1].Digest)
+
+	removed, err = Prune(0)
+	assertions.Nil(err)
+	assertions.Equal(2, len(removed))
+	size, err := Size()
+	assertions.Nil(err)
+	assertions.Equal(int64(0), size)
+}
+
+func TestMaxSize(t *testing.T) {
+	assertions := assert.New(t)
+	setupCacheDirForTesting(t)
+
+	assertions.Equal(int64(constants.DefaultPluginDownloadCacheMaxSizeMB*bytesPerMB), MaxSize())
+
+	t.Setenv(constants.PluginDownloadCacheMaxSize, "10")
+	assertions.Equal(int64(10*bytesPerMB), MaxSize())
+
+	t.Setenv(constants.PluginDownloadCacheMaxSize, "invalid")
+	assertions.Equal(int64(constants.DefaultPluginDownloadCacheMaxSizeMB*bytesPerMB), MaxSize())
+
+	// A size of 0 disables the cache
+	t.Setenv(constants.PluginDownloadCacheMaxSize, "0")
+	assertions.False(IsEnabled())
+	entry, binary := newTestEntry("cluster", "v1.0.0", 100)
+	assertions.Nil(Put(entry, binary))
+	_, found := Get(entry.Digest)
+	assertions.False(found)
+}
+
+func TestGetWhenDisabled(t *testing.T) {
+	assertions := assert.New(t)
+	setupCacheDirForTesting(t)
+
+	entry, binary := newTestEntry("cluster", "v1.0.0", 100)
+	assertions.Nil(Put(entry, binary))
+
+	// The entries stored before the cache was disabled are not used
+	t.Setenv(constants.PluginDownloadCacheMaxSize, "0")
+	_, found := Get(entry.Digest)
+	assertions.False(found)
+
+	// but they are kept until the cache is pruned
+	t.Setenv(constants.PluginDownloadCacheMaxSize, "")
+	b, found := Get(entry.Digest)
+	assertions.True(found)
+	assertions.Equal(binary, b)
+}
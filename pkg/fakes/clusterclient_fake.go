@@ -4,7 +4,7 @@ package fakes
 import (
 	"sync"
 
-	"github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cluster"
 	"github.com/vmware-tanzu/tanzu-framework/capabilities/client/pkg/discovery"
 )
@@ -34,16 +34,29 @@ type ClusterClient struct {
 		result1 map[string]string
 		result2 error
 	}
-	ListCLIPluginResourcesStub        func() ([]v1alpha1.CLIPlugin, error)
+	ListCLIPluginResourcesStub        func() ([]v1alpha2.CLIPlugin, error)
 	listCLIPluginResourcesMutex       sync.RWMutex
 	listCLIPluginResourcesArgsForCall []struct {
 	}
 	listCLIPluginResourcesReturns struct {
-		result1 []v1alpha1.CLIPlugin
+		result1 []v1alpha2.CLIPlugin
 		result2 error
 	}
 	listCLIPluginResourcesReturnsOnCall map[int]struct {
-		result1 []v1alpha1.CLIPlugin
+		result1 []v1alpha2.CLIPlugin
+		result2 error
+	}
+	ListCLIPluginResourcesWithOptionsStub        func(cluster.CLIPluginListOptions) ([]v1alpha2.CLIPlugin, error)
+	listCLIPluginResourcesWithOptionsMutex       sync.RWMutex
+	listCLIPluginResourcesWithOptionsArgsForCall []struct {
+		arg1 cluster.CLIPluginListOptions
+	}
+	listCLIPluginResourcesWithOptionsReturns struct {
+		result1 []v1alpha2.CLIPlugin
+		result2 error
+	}
+	listCLIPluginResourcesWithOptionsReturnsOnCall map[int]struct {
+		result1 []v1alpha2.CLIPlugin
 		result2 error
 	}
 	VerifyCLIPluginCRDStub        func() (bool, error)
@@ -174,7 +187,7 @@ func (fake *ClusterClient) GetCLIPluginImageRepositoryOverrideReturnsOnCall(i in
 	}{result1, result2}
 }
 
-func (fake *ClusterClient) ListCLIPluginResources() ([]v1alpha1.CLIPlugin, error) {
+func (fake *ClusterClient) ListCLIPluginResources() ([]v1alpha2.CLIPlugin, error) {
 	fake.listCLIPluginResourcesMutex.Lock()
 	ret, specificReturn := fake.listCLIPluginResourcesReturnsOnCall[len(fake.listCLIPluginResourcesArgsForCall)]
 	fake.listCLIPluginResourcesArgsForCall = append(fake.listCLIPluginResourcesArgsForCall, struct {
@@ -198,34 +211,98 @@ func (fake *ClusterClient) ListCLIPluginResourcesCallCount() int {
 	return len(fake.listCLIPluginResourcesArgsForCall)
 }
 
-func (fake *ClusterClient) ListCLIPluginResourcesCalls(stub func() ([]v1alpha1.CLIPlugin, error)) {
+func (fake *ClusterClient) ListCLIPluginResourcesCalls(stub func() ([]v1alpha2.CLIPlugin, error)) {
 	fake.listCLIPluginResourcesMutex.Lock()
 	defer fake.listCLIPluginResourcesMutex.Unlock()
 	fake.ListCLIPluginResourcesStub = stub
 }
 
-func (fake *ClusterClient) ListCLIPluginResourcesReturns(result1 []v1alpha1.CLIPlugin, result2 error) {
+func (fake *ClusterClient) ListCLIPluginResourcesReturns(result1 []v1alpha2.CLIPlugin, result2 error) {
 	fake.listCLIPluginResourcesMutex.Lock()
 	defer fake.listCLIPluginResourcesMutex.Unlock()
 	fake.ListCLIPluginResourcesStub = nil
 	fake.listCLIPluginResourcesReturns = struct {
-		result1 []v1alpha1.CLIPlugin
+		result1 []v1alpha2.CLIPlugin
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *ClusterClient) ListCLIPluginResourcesReturnsOnCall(i int, result1 []v1alpha1.CLIPlugin, result2 error) {
+func (fake *ClusterClient) ListCLIPluginResourcesReturnsOnCall(i int, result1 []v1alpha2.CLIPlugin, result2 error) {
 	fake.listCLIPluginResourcesMutex.Lock()
 	defer fake.listCLIPluginResourcesMutex.Unlock()
 	fake.ListCLIPluginResourcesStub = nil
 	if fake.listCLIPluginResourcesReturnsOnCall == nil {
 		fake.listCLIPluginResourcesReturnsOnCall = make(map[int]struct {
-			result1 []v1alpha1.CLIPlugin
+			result1 []v1alpha2.CLIPlugin
 			result2 error
 		})
 	}
 	fake.listCLIPluginResourcesReturnsOnCall[i] = struct {
-		result1 []v1alpha1.CLIPlugin
+		result1 []v1alpha2.CLIPlugin
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ClusterClient) ListCLIPluginResourcesWithOptions(arg1 cluster.CLIPluginListOptions) ([]v1alpha2.CLIPlugin, error) {
+	fake.listCLIPluginResourcesWithOptionsMutex.Lock()
+	ret, specificReturn := fake.listCLIPluginResourcesWithOptionsReturnsOnCall[len(fake.listCLIPluginResourcesWithOptionsArgsForCall)]
+	fake.listCLIPluginResourcesWithOptionsArgsForCall = append(fake.listCLIPluginResourcesWithOptionsArgsForCall, struct {
+		arg1 cluster.CLIPluginListOptions
+	}{arg1})
+	stub := fake.ListCLIPluginResourcesWithOptionsStub
+	fakeReturns := fake.listCLIPluginResourcesWithOptionsReturns
+	fake.recordInvocation("ListCLIPluginResourcesWithOptions", []interface{}{arg1})
+	fake.listCLIPluginResourcesWithOptionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ClusterClient) ListCLIPluginResourcesWithOptionsCallCount() int {
+	fake.listCLIPluginResourcesWithOptionsMutex.RLock()
+	defer fake.listCLIPluginResourcesWithOptionsMutex.RUnlock()
+	return len(fake.listCLIPluginResourcesWithOptionsArgsForCall)
+}
+
+func (fake *ClusterClient) ListCLIPluginResourcesWithOptionsCalls(stub func(cluster.CLIPluginListOptions) ([]v1alpha2.CLIPlugin, error)) {
+	fake.listCLIPluginResourcesWithOptionsMutex.Lock()
+	defer fake.listCLIPluginResourcesWithOptionsMutex.Unlock()
+	fake.ListCLIPluginResourcesWithOptionsStub = stub
+}
+
+func (fake *ClusterClient) ListCLIPluginResourcesWithOptionsArgsForCall(i int) cluster.CLIPluginListOptions {
+	fake.listCLIPluginResourcesWithOptionsMutex.RLock()
+	defer fake.listCLIPluginResourcesWithOptionsMutex.RUnlock()
+	argsForCall := fake.listCLIPluginResourcesWithOptionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ClusterClient) ListCLIPluginResourcesWithOptionsReturns(result1 []v1alpha2.CLIPlugin, result2 error) {
+	fake.listCLIPluginResourcesWithOptionsMutex.Lock()
+	defer fake.listCLIPluginResourcesWithOptionsMutex.Unlock()
+	fake.ListCLIPluginResourcesWithOptionsStub = nil
+	fake.listCLIPluginResourcesWithOptionsReturns = struct {
+		result1 []v1alpha2.CLIPlugin
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ClusterClient) ListCLIPluginResourcesWithOptionsReturnsOnCall(i int, result1 []v1alpha2.CLIPlugin, result2 error) {
+	fake.listCLIPluginResourcesWithOptionsMutex.Lock()
+	defer fake.listCLIPluginResourcesWithOptionsMutex.Unlock()
+	fake.ListCLIPluginResourcesWithOptionsStub = nil
+	if fake.listCLIPluginResourcesWithOptionsReturnsOnCall == nil {
+		fake.listCLIPluginResourcesWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 []v1alpha2.CLIPlugin
+			result2 error
+		})
+	}
+	fake.listCLIPluginResourcesWithOptionsReturnsOnCall[i] = struct {
+		result1 []v1alpha2.CLIPlugin
 		result2 error
 	}{result1, result2}
 }
@@ -295,6 +372,8 @@ func (fake *ClusterClient) Invocations() map[string][][]interface{} {
 	defer fake.getCLIPluginImageRepositoryOverrideMutex.RUnlock()
 	fake.listCLIPluginResourcesMutex.RLock()
 	defer fake.listCLIPluginResourcesMutex.RUnlock()
+	fake.listCLIPluginResourcesWithOptionsMutex.RLock()
+	defer fake.listCLIPluginResourcesWithOptionsMutex.RUnlock()
 	fake.verifyCLIPluginCRDMutex.RLock()
 	defer fake.verifyCLIPluginCRDMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
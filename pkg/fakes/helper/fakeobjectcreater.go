@@ -13,7 +13,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha1"
+	"github.com/vmware-tanzu/tanzu-cli/apis/cli/v1alpha2"
 )
 
 // ###################### Fake CAPI objects creation helper ######################
@@ -69,8 +69,8 @@ func GetFakePinnipedInfo(pinnipedInfo PinnipedInfo) string {
 }
 
 // NewCLIPlugin returns new NewCLIPlugin object
-func NewCLIPlugin(options TestCLIPluginOption) v1alpha1.CLIPlugin {
-	artifacts := []v1alpha1.Artifact{
+func NewCLIPlugin(options TestCLIPluginOption) v1alpha2.CLIPlugin {
+	artifacts := []v1alpha2.Artifact{
 		{
 			Image: "fake.image.repo.com/tkg/plugin/test-darwin-plugin:v1.4.0",
 			OS:    "darwin",
@@ -87,14 +87,14 @@ func NewCLIPlugin(options TestCLIPluginOption) v1alpha1.CLIPlugin {
 			Arch:  "amd64",
 		},
 	}
-	cliplugin := v1alpha1.CLIPlugin{
+	cliplugin := v1alpha2.CLIPlugin{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: options.Name,
 		},
-		Spec: v1alpha1.CLIPluginSpec{
+		Spec: v1alpha2.CLIPluginSpec{
 			Description:        options.Description,
 			RecommendedVersion: options.RecommendedVersion,
-			Artifacts: map[string]v1alpha1.ArtifactList{
+			Artifacts: map[string]v1alpha2.ArtifactList{
 				"v1.0.0": artifacts,
 			},
 		},
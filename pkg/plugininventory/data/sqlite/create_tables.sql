@@ -27,3 +27,30 @@ CREATE TABLE IF NOT EXISTS "PluginGroups" (
 		"Hidden"             TEXT NOT NULL,
 		PRIMARY KEY("Vendor", "Publisher", "GroupName", "GroupVersion", "PluginName", "Target")
 );
+
+CREATE TABLE IF NOT EXISTS "PluginRequirements" (
+		"PluginName"         TEXT NOT NULL,
+		"Target"             TEXT NOT NULL,
+		"Version"            TEXT NOT NULL,
+		"MinCLIVersion"      TEXT NOT NULL,
+		PRIMARY KEY("PluginName", "Target", "Version")
+);
+
+CREATE TABLE IF NOT EXISTS "PluginDependencies" (
+		"PluginName"         TEXT NOT NULL,
+		"Target"             TEXT NOT NULL,
+		"Version"            TEXT NOT NULL,
+		"DependencyName"     TEXT NOT NULL,
+		"DependencyTarget"   TEXT NOT NULL,
+		"DependencyVersion"  TEXT NOT NULL,
+		PRIMARY KEY("PluginName", "Target", "Version", "DependencyName", "DependencyTarget")
+);
+
+CREATE TABLE IF NOT EXISTS "PluginDeprecations" (
+		"PluginName"         TEXT NOT NULL,
+		"Target"             TEXT NOT NULL,
+		"Version"            TEXT NOT NULL,
+		"Status"             TEXT NOT NULL,
+		"Message"            TEXT NOT NULL,
+		PRIMARY KEY("PluginName", "Target", "Version")
+);
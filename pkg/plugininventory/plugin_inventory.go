@@ -45,6 +45,10 @@ type PluginInventory interface {
 
 	// UpdatePluginGroupActivationState updates plugin-group metadata to activate or deactivate the plugin-group
 	UpdatePluginGroupActivationState(*PluginGroup) error
+
+	// UpdatePluginDeprecation updates the deprecation of the plugin versions found in the artifacts
+	// of the entry. The versions without a deprecation in the entry are no longer deprecated.
+	UpdatePluginDeprecation(*PluginInventoryEntry) error
 }
 
 // PluginInventoryEntry represents the inventory information
@@ -69,6 +73,55 @@ type PluginInventoryEntry struct {
 	Hidden bool
 	// Artifacts contains an artifact list for every available version.
 	Artifacts distribution.Artifacts
+	// Requirements contains the requirements of every version that has any,
+	// keyed by version.
+	Requirements map[string]PluginRequirements
+	// Deprecations contains the deprecation of every version that is deprecated,
+	// keyed by version.
+	Deprecations map[string]PluginDeprecation
+	// Relevance tells how well the plugin matches the keywords it was searched with.
+	// A higher value means a more relevant plugin. It is only set when searching by keywords.
+	Relevance float64
+}
+
+// PluginRequirements represents what a specific version of a plugin
+// needs to be installed alongside it to work properly.
+type PluginRequirements struct {
+	// MinCLIVersion is the minimum version of the Tanzu CLI
+	// the plugin version works with.
+	MinCLIVersion string
+	// Dependencies are the other plugins the plugin version needs.
+	Dependencies []PluginDependency
+}
+
+// PluginDeprecationStatus tells how far a plugin version is in its deprecation
+type PluginDeprecationStatus string
+
+const (
+	// PluginDeprecationStatusDeprecated means the plugin version still works
+	// but should be replaced by a newer version.
+	PluginDeprecationStatusDeprecated PluginDeprecationStatus = "deprecated"
+	// PluginDeprecationStatusEndOfLife means the plugin version is no longer supported.
+	PluginDeprecationStatusEndOfLife PluginDeprecationStatus = "end-of-life"
+)
+
+// PluginDeprecation represents the deprecation of a specific version of a plugin
+type PluginDeprecation struct {
+	// Status is the deprecation status of the plugin version
+	Status PluginDeprecationStatus
+	// Message explains why the plugin version is deprecated
+	Message string
+}
+
+// PluginDependency represents a plugin that is needed by another plugin
+type PluginDependency struct {
+	// Name is the name of the plugin
+	Name string
+	// Target is the target of the plugin
+	Target configtypes.Target
+	// VersionConstraint is the semver range the version of the plugin must
+	// satisfy, e.g. ">=1.2.0". An empty value accepts any version.
+	VersionConstraint string
 }
 
 // PluginInventoryFilter allows to specify different criteria for
@@ -88,6 +141,9 @@ type PluginInventoryFilter struct {
 	Publisher string
 	// Vendor of the plugins to look for
 	Vendor string
+	// Keywords to look for in the name, description, publisher and vendor of the plugins.
+	// Multiple keywords are separated by spaces and must all be found.
+	Keywords string
 	// IncludeHidden indicates if hidden plugins should be included
 	IncludeHidden bool
 }
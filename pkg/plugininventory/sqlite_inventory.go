@@ -46,6 +46,18 @@ const (
 	// The column order must also match the order used in getPluginNextRow().
 	pluginOrderClause = "ORDER BY PluginName,Target,Version"
 
+	// requirementSelectClause is the SELECT section of the query used to extract the
+	// minimum CLI version of plugin versions from the PluginRequirements table
+	requirementSelectClause = "SELECT PluginName,Target,Version,MinCLIVersion FROM PluginRequirements"
+
+	// dependencySelectClause is the SELECT section of the query used to extract the
+	// plugins needed by plugin versions from the PluginDependencies table
+	dependencySelectClause = "SELECT PluginName,Target,Version,DependencyName,DependencyTarget,DependencyVersion FROM PluginDependencies"
+
+	// deprecationSelectClause is the SELECT section of the query used to extract the
+	// deprecation of plugin versions from the PluginDeprecations table
+	deprecationSelectClause = "SELECT PluginName,Target,Version,Status,Message FROM PluginDeprecations"
+
 	// groupSelectClause is the SELECT section of the query used to extract plugin groups from the PluginGroups table
 	groupSelectClause = "SELECT Vendor,Publisher,GroupName,GroupVersion,Description,PluginName,Target,PluginVersion,Mandatory,Hidden FROM PluginGroups"
 
@@ -194,7 +206,155 @@ func (b *SQLiteInventory) getPluginsFromDB(filter *PluginInventoryFilter) ([]*Pl
 	}
 	defer rows.Close()
 
-	return b.extractPluginsFromRows(rows)
+	plugins, err := b.extractPluginsFromRows(rows)
+	if err != nil {
+		return plugins, err
+	}
+	plugins, err = b.filterPluginsByKeywords(db, plugins, filter.Keywords)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.addPluginRequirements(db, plugins); err != nil {
+		return plugins, err
+	}
+	return plugins, b.addPluginDeprecations(db, plugins)
+}
+
+// addPluginRequirements fills the requirements of the versions of the specified plugins
+// from the PluginRequirements and PluginDependencies tables.
+func (b *SQLiteInventory) addPluginRequirements(db *sql.DB, plugins []*PluginInventoryEntry) error {
+	if len(plugins) == 0 {
+		return nil
+	}
+	// Inventories published before plugin requirements were introduced don't have the tables
+	exists, err := tableExists(db, "PluginRequirements")
+	if err != nil || !exists {
+		return err
+	}
+
+	pluginsByID := make(map[string]*PluginInventoryEntry, len(plugins))
+	for _, p := range plugins {
+		pluginsByID[catalog.PluginNameTarget(p.Name, p.Target)] = p
+	}
+	// Only keep the requirements of the versions that were found
+	pluginOfVersion := func(name, target, version string) *PluginInventoryEntry {
+		p := pluginsByID[catalog.PluginNameTarget(name, configtypes.StringToTarget(strings.ToLower(target)))]
+		if p == nil {
+			return nil
+		}
+		if _, ok := p.Artifacts[version]; !ok {
+			return nil
+		}
+		if p.Requirements == nil {
+			p.Requirements = make(map[string]PluginRequirements)
+		}
+		return p
+	}
+
+	whereClause := ""
+	if len(plugins) == 1 {
+		whereClause = fmt.Sprintf("WHERE PluginName='%s' AND Target='%s'", plugins[0].Name, string(plugins[0].Target))
+	}
+
+	rows, err := db.Query(fmt.Sprintf("%s %s", requirementSelectClause, whereClause))
+	if err != nil {
+		return errors.Wrapf(err, "unable to setup DB query for DB at '%s'", b.inventoryFile)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, target, version, minCLIVersion string
+		if err := rows.Scan(&name, &target, &version, &minCLIVersion); err != nil {
+			return err
+		}
+		if p := pluginOfVersion(name, target, version); p != nil {
+			requirements := p.Requirements[version]
+			requirements.MinCLIVersion = minCLIVersion
+			p.Requirements[version] = requirements
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	depRows, err := db.Query(fmt.Sprintf("%s %s ORDER BY PluginName,Target,Version,DependencyName,DependencyTarget", dependencySelectClause, whereClause))
+	if err != nil {
+		return errors.Wrapf(err, "unable to setup DB query for DB at '%s'", b.inventoryFile)
+	}
+	defer depRows.Close()
+	for depRows.Next() {
+		var name, target, version, depName, depTarget, depVersion string
+		if err := depRows.Scan(&name, &target, &version, &depName, &depTarget, &depVersion); err != nil {
+			return err
+		}
+		if p := pluginOfVersion(name, target, version); p != nil {
+			requirements := p.Requirements[version]
+			requirements.Dependencies = append(requirements.Dependencies, PluginDependency{
+				Name:              depName,
+				Target:            configtypes.StringToTarget(strings.ToLower(depTarget)),
+				VersionConstraint: depVersion,
+			})
+			p.Requirements[version] = requirements
+		}
+	}
+	return depRows.Err()
+}
+
+// addPluginDeprecations fills the deprecation of the versions of the specified plugins
+// from the PluginDeprecations table.
+func (b *SQLiteInventory) addPluginDeprecations(db *sql.DB, plugins []*PluginInventoryEntry) error {
+	if len(plugins) == 0 {
+		return nil
+	}
+	// Inventories published before plugin deprecations were introduced don't have the table
+	exists, err := tableExists(db, "PluginDeprecations")
+	if err != nil || !exists {
+		return err
+	}
+
+	pluginsByID := make(map[string]*PluginInventoryEntry, len(plugins))
+	for _, p := range plugins {
+		pluginsByID[catalog.PluginNameTarget(p.Name, p.Target)] = p
+	}
+
+	whereClause := ""
+	if len(plugins) == 1 {
+		whereClause = fmt.Sprintf("WHERE PluginName='%s' AND Target='%s'", plugins[0].Name, string(plugins[0].Target))
+	}
+
+	rows, err := db.Query(fmt.Sprintf("%s %s", deprecationSelectClause, whereClause))
+	if err != nil {
+		return errors.Wrapf(err, "unable to setup DB query for DB at '%s'", b.inventoryFile)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, target, version, status, message string
+		if err := rows.Scan(&name, &target, &version, &status, &message); err != nil {
+			return err
+		}
+		// Only keep the deprecation of the versions that were found
+		p := pluginsByID[catalog.PluginNameTarget(name, configtypes.StringToTarget(strings.ToLower(target)))]
+		if p == nil {
+			continue
+		}
+		if _, ok := p.Artifacts[version]; !ok {
+			continue
+		}
+		if p.Deprecations == nil {
+			p.Deprecations = make(map[string]PluginDeprecation)
+		}
+		p.Deprecations[version] = PluginDeprecation{Status: PluginDeprecationStatus(status), Message: message}
+	}
+	return rows.Err()
+}
+
+// tableExists checks if the DB contains the specified table
+func tableExists(db *sql.DB, table string) (bool, error) {
+	var name string
+	err := db.QueryRow("SELECT name FROM sqlite_master WHERE type='table' AND name=?;", table).Scan(&name)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
 }
 
 // createPluginWhereClause parses the filter and creates the WHERE clause for the DB query.
@@ -640,6 +800,71 @@ func (b *SQLiteInventory) InsertPlugin(pluginInventoryEntry *PluginInventoryEntr
 			writeSQLStatementLogs(fmt.Sprintf("INSERT INTO PluginBinaries VALUES(%v,%v,%v,%v,%v,%v,%v,%v,%v,%v,%v,%v);\n", row.name, row.target, row.recommendedVersion, row.version, row.hidden, row.description, row.publisher, row.vendor, row.os, row.arch, row.digest, row.uri))
 		}
 	}
+	if err := insertPluginRequirements(db, pluginInventoryEntry); err != nil {
+		return err
+	}
+	return insertPluginDeprecations(db, pluginInventoryEntry)
+}
+
+// insertPluginRequirements inserts the requirements of the plugin versions to the inventory
+func insertPluginRequirements(db *sql.DB, pluginInventoryEntry *PluginInventoryEntry) error {
+	if len(pluginInventoryEntry.Requirements) == 0 {
+		return nil
+	}
+	// The inventory may have been created before plugin requirements were introduced
+	if _, err := db.Exec(CreateTablesSchema); err != nil {
+		return errors.Wrap(err, "error while creating tables to the database")
+	}
+
+	name := pluginInventoryEntry.Name
+	target := string(pluginInventoryEntry.Target)
+	for version, requirements := range pluginInventoryEntry.Requirements {
+		if requirements.MinCLIVersion != "" {
+			_, err := db.Exec("INSERT INTO PluginRequirements VALUES(?,?,?,?);", name, target, version, requirements.MinCLIVersion)
+			if err != nil {
+				return errors.Wrapf(err, "unable to insert the requirements of plugin '%s' version '%s'", name, version)
+			}
+			writeSQLStatementLogs(fmt.Sprintf("INSERT INTO PluginRequirements VALUES(%v,%v,%v,%v);\n", name, target, version, requirements.MinCLIVersion))
+		}
+		for _, dep := range requirements.Dependencies {
+			_, err := db.Exec("INSERT INTO PluginDependencies VALUES(?,?,?,?,?,?);", name, target, version, dep.Name, string(dep.Target), dep.VersionConstraint)
+			if err != nil {
+				return errors.Wrapf(err, "unable to insert the dependency '%s' of plugin '%s' version '%s'", dep.Name, name, version)
+			}
+			writeSQLStatementLogs(fmt.Sprintf("INSERT INTO PluginDependencies VALUES(%v,%v,%v,%v,%v,%v);\n", name, target, version, dep.Name, dep.Target, dep.VersionConstraint))
+		}
+	}
+	return nil
+}
+
+// insertPluginDeprecations inserts the deprecation of the plugin versions to the inventory
+func insertPluginDeprecations(db *sql.DB, pluginInventoryEntry *PluginInventoryEntry) error {
+	if len(pluginInventoryEntry.Deprecations) == 0 {
+		return nil
+	}
+	// The inventory may have been created before plugin deprecations were introduced
+	if _, err := db.Exec(CreateTablesSchema); err != nil {
+		return errors.Wrap(err, "error while creating tables to the database")
+	}
+
+	for version, deprecation := range pluginInventoryEntry.Deprecations {
+		if err := insertPluginDeprecation(db, pluginInventoryEntry.Name, string(pluginInventoryEntry.Target), version, deprecation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertPluginDeprecation(db *sql.DB, name, target, version string, deprecation PluginDeprecation) error {
+	status := deprecation.Status
+	if status == "" {
+		status = PluginDeprecationStatusDeprecated
+	}
+	_, err := db.Exec("INSERT INTO PluginDeprecations VALUES(?,?,?,?,?);", name, target, version, string(status), deprecation.Message)
+	if err != nil {
+		return errors.Wrapf(err, "unable to insert the deprecation of plugin '%s' version '%s'", name, version)
+	}
+	writeSQLStatementLogs(fmt.Sprintf("INSERT INTO PluginDeprecations VALUES(%v,%v,%v,%v,%v);\n", name, target, version, status, deprecation.Message))
 	return nil
 }
 
@@ -763,6 +988,46 @@ func (b *SQLiteInventory) updatePluginVersionActivationState(db *sql.DB, name, t
 	return nil
 }
 
+// UpdatePluginDeprecation updates the deprecation of the plugin versions found in the artifacts of the entry.
+// The versions without a deprecation in the entry are no longer deprecated.
+func (b *SQLiteInventory) UpdatePluginDeprecation(pluginInventoryEntry *PluginInventoryEntry) error {
+	db, err := sql.Open("sqlite", b.inventoryFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the DB from '%s' file", b.inventoryFile)
+	}
+	defer db.Close()
+
+	// The inventory may have been created before plugin deprecations were introduced
+	if _, err := db.Exec(CreateTablesSchema); err != nil {
+		return errors.Wrap(err, "error while creating tables to the database")
+	}
+
+	name := pluginInventoryEntry.Name
+	target := string(pluginInventoryEntry.Target)
+	for version := range pluginInventoryEntry.Artifacts {
+		var count int
+		err := db.QueryRow("SELECT COUNT(*) FROM PluginBinaries WHERE PluginName = ? AND Target = ? AND Version = ? ;", name, target, version).Scan(&count)
+		if err != nil {
+			return errors.Wrapf(err, "unable to update plugin %v_%v", name, target)
+		}
+		if count == 0 {
+			return errors.Errorf("unable to update plugin %v_%v. This might be possible because the provided plugin version '%s' doesn't exists", name, target, version)
+		}
+
+		if _, err := db.Exec("DELETE FROM PluginDeprecations WHERE PluginName = ? AND Target = ? AND Version = ? ;", name, target, version); err != nil {
+			return errors.Wrapf(err, "unable to update the deprecation of plugin '%s' version '%s'", name, version)
+		}
+		writeSQLStatementLogs(fmt.Sprintf("DELETE FROM PluginDeprecations WHERE PluginName = %v AND Target = %v AND Version = %v ;\n", name, target, version))
+
+		if deprecation, ok := pluginInventoryEntry.Deprecations[version]; ok {
+			if err := insertPluginDeprecation(db, name, target, version, deprecation); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (b *SQLiteInventory) UpdatePluginGroupActivationState(pg *PluginGroup) error {
 	db, err := sql.Open("sqlite", b.inventoryFile)
 	if err != nil {
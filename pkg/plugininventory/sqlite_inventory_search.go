@@ -0,0 +1,174 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package plugininventory
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+)
+
+const (
+	// searchTableName is the name of the full-text search table built from the
+	// PluginBinaries table to search the plugins by keywords.
+	searchTableName = "PluginSearch"
+
+	// createSearchTableSchema creates the full-text search table and fills it with one row per plugin.
+	// As the description, publisher and vendor of a plugin can change between versions, all the
+	// distinct values are indexed.
+	createSearchTableSchema = `
+DROP TABLE IF EXISTS PluginSearch;
+CREATE VIRTUAL TABLE PluginSearch USING fts5(PluginName, Target UNINDEXED, Description, Publisher, Vendor);
+INSERT INTO PluginSearch (PluginName, Target, Description, Publisher, Vendor)
+	SELECT DISTINCT PluginName, Target, Description, Publisher, Vendor FROM PluginBinaries;`
+
+	// searchSelectClause is the query returning the plugins matching the keywords.
+	// The relevance of these plugins is computed by KeywordRelevance(), like for the
+	// inventories without a search table and for the other types of discovery sources,
+	// so that the plugins of all the discovery sources can be ranked together.
+	searchSelectClause = "SELECT DISTINCT PluginName,Target FROM PluginSearch WHERE PluginSearch MATCH ?"
+)
+
+// CreateSearchIndex builds the full-text search table of the plugins of the inventory
+// DB 'inventoryFile'. The table is used to search the plugins by keywords and to rank
+// them by relevance. Without the table, keyword searches are still supported but use
+// a simpler ranking.
+func CreateSearchIndex(inventoryFile string) error {
+	db, err := sql.Open("sqlite", inventoryFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the DB at '%s'", inventoryFile)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(createSearchTableSchema); err != nil {
+		return errors.Wrapf(err, "unable to create the search index of the DB at '%s'", inventoryFile)
+	}
+	return nil
+}
+
+// KeywordRelevance returns how relevant a plugin is for the keywords, or 0 if the plugin does
+// not match all the keywords. The keywords are separated by spaces and are matched, ignoring the
+// case, against the name, description, publisher and vendor of the plugin. Like for the search
+// table, a keyword matches a field if the words of the keyword are found in the field in the same
+// order, the last word of the keyword being a prefix of a word of the field, e.g., "clus" and
+// "management-cl" match "management-cluster", but "uster" does not.
+// An exact match of the name is the most relevant, followed by a partial match of the name.
+func KeywordRelevance(keywords, name, description, publisher, vendor string) float64 {
+	nameWords := searchWords(name)
+	otherFieldsWords := [][]string{searchWords(description), searchWords(publisher), searchWords(vendor)}
+
+	relevance := 0.0
+	for _, keyword := range strings.Fields(strings.ToLower(keywords)) {
+		keywordWords := searchWords(keyword)
+		switch {
+		case len(keywordWords) == 0:
+			// A keyword without any letter or digit cannot match anything
+			return 0
+		case keyword == strings.ToLower(name):
+			relevance += 3
+		case wordsMatch(nameWords, keywordWords):
+			relevance += 2
+		case wordsMatch(otherFieldsWords[0], keywordWords) || wordsMatch(otherFieldsWords[1], keywordWords) || wordsMatch(otherFieldsWords[2], keywordWords):
+			relevance++
+		default:
+			return 0
+		}
+	}
+	return relevance
+}
+
+// searchWords splits the text into lowercase words made of letters and digits,
+// like the default tokenizer of the search table
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// wordsMatch returns true if the keyword words are found consecutively in the field
+// words, the last keyword word being a prefix of the corresponding field word
+func wordsMatch(fieldWords, keywordWords []string) bool {
+	last := len(keywordWords) - 1
+	for i := 0; i+last < len(fieldWords); i++ {
+		matches := true
+		for j := 0; j < last && matches; j++ {
+			matches = fieldWords[i+j] == keywordWords[j]
+		}
+		if matches && strings.HasPrefix(fieldWords[i+last], keywordWords[last]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ftsQuery converts the keywords into a full-text search query matching the
+// plugins containing all the keywords, each keyword being used as a prefix.
+func ftsQuery(keywords string) string {
+	var terms []string
+	for _, keyword := range strings.Fields(keywords) {
+		terms = append(terms, fmt.Sprintf(`"%s"*`, strings.ReplaceAll(keyword, `"`, `""`)))
+	}
+	return strings.Join(terms, " AND ")
+}
+
+// filterPluginsByKeywords returns the plugins matching the keywords with their relevance set
+// by KeywordRelevance(). If the DB has a search table, it is used to quickly find the plugins
+// which can match the keywords.
+func (b *SQLiteInventory) filterPluginsByKeywords(db *sql.DB, plugins []*PluginInventoryEntry, keywords string) ([]*PluginInventoryEntry, error) {
+	if strings.TrimSpace(keywords) == "" {
+		return plugins, nil
+	}
+
+	// Inventories cached before the search index was introduced don't have the table
+	exists, err := tableExists(db, searchTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var searchMatches map[string]bool
+	if exists {
+		if searchMatches, err = b.getSearchMatches(db, keywords); err != nil {
+			return nil, err
+		}
+	}
+
+	matchingPlugins := make([]*PluginInventoryEntry, 0)
+	for _, p := range plugins {
+		if searchMatches != nil && !searchMatches[catalog.PluginNameTarget(p.Name, p.Target)] {
+			continue
+		}
+		if relevance := KeywordRelevance(keywords, p.Name, p.Description, p.Publisher, p.Vendor); relevance > 0 {
+			p.Relevance = relevance
+			matchingPlugins = append(matchingPlugins, p)
+		}
+	}
+	return matchingPlugins, nil
+}
+
+// getSearchMatches returns the plugins matching the keywords in the search table,
+// keyed by plugin name and target.
+func (b *SQLiteInventory) getSearchMatches(db *sql.DB, keywords string) (map[string]bool, error) {
+	rows, err := db.Query(searchSelectClause, ftsQuery(keywords))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to search the plugins of the DB at '%s'", b.inventoryFile)
+	}
+	defer rows.Close()
+
+	matches := make(map[string]bool)
+	for rows.Next() {
+		var name, target string
+		if err := rows.Scan(&name, &target); err != nil {
+			return nil, err
+		}
+		matches[catalog.PluginNameTarget(name, configtypes.StringToTarget(strings.ToLower(target)))] = true
+	}
+	return matches, rows.Err()
+}
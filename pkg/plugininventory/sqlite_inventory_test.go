@@ -740,6 +740,108 @@ var _ = Describe("Unit tests for plugin inventory", func() {
 					}
 				})
 			})
+			Context("When searching plugins by keywords without a search index", func() {
+				It("should return the plugins containing all the keywords", func() {
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Keywords: "cluster"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(2))
+					for _, p := range plugins {
+						Expect(p.Relevance).To(BeNumerically(">", 0))
+					}
+
+					plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Keywords: "Management Operations"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+					Expect(plugins[0].Name).To(Equal("management-cluster"))
+
+					plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Keywords: "cluster missing"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(0))
+				})
+				It("should only return the hidden plugins when requested", func() {
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Keywords: "hidden"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(0))
+
+					plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Keywords: "hidden", IncludeHidden: true})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+					Expect(plugins[0].Name).To(Equal("hidden-plugin"))
+				})
+			})
+			Context("When searching plugins by keywords with a search index", func() {
+				BeforeEach(func() {
+					err = CreateSearchIndex(dbFile.Name())
+					Expect(err).ToNot(HaveOccurred())
+				})
+				It("should return the plugins containing all the keywords as prefixes", func() {
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Keywords: "manage"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+					Expect(plugins[0].Name).To(Equal("management-cluster"))
+					Expect(plugins[0].Relevance).To(BeNumerically(">", 0))
+
+					plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Keywords: "isolated cluster"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+					Expect(plugins[0].Name).To(Equal("isolated-cluster"))
+
+					plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Keywords: "othervendor"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+					Expect(plugins[0].Name).To(Equal("isolated-cluster"))
+				})
+				It("should rank the plugins matching the keywords in their name first", func() {
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Keywords: "kubernetes", IncludeHidden: true})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+					Expect(plugins[0].Name).To(Equal("management-cluster"))
+
+					plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Keywords: "plugin", IncludeHidden: true})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(2))
+					relevances := map[string]float64{}
+					for _, p := range plugins {
+						relevances[p.Name] = p.Relevance
+					}
+					Expect(relevances["hidden-plugin"]).To(BeNumerically(">", relevances["isolated-cluster"]))
+				})
+				It("should combine the keywords with the other filters", func() {
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Keywords: "cluster", Vendor: "vmware"})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(1))
+					Expect(plugins[0].Name).To(Equal("management-cluster"))
+				})
+				It("should support keywords with special characters", func() {
+					plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Keywords: `clus"ter AND -`})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(plugins)).To(Equal(0))
+				})
+				It("should match and rank the plugins like without the search index", func() {
+					searchAll := func(keywords string) map[string]float64 {
+						plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Keywords: keywords, IncludeHidden: true})
+						Expect(err).ToNot(HaveOccurred())
+						relevances := map[string]float64{}
+						for _, p := range plugins {
+							relevances[p.Name] = p.Relevance
+						}
+						return relevances
+					}
+					for _, keywords := range []string{"cluster", "uster", "manage", "management-cl", "plugin", "isolated-cluster", "othervendor vmware"} {
+						withIndex := searchAll(keywords)
+
+						db, err := sql.Open("sqlite", dbFile.Name())
+						Expect(err).ToNot(HaveOccurred())
+						_, err = db.Exec("DROP TABLE PluginSearch")
+						Expect(err).ToNot(HaveOccurred())
+						db.Close()
+						Expect(searchAll(keywords)).To(Equal(withIndex), keywords)
+						Expect(CreateSearchIndex(dbFile.Name())).To(Succeed())
+					}
+					Expect(searchAll("uster")).To(BeEmpty())
+					Expect(searchAll("isolated-cluster")["isolated-cluster"]).To(Equal(3.0))
+				})
+			})
 		})
 		Describe("With a DB table with one plugin and no recommended version", func() {
 			BeforeEach(func() {
@@ -1333,6 +1435,126 @@ var _ = Describe("Unit tests for plugin inventory", func() {
 				Expect(err.Error()).To(ContainSubstring("UNIQUE constraint failed"))
 			})
 		})
+		Context("When inserting a plugin with requirements", func() {
+			It("getplugins should return the requirements of the plugin versions", func() {
+				entry := piEntry1
+				entry.Requirements = map[string]PluginRequirements{
+					"v0.28.0": {
+						MinCLIVersion: "v1.1.0",
+						Dependencies: []PluginDependency{
+							{Name: "feature", Target: types.TargetK8s, VersionConstraint: ">=v0.28.0"},
+							{Name: "isolated-cluster", Target: types.TargetGlobal},
+						},
+					},
+				}
+				err = inventory.InsertPlugin(&entry)
+				Expect(err).To(BeNil(), "failed to insert plugin1")
+				err = inventory.InsertPlugin(&piEntry3)
+				Expect(err).To(BeNil(), "failed to insert plugin3")
+
+				plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "management-cluster", Target: types.TargetK8s})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(plugins)).To(Equal(1))
+				Expect(plugins[0].Requirements).To(Equal(entry.Requirements))
+
+				// Plugins without requirements have none
+				plugins, err = inventory.GetAllPlugins()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(plugins)).To(Equal(2))
+				for _, p := range plugins {
+					if p.Target == types.TargetK8s {
+						Expect(p.Requirements).To(Equal(entry.Requirements))
+					} else {
+						Expect(p.Requirements).To(BeNil())
+					}
+				}
+			})
+		})
+		Context("When inserting a plugin with deprecated versions", func() {
+			It("getplugins should return the deprecation of the plugin versions", func() {
+				entry := piEntry1
+				entry.Deprecations = map[string]PluginDeprecation{
+					"v0.28.0": {Status: PluginDeprecationStatusEndOfLife, Message: "This version has a security issue"},
+				}
+				err = inventory.InsertPlugin(&entry)
+				Expect(err).To(BeNil(), "failed to insert plugin1")
+				err = inventory.InsertPlugin(&piEntry3)
+				Expect(err).To(BeNil(), "failed to insert plugin3")
+
+				plugins, err := inventory.GetAllPlugins()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(plugins)).To(Equal(2))
+				for _, p := range plugins {
+					if p.Target == types.TargetK8s {
+						Expect(p.Deprecations).To(Equal(entry.Deprecations))
+					} else {
+						Expect(p.Deprecations).To(BeNil())
+					}
+				}
+			})
+		})
+		Context("When updating the deprecation of plugin versions", func() {
+			BeforeEach(func() {
+				err = inventory.InsertPlugin(&piEntry1)
+				Expect(err).To(BeNil(), "failed to insert plugin1")
+			})
+			It("should deprecate and undeprecate the plugin versions", func() {
+				entry := piEntry1
+				entry.Deprecations = map[string]PluginDeprecation{"v0.28.0": {Message: "Please upgrade"}}
+				err = inventory.UpdatePluginDeprecation(&entry)
+				Expect(err).To(BeNil())
+
+				plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "management-cluster", Target: types.TargetK8s})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(plugins)).To(Equal(1))
+				Expect(plugins[0].Deprecations).To(Equal(map[string]PluginDeprecation{
+					"v0.28.0": {Status: PluginDeprecationStatusDeprecated, Message: "Please upgrade"},
+				}))
+
+				entry.Deprecations = nil
+				err = inventory.UpdatePluginDeprecation(&entry)
+				Expect(err).To(BeNil())
+
+				plugins, err = inventory.GetPlugins(&PluginInventoryFilter{Name: "management-cluster", Target: types.TargetK8s})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(plugins)).To(Equal(1))
+				Expect(plugins[0].Deprecations).To(BeNil())
+			})
+			It("should return an error if the plugin version does not exist", func() {
+				err = inventory.UpdatePluginDeprecation(&piEntry3)
+				Expect(err).NotTo(BeNil())
+				Expect(err.Error()).To(ContainSubstring("unable to update plugin management-cluster_mission-control"))
+			})
+		})
+		Context("With an inventory created before plugin requirements were introduced", func() {
+			BeforeEach(func() {
+				db, err := sql.Open("sqlite", dbFile.Name())
+				Expect(err).To(BeNil())
+				defer db.Close()
+				_, err = db.Exec("DROP TABLE PluginRequirements; DROP TABLE PluginDependencies;")
+				Expect(err).To(BeNil())
+
+				err = inventory.InsertPlugin(&piEntry3)
+				Expect(err).To(BeNil(), "failed to insert plugin3")
+			})
+			It("getplugins should return the plugins without requirements", func() {
+				plugins, err := inventory.GetAllPlugins()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(plugins)).To(Equal(1))
+				Expect(plugins[0].Requirements).To(BeNil())
+			})
+			It("inserting a plugin with requirements should add the missing tables", func() {
+				entry := piEntry1
+				entry.Requirements = map[string]PluginRequirements{"v0.28.0": {MinCLIVersion: "v1.1.0"}}
+				err = inventory.InsertPlugin(&entry)
+				Expect(err).To(BeNil(), "failed to insert plugin1")
+
+				plugins, err := inventory.GetPlugins(&PluginInventoryFilter{Name: "management-cluster", Target: types.TargetK8s})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(plugins)).To(Equal(1))
+				Expect(plugins[0].Requirements).To(Equal(entry.Requirements))
+			})
+		})
 	})
 
 	Describe("Inserting plugin-groups to inventory and verifying it with GetPluginGroups", func() {
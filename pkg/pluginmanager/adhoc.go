@@ -0,0 +1,210 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/artifact"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/downloadcache"
+)
+
+var sha256DigestRegexp = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// InstallPluginFromImage installs the plugin binary contained in an OCI image without using a
+// discovery source. The image must be referenced by its digest, e.g., REPOSITORY@sha256:DIGEST,
+// and be hosted in a trusted registry.
+// The name, version and target of the plugin are obtained from the plugin binary itself.
+// If pluginName or target are specified, they must match the ones of the plugin binary.
+func InstallPluginFromImage(image, pluginName string, target configtypes.Target) (*cli.PluginInfo, error) {
+	binary, err := fetchPluginFromImage(image)
+	if err != nil {
+		return nil, err
+	}
+	return installAdHocPlugin(image, binary, pluginName, target)
+}
+
+// fetchPluginFromImage fetches the plugin binary contained in an OCI image referenced by its digest
+func fetchPluginFromImage(image string) ([]byte, error) {
+	_, digest, found := strings.Cut(image, "@sha256:")
+	if !found || !sha256DigestRegexp.MatchString(digest) {
+		return nil, errors.Errorf("the image %q must be referenced by its digest, e.g., REPOSITORY@sha256:DIGEST", image)
+	}
+	if err := verifyRegistry(image); err != nil {
+		return nil, err
+	}
+	if config.IsOfflineMode() {
+		return nil, discovery.NewOfflineError(fmt.Sprintf("fetching plugin from image %q", image))
+	}
+
+	log.Infof("Fetching plugin from image %q", image)
+	return artifact.NewOCIArtifact(image).Fetch()
+}
+
+// InstallPluginFromURL installs the plugin binary available at an HTTP(S) URL without using a
+// discovery source. The URL must be a trusted artifact location and the SHA256 digest of the
+// downloaded binary must match the specified digest.
+// The name, version and target of the plugin are obtained from the plugin binary itself.
+// If pluginName or target are specified, they must match the ones of the plugin binary.
+func InstallPluginFromURL(uri, digest, pluginName string, target configtypes.Target) (*cli.PluginInfo, error) {
+	binary, err := fetchPluginFromURL(uri, digest)
+	if err != nil {
+		return nil, err
+	}
+	return installAdHocPlugin(uri, binary, pluginName, target)
+}
+
+// fetchPluginFromURL fetches the plugin binary available at an HTTP(S) URL, or gets it from the
+// download cache, and verifies that its SHA256 digest matches the specified digest
+func fetchPluginFromURL(uri, digest string) ([]byte, error) {
+	digest = strings.ToLower(strings.TrimPrefix(digest, "sha256:"))
+	if !sha256DigestRegexp.MatchString(digest) {
+		return nil, errors.Errorf("invalid SHA256 digest %q", digest)
+	}
+	if u, err := url.Parse(uri); err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, errors.Errorf("the plugin URL %q must use the https or http scheme", uri)
+	}
+	if err := verifyArtifactLocation(uri); err != nil {
+		return nil, err
+	}
+
+	// The download cache verifies the content of the binary against the digest
+	if binary, found := downloadcache.Get(digest); found {
+		log.V(6).Infof("Using the binary of plugin from %q from the download cache", uri)
+		return binary, nil
+	}
+	if config.IsOfflineMode() {
+		return nil, discovery.NewOfflineError(fmt.Sprintf("fetching plugin from %q", uri))
+	}
+
+	log.Infof("Fetching plugin from %q", uri)
+	art, err := artifact.NewURIArtifact(uri)
+	if err != nil {
+		return nil, err
+	}
+	binary, err := art.Fetch()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to fetch the plugin binary")
+	}
+	if actual := fmt.Sprintf("%x", sha256.Sum256(binary)); actual != digest {
+		return nil, errors.Errorf("the plugin binary fetched from %q does not match the expected digest. expected digest: %s, actual digest: %s", uri, digest, actual)
+	}
+	return binary, nil
+}
+
+// isAdHocPlugin returns true if the plugin was installed from an image or a URL
+// without using a discovery source
+func isAdHocPlugin(p *cli.PluginInfo) bool {
+	return strings.HasPrefix(p.Discovery, common.AdHocPluginSourcePrefix)
+}
+
+// reinstallAdHocPlugin installs the binary of an ad-hoc plugin again using the source and
+// the digest of the binary recorded in the plugin catalog when the plugin was installed
+func reinstallAdHocPlugin(p *cli.PluginInfo) (*cli.PluginInfo, error) {
+	source := strings.TrimPrefix(p.Discovery, common.AdHocPluginSourcePrefix)
+	var binary []byte
+	var err error
+	if u, parseErr := url.Parse(source); parseErr == nil && (u.Scheme == "https" || u.Scheme == "http") {
+		binary, err = fetchPluginFromURL(source, p.Digest)
+	} else if binary, err = fetchPluginFromImage(source); err == nil {
+		if actual := fmt.Sprintf("%x", sha256.Sum256(binary)); actual != p.Digest {
+			err = errors.Errorf("the plugin binary fetched from %q does not match the digest recorded at installation. expected digest: %s, actual digest: %s", source, p.Digest, actual)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return installAdHocPlugin(source, binary, p.Name, p.Target)
+}
+
+// installAdHocPlugin installs a plugin binary obtained from the specified source. As no discovery
+// source describes the plugin, the plugin binary is run to learn its name, version and target.
+func installAdHocPlugin(source string, binary []byte, pluginName string, target configtypes.Target) (*cli.PluginInfo, error) {
+	info, err := describeAdHocPluginBinary(source, binary)
+	if err != nil {
+		return nil, err
+	}
+	if pluginName != "" && pluginName != info.Name {
+		return nil, errors.Errorf("the plugin from %q is named '%s' instead of '%s'", source, info.Name, pluginName)
+	}
+	pluginTarget := configtypes.StringToTarget(string(info.Target))
+	if pluginTarget == configtypes.TargetUnknown {
+		pluginTarget = configtypes.TargetGlobal
+	}
+	if target != configtypes.TargetUnknown && target != pluginTarget {
+		return nil, errors.Errorf("the plugin '%s' from %q is for target '%s' instead of '%s'", info.Name, source, pluginTarget, target)
+	}
+
+	p := &discovery.Discovered{
+		Name:               info.Name,
+		Description:        info.Description,
+		RecommendedVersion: info.Version,
+		SupportedVersions:  []string{info.Version},
+		Scope:              common.PluginScopeStandalone,
+		Source:             common.AdHocPluginSourcePrefix + source,
+		Target:             pluginTarget,
+	}
+
+	entry := &downloadcache.Entry{Digest: fmt.Sprintf("%x", sha256.Sum256(binary)), Name: p.Name, Target: p.Target, Version: p.RecommendedVersion, OS: cli.GOOS, Arch: cli.GOARCH}
+	if err := downloadcache.Put(entry, binary); err != nil {
+		// Failing to cache the binary should not prevent the installation
+		log.V(6).Infof("Unable to store plugin %q in the download cache: %v", p.Name, err)
+	}
+
+	log.Infof("Installing plugin '%s:%s' with target '%s'", p.Name, p.RecommendedVersion, p.Target)
+	plugin, err := installAndDescribePlugin(p, p.RecommendedVersion, binary)
+	if err != nil {
+		return nil, err
+	}
+	if err := updatePluginInfoAndInitializePlugin(p, plugin); err != nil {
+		return nil, err
+	}
+	return plugin, nil
+}
+
+// describeAdHocPluginBinary runs the 'info' command of a plugin binary that is not installed yet
+func describeAdHocPluginBinary(source string, binary []byte) (*cli.PluginInfo, error) {
+	tmpDir, err := os.MkdirTemp("", "tanzu-plugin-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	pluginPath := filepath.Join(tmpDir, "plugin")
+	if cli.BuildArch().IsWindows() {
+		pluginPath += exe
+	}
+	if err := os.WriteFile(pluginPath, binary, 0755); err != nil {
+		return nil, errors.Wrap(err, "could not write file")
+	}
+
+	bytesInfo, err := execCommand(pluginPath, "info").Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not describe the plugin from %q", source)
+	}
+	var info cli.PluginInfo
+	if err = json.Unmarshal(bytesInfo, &info); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal the description of the plugin from %q", source)
+	}
+	if info.Name == "" || info.Version == "" {
+		return nil, errors.Errorf("the plugin from %q does not report its name and version", source)
+	}
+	return &info, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/downloadcache"
+)
+
+func TestInstallPluginFromURL(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// The fake exec command prints the content of the binary as the plugin information
+	binary := []byte(`{"name":"adhoc","version":"v1.0.0-rc.1","target":"kubernetes","description":"Pre-release plugin"}`)
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+	uri := config.DefaultTMCPluginsArtifactRepository + "/adhoc/v1.0.0-rc.1/tanzu-adhoc"
+
+	// The binary is stored in the download cache to avoid accessing the network
+	assertions.Nil(downloadcache.Put(&downloadcache.Entry{Digest: digest, Name: "adhoc"}, binary))
+
+	_, err := InstallPluginFromURL(uri, "invalid", "", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "invalid SHA256 digest")
+
+	_, err = InstallPluginFromURL("file:///tmp/tanzu-adhoc", digest, "", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "must use the https or http scheme")
+
+	_, err = InstallPluginFromURL("https://example.com/tanzu-adhoc", digest, "", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "untrusted artifact location detected")
+
+	// The name and target must match the ones reported by the plugin
+	_, err = InstallPluginFromURL(uri, digest, "other", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "is named 'adhoc' instead of 'other'")
+
+	_, err = InstallPluginFromURL(uri, digest, "", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "is for target 'kubernetes' instead of 'global'")
+
+	plugin, err := InstallPluginFromURL(uri, "sha256:"+digest, "adhoc", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal("adhoc", plugin.Name)
+	assertions.Equal("v1.0.0-rc.1", plugin.Version)
+
+	pd, err := DescribePlugin("adhoc", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.Equal("v1.0.0-rc.1", pd.Version)
+	assertions.Equal(common.AdHocPluginSourcePrefix+uri, pd.Discovery)
+	assertions.Equal(common.PluginScopeStandalone, pd.Scope)
+	assertions.Equal(common.PluginStatusInstalled, pd.Status)
+}
+
+func TestInstallPluginFromImage(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+
+	_, err := InstallPluginFromImage("example.com/plugins/adhoc:v1.0.0", "", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "must be referenced by its digest")
+
+	_, err = InstallPluginFromImage("example.com/plugins/adhoc@sha256:1234", "", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "must be referenced by its digest")
+
+	_, err = InstallPluginFromImage("untrusted.example.org/plugins/adhoc@sha256:"+strings.Repeat("a", 64), "", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "untrusted registry detected with image")
+}
+
+func TestVerifyRepairAndUpgradeAdHocPlugin(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// An older version of a plugin also available from the discovery source
+	binary := []byte(`{"name":"login","version":"v0.1.0-dev","target":"global"}`)
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+	uri := config.DefaultTMCPluginsArtifactRepository + "/login/v0.1.0-dev/tanzu-login"
+	assertions.Nil(downloadcache.Put(&downloadcache.Entry{Digest: digest, Name: "login"}, binary))
+
+	_, err := InstallPluginFromURL(uri, digest, "", configtypes.TargetUnknown)
+	assertions.Nil(err)
+	installed, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal(digest, installed.Digest)
+
+	// Only the digest recorded at installation is checked
+	verifications, err := VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(1, len(verifications))
+	assertions.Equal(PluginVerificationOK, verifications[0].Status)
+	assertions.Empty(verifications[0].Details)
+
+	// A modified binary is installed again from the same URL
+	assertions.Nil(os.WriteFile(installed.InstallationPath, []byte(`{"name":"login","version":"v0.1.0-dev","target":"global","description":"modified"}`), 0755))
+	verifications, err = VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(PluginVerificationModified, verifications[0].Status)
+	assertions.Contains(verifications[0].Details, "the digest does not match the digest '"+digest+"' recorded at installation")
+
+	results := RepairPlugins(verifications)
+	assertions.Equal(1, len(results))
+	assertions.Nil(results[0].Err)
+	verifications, err = VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(PluginVerificationOK, verifications[0].Status)
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal(common.AdHocPluginSourcePrefix+uri, pd.Discovery)
+
+	// The plugin is neither reported as outdated nor upgraded
+	outdated, _ := GetOutdatedPlugins()
+	assertions.Empty(outdated)
+	plan, _ := GetPluginUpgradePlan()
+	assertions.Empty(plan)
+}
+
+func TestInstallAdHocPluginInOfflineMode(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	t.Setenv(constants.OfflineMode, "true")
+
+	_, err := InstallPluginFromImage("example.com/plugins/adhoc@sha256:"+strings.Repeat("a", 64), "", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "requires network")
+
+	// A binary which is not in the download cache cannot be fetched
+	_, err = InstallPluginFromURL(config.DefaultTMCPluginsArtifactRepository+"/adhoc/v1.0.0/tanzu-adhoc", strings.Repeat("b", 64), "", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "requires network")
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// pluginCatalogSnapshot records the standalone plugins installed at a point in time
+// so that the catalog can be restored if a set of installations must be undone.
+type pluginCatalogSnapshot struct {
+	plugins []cli.PluginInfo
+	// history records the installation paths of the installation history of each plugin
+	history map[string][]string
+}
+
+// takePluginCatalogSnapshot records the standalone plugins currently installed
+// along with their installation history
+func takePluginCatalogSnapshot() (*pluginCatalogSnapshot, error) {
+	c, err := catalog.NewContextCatalog("")
+	if err != nil {
+		return nil, err
+	}
+	s := &pluginCatalogSnapshot{plugins: c.List(), history: map[string][]string{}}
+	for i := range s.plugins {
+		pluginNameTarget := catalog.PluginNameTarget(s.plugins[i].Name, s.plugins[i].Target)
+		for _, pd := range c.GetHistory(pluginNameTarget) {
+			s.history[pluginNameTarget] = append(s.history[pluginNameTarget], pd.InstallationPath)
+		}
+	}
+	return s, nil
+}
+
+// find returns the recorded plugin matching the name and target, if any.
+// A plugin recorded with the "unknown" target matches the global and kubernetes targets,
+// as it is replaced by any plugin with those targets when a plugin is installed.
+func (s *pluginCatalogSnapshot) find(name string, target configtypes.Target) *cli.PluginInfo {
+	for i := range s.plugins {
+		if s.plugins[i].Name != name {
+			continue
+		}
+		if s.plugins[i].Target == target ||
+			(s.plugins[i].Target == configtypes.TargetUnknown && (target == configtypes.TargetGlobal || target == configtypes.TargetK8s)) {
+			return &s.plugins[i]
+		}
+	}
+	return nil
+}
+
+// restore reverts the successful installations of the specified results to the recorded state.
+// Plugins that were previously installed are restored to their previous installation, and plugins
+// that were not installed are removed from the catalog. The command tree cache is updated accordingly.
+// The installations being reverted are removed from the installation history of the plugins,
+// unless they were already part of it. The results that were reverted are marked as rolled back.
+func (s *pluginCatalogSnapshot) restore(results []PluginInstallResult) error {
+	c, err := catalog.NewContextCatalogUpdater("")
+	if err != nil {
+		return err
+	}
+
+	var errList []error
+	var restoredPlugins, removedPlugins []cli.PluginInfo
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		installed, isInstalled := c.Get(catalog.PluginNameTarget(results[i].Name, results[i].Target))
+		previous := s.find(results[i].Name, results[i].Target)
+		switch {
+		case previous != nil:
+			if isInstalled && installed.InstallationPath == previous.InstallationPath {
+				// The same installation was kept, there is nothing to restore
+				continue
+			}
+			// The previous installation is restored, not installed again, so it is not added to the history
+			if err := c.UpsertWithoutHistory(previous); err != nil {
+				errList = append(errList, err)
+				continue
+			}
+			restoredPlugins = append(restoredPlugins, *previous)
+		case isInstalled:
+			if err := c.Delete(catalog.PluginNameTarget(installed.Name, installed.Target)); err != nil {
+				errList = append(errList, err)
+				continue
+			}
+		default:
+			continue
+		}
+		if isInstalled {
+			pluginNameTarget := catalog.PluginNameTarget(installed.Name, installed.Target)
+			if !utils.ContainsString(s.history[pluginNameTarget], installed.InstallationPath) {
+				if err := c.DeleteFromHistory(pluginNameTarget, []string{installed.InstallationPath}); err != nil {
+					errList = append(errList, err)
+				}
+			}
+			removedPlugins = append(removedPlugins, installed)
+		}
+		results[i].RolledBack = true
+	}
+
+	// Release the lock as soon as the catalog is updated
+	c.Unlock()
+
+	for i := range removedPlugins {
+		deletePluginFromCommandTreeCache(&removedPlugins[i])
+	}
+	for i := range restoredPlugins {
+		addPluginToCommandTreeCache(&restoredPlugins[i])
+	}
+	return kerrors.NewAggregate(errList)
+}
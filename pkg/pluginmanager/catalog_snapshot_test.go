@@ -0,0 +1,137 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+func TestInstallPluginsFromGivenPluginGroupRollback(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	err := InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+
+	pg := &plugininventory.PluginGroup{
+		Vendor:             "vmware",
+		Publisher:          "test",
+		Name:               "rollback",
+		RecommendedVersion: "v1.0.0",
+		Versions: map[string][]*plugininventory.PluginGroupPluginEntry{
+			"v1.0.0": {
+				{PluginIdentifier: plugininventory.PluginIdentifier{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.20.0"}, Mandatory: true},
+				{PluginIdentifier: plugininventory.PluginIdentifier{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.6.0"}, Mandatory: true},
+				{PluginIdentifier: plugininventory.PluginIdentifier{Name: "invalid", Target: configtypes.TargetGlobal, Version: "v1.0.0"}, Mandatory: true},
+			},
+		},
+	}
+	groupID := "vmware-test/rollback:v1.0.0"
+
+	// A mandatory plugin cannot be installed so the plugins of the group are rolled back
+	_, err = InstallPluginsFromGivenPluginGroup(cli.AllPlugins, groupID, pg)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "could not install 1 plugin(s) from group '"+groupID+"'; the previously installed plugins were restored")
+
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+	assertions.False(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+
+	// Without the failing plugin, the plugins of the group are installed
+	pg.Versions["v1.0.0"] = pg.Versions["v1.0.0"][:2]
+	_, err = InstallPluginsFromGivenPluginGroup(cli.AllPlugins, groupID, pg)
+	assertions.Nil(err)
+
+	pd, err = DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.20.0", pd.Version)
+	assertions.True(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+}
+
+func TestRollbackPluginAfterPluginGroupRollback(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	err := InstallStandalonePlugin("login", "v0.2.0-beta.1", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	err = UpgradePlugin("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+
+	pg := &plugininventory.PluginGroup{
+		Vendor:             "vmware",
+		Publisher:          "test",
+		Name:               "rollback",
+		RecommendedVersion: "v1.0.0",
+		Versions: map[string][]*plugininventory.PluginGroupPluginEntry{
+			"v1.0.0": {
+				{PluginIdentifier: plugininventory.PluginIdentifier{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.20.0"}, Mandatory: true},
+				{PluginIdentifier: plugininventory.PluginIdentifier{Name: "invalid", Target: configtypes.TargetGlobal, Version: "v1.0.0"}, Mandatory: true},
+			},
+		},
+	}
+	_, err = InstallPluginsFromGivenPluginGroup(cli.AllPlugins, "vmware-test/rollback:v1.0.0", pg)
+	assertions.NotNil(err)
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+
+	// The installation reverted with the plugin group is not part of the history,
+	// so rolling back the plugin restores the version installed before the group
+	version, err := RollbackPlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0-beta.1", version)
+	pd, err = DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0-beta.1", pd.Version)
+}
+
+func TestPluginCatalogSnapshotFind(t *testing.T) {
+	snapshot := &pluginCatalogSnapshot{
+		plugins: []cli.PluginInfo{
+			{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.2.0"},
+			{Name: "legacy", Target: configtypes.TargetUnknown, Version: "v0.1.0"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		plugin   string
+		target   configtypes.Target
+		expected string
+	}{
+		{name: "same target", plugin: "login", target: configtypes.TargetGlobal, expected: "v0.2.0"},
+		{name: "different target", plugin: "login", target: configtypes.TargetK8s, expected: ""},
+		{name: "unknown target replaced by global", plugin: "legacy", target: configtypes.TargetGlobal, expected: "v0.1.0"},
+		{name: "unknown target replaced by kubernetes", plugin: "legacy", target: configtypes.TargetK8s, expected: "v0.1.0"},
+		{name: "unknown target not replaced by tmc", plugin: "legacy", target: configtypes.TargetTMC, expected: ""},
+		{name: "not installed", plugin: "cluster", target: configtypes.TargetK8s, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := snapshot.find(tt.plugin, tt.target)
+			if tt.expected == "" {
+				assert.Nil(t, p)
+				return
+			}
+			assert.NotNil(t, p)
+			assert.Equal(t, tt.expected, p.Version)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+// dataStorePluginDeprecationChecksKey is the key used to store, for each installed
+// plugin binary, the last time it was checked for deprecation upon invocation.
+const dataStorePluginDeprecationChecksKey = "pluginDeprecationChecks"
+
+// WarnIfDeprecatedPlugin prints a warning if the version of the installed plugin at the
+// specified installation path is deprecated or has reached its end of life in the plugin inventory.
+// The check is done at most once per period for the same plugin binary, whether it is deprecated
+// or not, so that invoking a plugin does not query the plugin inventory every time.
+// Only the local cache of the plugin inventory is used to keep this check fast.
+func WarnIfDeprecatedPlugin(installationPath string) {
+	if installationPath == "" {
+		return
+	}
+	delay := getDeprecationWarningDelay()
+	if delay == 0 {
+		// The user has disabled the warning
+		return
+	}
+
+	var lastChecks map[string]time.Time
+	_ = datastore.GetDataStoreValue(dataStorePluginDeprecationChecksKey, &lastChecks)
+	if lastCheck, ok := lastChecks[installationPath]; ok && time.Since(lastCheck) < delay {
+		return
+	}
+
+	// Record the check before doing it and drop the checks which expired
+	// so that the entries of uninstalled plugins do not accumulate
+	checks := map[string]time.Time{installationPath: time.Now()}
+	for path, lastCheck := range lastChecks {
+		if path != installationPath && time.Since(lastCheck) < delay {
+			checks[path] = lastCheck
+		}
+	}
+	_ = datastore.SetDataStoreValue(dataStorePluginDeprecationChecksKey, checks)
+
+	p := findInstalledPlugin(installationPath)
+	if p == nil {
+		return
+	}
+	plugin := discoverPluginFromLocalCache(p.Name, p.Target)
+	if plugin == nil {
+		return
+	}
+	if deprecation, ok := plugin.Deprecations[p.Version]; ok {
+		log.Warning(deprecationWarning(p.Name, p.Target, p.Version, deprecation, replacementVersion(plugin, p.Version)))
+	}
+}
+
+// findInstalledPlugin returns the installed plugin with the specified installation path, or nil if there is none
+func findInstalledPlugin(installationPath string) *cli.PluginInfo {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil
+	}
+	return findPluginInfoByInstallationPath(installedPlugins, installationPath)
+}
+
+// warnIfDeprecatedVersion prints a warning if the version of the plugin being installed
+// is deprecated or has reached its end of life in the plugin inventory.
+func warnIfDeprecatedVersion(p *discovery.Discovered) {
+	if p == nil {
+		return
+	}
+	deprecation, ok := p.Deprecations[p.RecommendedVersion]
+	if !ok {
+		return
+	}
+	// The recommended version of the discovered plugin is the version being installed
+	// so the recommended version of the inventory is looked up again
+	replacement := ""
+	if plugin := discoverPluginFromLocalCache(p.Name, p.Target); plugin != nil {
+		replacement = replacementVersion(plugin, p.RecommendedVersion)
+	}
+	log.Warning(deprecationWarning(p.Name, p.Target, p.RecommendedVersion, deprecation, replacement))
+}
+
+// discoverPluginFromLocalCache returns the plugin matching the name and target
+// found in the local cache of the plugin inventory, or nil if there is none
+func discoverPluginFromLocalCache(pluginName string, target configtypes.Target) *discovery.Discovered {
+	criteria := &discovery.PluginDiscoveryCriteria{
+		Name:   pluginName,
+		Target: target,
+	}
+	matchedPlugins, err := DiscoverStandalonePlugins(discovery.WithPluginDiscoveryCriteria(criteria), discovery.WithUseLocalCacheOnly())
+	if err != nil || len(matchedPlugins) != 1 {
+		return nil
+	}
+	return &matchedPlugins[0]
+}
+
+// replacementVersion returns the recommended version of the plugin if it can replace
+// the specified version, or an empty string if it cannot
+func replacementVersion(p *discovery.Discovered, version string) string {
+	if p.RecommendedVersion == "" || p.RecommendedVersion == version {
+		return ""
+	}
+	if _, deprecated := p.Deprecations[p.RecommendedVersion]; deprecated {
+		return ""
+	}
+	return p.RecommendedVersion
+}
+
+// deprecationWarning builds the warning printed for a deprecated plugin version
+func deprecationWarning(pluginName string, target configtypes.Target, version string, deprecation plugininventory.PluginDeprecation, replacement string) string {
+	var msg string
+	if deprecation.Status == plugininventory.PluginDeprecationStatusEndOfLife {
+		msg = fmt.Sprintf("Plugin '%s' version '%s' has reached its end of life and is no longer supported.", pluginName, version)
+	} else {
+		msg = fmt.Sprintf("Plugin '%s' version '%s' is deprecated.", pluginName, version)
+	}
+	if deprecation.Message != "" {
+		msg += " " + deprecation.Message
+	}
+	if replacement != "" {
+		msg += fmt.Sprintf("\nPlease install the recommended version '%s' using 'tanzu plugin install %s --target %s --version %s'",
+			replacement, pluginName, target, replacement)
+	}
+	return msg
+}
+
+// getDeprecationWarningDelay returns the minimum delay between two deprecation
+// checks done when the same plugin binary is invoked
+func getDeprecationWarningDelay() time.Duration {
+	delay := constants.DefaultDeprecatedPluginWarningDelayHours
+	if delayOverride := os.Getenv(constants.ConfigVariableDeprecatedPluginWarningDelayHours); delayOverride != "" {
+		if delayOverrideValue, err := strconv.Atoi(delayOverride); err == nil && delayOverrideValue >= 0 {
+			delay = delayOverrideValue
+		}
+	}
+	return time.Duration(delay) * time.Hour
+}
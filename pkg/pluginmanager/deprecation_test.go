@@ -0,0 +1,166 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+const createDeprecationsStmt = `
+INSERT INTO PluginDeprecations VALUES('isolated-cluster','global','v1.2.3','deprecated','Version v1.2.3 has a known vulnerability.');
+INSERT INTO PluginDeprecations VALUES('management-cluster','mission-control','v0.0.1','end-of-life','');
+INSERT INTO PluginDeprecations VALUES('management-cluster','mission-control','v0.2.0','deprecated','');
+`
+
+func setupPluginDeprecationsForTesting(t *testing.T) {
+	dbFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, config.DefaultStandaloneDiscoveryName, plugininventory.SQliteDBFileName)
+	db, err := sql.Open("sqlite", dbFile)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(createDeprecationsStmt)
+	assert.Nil(t, err)
+}
+
+func captureLogStderr(t *testing.T) *bytes.Buffer {
+	b := &bytes.Buffer{}
+	log.SetStderr(b)
+	t.Cleanup(func() { log.SetStderr(os.Stderr) })
+	return b
+}
+
+func TestInstallDeprecatedPluginVersion(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	setupPluginDeprecationsForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// A deprecated version suggests the recommended version
+	b := captureLogStderr(t)
+	err := InstallStandalonePlugin("isolated-cluster", "v1.2.3", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Contains(b.String(), "Plugin 'isolated-cluster' version 'v1.2.3' is deprecated. Version v1.2.3 has a known vulnerability.")
+	assertions.Contains(b.String(), "Please install the recommended version 'v1.3.0' using 'tanzu plugin install isolated-cluster --target global --version v1.3.0'")
+
+	// A version which is not deprecated does not print a warning
+	b.Reset()
+	err = InstallStandalonePlugin("isolated-cluster", "v1.3.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.NotContains(b.String(), "deprecated")
+
+	// A deprecated recommended version is not suggested
+	b.Reset()
+	results := InstallStandalonePlugins([]PluginInstallRequest{
+		{Name: "management-cluster", Version: "v0.0.1", Target: configtypes.TargetTMC},
+	})
+	assertions.Equal(1, len(results))
+	assertions.Nil(results[0].Err)
+	assertions.Contains(b.String(), "Plugin 'management-cluster' version 'v0.0.1' has reached its end of life and is no longer supported.")
+	assertions.NotContains(b.String(), "Please install the recommended version")
+}
+
+func TestInstallDeprecatedPluginVersionFromGroupAndLockFile(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	setupPluginDeprecationsForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// The group contains the deprecated version v1.2.3 of isolated-cluster
+	b := captureLogStderr(t)
+	_, err := InstallPluginsFromGroup("isolated-cluster", testGroupName+":"+testGroupVersion)
+	assertions.Nil(err)
+	assertions.Contains(b.String(), "Plugin 'isolated-cluster' version 'v1.2.3' is deprecated.")
+
+	b.Reset()
+	lockFilePath := filepath.Join(t.TempDir(), "plugins.lock.yaml")
+	err = os.WriteFile(lockFilePath, []byte(`version: v1
+plugins:
+- name: management-cluster
+  target: mission-control
+  version: v0.0.1
+`), 0600)
+	assertions.Nil(err)
+	err = InstallPluginsFromLockFile(lockFilePath)
+	assertions.Nil(err)
+	assertions.Contains(b.String(), "Plugin 'management-cluster' version 'v0.0.1' has reached its end of life and is no longer supported.")
+}
+
+func TestWarnIfDeprecatedPlugin(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	setupPluginDeprecationsForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	err := InstallStandalonePlugin("isolated-cluster", "v1.2.3", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	p, err := DescribePlugin("isolated-cluster", configtypes.TargetGlobal)
+	assertions.Nil(err)
+
+	// The check is disabled
+	t.Setenv(constants.ConfigVariableDeprecatedPluginWarningDelayHours, "0")
+	b := captureLogStderr(t)
+	WarnIfDeprecatedPlugin(p.InstallationPath)
+	assertions.Empty(b.String())
+
+	// The warning is printed once per period
+	t.Setenv(constants.ConfigVariableDeprecatedPluginWarningDelayHours, "")
+	WarnIfDeprecatedPlugin(p.InstallationPath)
+	assertions.Contains(b.String(), "Plugin 'isolated-cluster' version 'v1.2.3' is deprecated.")
+	b.Reset()
+	WarnIfDeprecatedPlugin(p.InstallationPath)
+	assertions.Empty(b.String())
+
+	// A version which is not deprecated does not print a warning
+	err = InstallStandalonePlugin("isolated-cluster", "v1.3.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	p, err = DescribePlugin("isolated-cluster", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	b.Reset()
+	WarnIfDeprecatedPlugin(p.InstallationPath)
+	assertions.Empty(b.String())
+
+	// A version which is not deprecated is not checked again during the period
+	dbFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, config.DefaultStandaloneDiscoveryName, plugininventory.SQliteDBFileName)
+	db, err := sql.Open("sqlite", dbFile)
+	assertions.Nil(err)
+	defer db.Close()
+	_, err = db.Exec("INSERT INTO PluginDeprecations VALUES('isolated-cluster','global','v1.3.0','deprecated','');")
+	assertions.Nil(err)
+	WarnIfDeprecatedPlugin(p.InstallationPath)
+	assertions.Empty(b.String())
+
+	// Once the period is over, the check is done again
+	t.Setenv(constants.ConfigVariableDeprecatedPluginWarningDelayHours, "1")
+	lastChecks := map[string]time.Time{p.InstallationPath: time.Now().Add(-2 * time.Hour)}
+	err = datastore.SetDataStoreValue(dataStorePluginDeprecationChecksKey, lastChecks)
+	assertions.Nil(err)
+	WarnIfDeprecatedPlugin(p.InstallationPath)
+	assertions.Contains(b.String(), "Plugin 'isolated-cluster' version 'v1.3.0' is deprecated.")
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// applyPluginConflictPolicy applies the discovery conflict policy to the plugins found
+// in more than one discovery source. The plugins must be ordered by the priority of their
+// discovery source, which is the order in which the discovery sources are searched.
+// With the "first-wins" policy, only the plugins of the first discovery source providing
+// a plugin are kept; with the "error" policy, an error is returned if a plugin is provided
+// by more than one discovery source; with the "merge" policy, the plugins are returned as is
+// to be merged by mergeDuplicatePlugins().
+func applyPluginConflictPolicy(plugins []discovery.Discovered) ([]discovery.Discovered, error) {
+	policy, err := config.GetDiscoveryConflictPolicy()
+	if err != nil || policy == config.DiscoveryConflictPolicyMerge {
+		return plugins, err
+	}
+
+	winningSources := make(map[string]string)
+	var conflicts []string
+	var result []discovery.Discovered
+	for i := range plugins {
+		target := plugins[i].Target
+		if target == configtypes.TargetUnknown {
+			// Consistent with mergeDuplicatePlugins()
+			target = configtypes.TargetK8s
+		}
+		key := fmt.Sprintf("%s_%s", plugins[i].Name, target)
+		source, exists := winningSources[key]
+		if !exists {
+			winningSources[key] = plugins[i].Source
+			result = append(result, plugins[i])
+			continue
+		}
+		if source == plugins[i].Source {
+			result = append(result, plugins[i])
+			continue
+		}
+		if policy == config.DiscoveryConflictPolicyError {
+			conflicts = append(conflicts, fmt.Sprintf("plugin '%s' with target '%s' is provided by discovery sources '%s' and '%s'", plugins[i].Name, target, source, plugins[i].Source))
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, errors.Errorf("%s. Remove the duplicate entries or change the policy set by %s", strings.Join(conflicts, ", "), constants.PluginDiscoveryConflictPolicy)
+	}
+	return result, nil
+}
+
+// sourcedPluginGroups are the plugin groups found in a discovery source
+type sourcedPluginGroups struct {
+	source string
+	groups []*plugininventory.PluginGroup
+}
+
+// applyGroupConflictPolicy applies the discovery conflict policy to the plugin groups found
+// in more than one discovery source and returns the remaining groups. The discovery sources
+// must be ordered by priority.
+func applyGroupConflictPolicy(groupsOfSources []sourcedPluginGroups) ([]*plugininventory.PluginGroup, error) {
+	policy, err := config.GetDiscoveryConflictPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	winningSources := make(map[string]string)
+	var conflicts []string
+	var result []*plugininventory.PluginGroup
+	for _, sourceGroups := range groupsOfSources {
+		for _, group := range sourceGroups.groups {
+			id := plugininventory.PluginGroupToID(group)
+			source, exists := winningSources[id]
+			if !exists {
+				winningSources[id] = sourceGroups.source
+			}
+			if !exists || source == sourceGroups.source || policy == config.DiscoveryConflictPolicyMerge {
+				result = append(result, group)
+				continue
+			}
+			if policy == config.DiscoveryConflictPolicyError {
+				conflicts = append(conflicts, fmt.Sprintf("plugin group '%s' is provided by discovery sources '%s' and '%s'", id, source, sourceGroups.source))
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, errors.Errorf("%s. Remove the duplicate entries or change the policy set by %s", strings.Join(conflicts, ", "), constants.PluginDiscoveryConflictPolicy)
+	}
+	return result, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+func TestApplyPluginConflictPolicy(t *testing.T) {
+	assertions := assert.New(t)
+
+	// The plugins are ordered by the priority of their discovery source
+	plugins := []discovery.Discovered{
+		{Name: "foo", Target: configtypes.TargetK8s, RecommendedVersion: "v1.0.1", Source: "mirror"},
+		{Name: "foo", Target: configtypes.TargetK8s, RecommendedVersion: "v1.0.0", Source: "mirror"},
+		{Name: "bar", Target: configtypes.TargetGlobal, RecommendedVersion: "v0.1.0", Source: "mirror"},
+		{Name: "foo", Target: configtypes.TargetUnknown, RecommendedVersion: "v2.0.0", Source: "default"},
+		{Name: "foo", Target: configtypes.TargetTMC, RecommendedVersion: "v2.0.0", Source: "default"},
+		{Name: "baz", Target: configtypes.TargetGlobal, RecommendedVersion: "v0.2.0", Source: "default"},
+	}
+
+	t.Setenv(constants.PluginDiscoveryConflictPolicy, "merge")
+	result, err := applyPluginConflictPolicy(plugins)
+	assertions.Nil(err)
+	assertions.Equal(plugins, result)
+
+	t.Setenv(constants.PluginDiscoveryConflictPolicy, "first-wins")
+	result, err = applyPluginConflictPolicy(plugins)
+	assertions.Nil(err)
+	assertions.Len(result, 5)
+	for i := range result {
+		if result[i].Name == "foo" && result[i].Target != configtypes.TargetTMC {
+			assertions.Equal("mirror", result[i].Source)
+		}
+	}
+
+	t.Setenv(constants.PluginDiscoveryConflictPolicy, "error")
+	_, err = applyPluginConflictPolicy(plugins)
+	assertions.ErrorContains(err, "plugin 'foo' with target 'kubernetes' is provided by discovery sources 'mirror' and 'default'")
+	assertions.ErrorContains(err, constants.PluginDiscoveryConflictPolicy)
+
+	t.Setenv(constants.PluginDiscoveryConflictPolicy, "invalid")
+	_, err = applyPluginConflictPolicy(plugins)
+	assertions.NotNil(err)
+}
+
+func TestApplyGroupConflictPolicy(t *testing.T) {
+	assertions := assert.New(t)
+
+	groupsOfSources := []sourcedPluginGroups{
+		{
+			source: "mirror",
+			groups: []*plugininventory.PluginGroup{
+				{Vendor: "vmware", Publisher: "tkg", Name: "default", RecommendedVersion: "v2.1.0"},
+			},
+		},
+		{
+			source: "default",
+			groups: []*plugininventory.PluginGroup{
+				{Vendor: "vmware", Publisher: "tkg", Name: "default", RecommendedVersion: "v2.2.0"},
+				{Vendor: "vmware", Publisher: "tmc", Name: "default", RecommendedVersion: "v1.0.0"},
+			},
+		},
+	}
+
+	t.Setenv(constants.PluginDiscoveryConflictPolicy, "")
+	result, err := applyGroupConflictPolicy(groupsOfSources)
+	assertions.Nil(err)
+	assertions.Len(result, 3)
+
+	t.Setenv(constants.PluginDiscoveryConflictPolicy, "first-wins")
+	result, err = applyGroupConflictPolicy(groupsOfSources)
+	assertions.Nil(err)
+	assertions.Len(result, 2)
+	assertions.Equal("v2.1.0", result[0].RecommendedVersion)
+	assertions.Equal("tmc", result[1].Publisher)
+
+	t.Setenv(constants.PluginDiscoveryConflictPolicy, "error")
+	_, err = applyGroupConflictPolicy(groupsOfSources)
+	assertions.ErrorContains(err, "plugin group 'vmware-tkg/default' is provided by discovery sources 'mirror' and 'default'")
+}
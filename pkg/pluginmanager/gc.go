@@ -0,0 +1,123 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+)
+
+// testPluginPrefix is the prefix of the file name of a test plugin binary
+const testPluginPrefix = "test-"
+
+// UnreferencedPluginFile is a file of the plugin root directory which is no longer
+// referenced by the plugin catalog
+type UnreferencedPluginFile struct {
+	// Name of the plugin the file was installed for
+	Name string `json:"name" yaml:"name"`
+	// Path of the file
+	Path string `json:"path" yaml:"path"`
+	// Size of the file in bytes
+	Size int64 `json:"size" yaml:"size"`
+}
+
+// GarbageCollectPlugins removes the plugin binaries and test plugin binaries of the plugin
+// root directory which are not referenced by any installed plugin or by the installation history
+// used to roll back plugins. If dryRun is true, the files are only reported and not removed.
+// The unreferenced files are returned.
+func GarbageCollectPlugins(dryRun bool) ([]UnreferencedPluginFile, error) {
+	startTime := time.Now()
+	referenced, err := catalog.GetReferencedInstallationPaths()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the plugin catalog")
+	}
+
+	files, err := findUnreferencedPluginFiles(referenced, startTime)
+	if err != nil || dryRun {
+		return files, err
+	}
+
+	var errorList []error
+	var removedPaths []string
+	removed := make([]UnreferencedPluginFile, 0, len(files))
+	for i := range files {
+		if err := os.Remove(files[i].Path); err != nil {
+			errorList = append(errorList, errors.Wrapf(err, "unable to remove '%s'", files[i].Path))
+			continue
+		}
+		removed = append(removed, files[i])
+		removedPaths = append(removedPaths, files[i].Path)
+	}
+
+	// Remove the plugin directories left empty
+	for i := range removed {
+		_ = os.Remove(filepath.Dir(removed[i].Path))
+	}
+
+	// Drop the removed installations from the catalog indexes
+	if len(removedPaths) > 0 {
+		if err := catalog.RemoveInstallationPaths(removedPaths); err != nil {
+			errorList = append(errorList, errors.Wrap(err, "unable to update the plugin catalog"))
+		}
+	}
+	return removed, kerrors.NewAggregate(errorList)
+}
+
+// findUnreferencedPluginFiles walks the plugin root directory looking for the files
+// that are not referenced. Files modified after the given time are ignored as they
+// may belong to a plugin installation which is in progress.
+func findUnreferencedPluginFiles(referenced map[string]bool, notAfter time.Time) ([]UnreferencedPluginFile, error) {
+	pluginDirs, err := os.ReadDir(common.DefaultPluginRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "unable to read the plugin root directory")
+	}
+
+	var files []UnreferencedPluginFile
+	for _, pluginDir := range pluginDirs {
+		// The 'test' directory is created by the catalog and does not hold plugin installations
+		if !pluginDir.IsDir() || pluginDir.Name() == "test" {
+			continue
+		}
+
+		dir := filepath.Join(common.DefaultPluginRoot, pluginDir.Name())
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the plugin directory '%s'", dir)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			// A test plugin binary is kept as long as its plugin binary is referenced
+			pluginPath := filepath.Join(dir, strings.TrimPrefix(entry.Name(), testPluginPrefix))
+			if referenced[path] || referenced[pluginPath] {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil || info.ModTime().After(notAfter) {
+				continue
+			}
+			files = append(files, UnreferencedPluginFile{Name: pluginDir.Name(), Path: path, Size: info.Size()})
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
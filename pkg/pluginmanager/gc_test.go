@@ -0,0 +1,82 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+func TestGarbageCollectPlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal))
+	previous, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Nil(UpgradePlugin("login", "v0.20.0", configtypes.TargetGlobal))
+	current, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+
+	// A test plugin binary is kept along with its plugin binary
+	testPluginPath := cli.TestPluginPathFromPluginPath(current.InstallationPath)
+	assertions.Nil(os.WriteFile(testPluginPath, []byte("test"), 0755))
+	// An orphaned test plugin binary is removed
+	orphanedTestPluginPath := filepath.Join(filepath.Dir(current.InstallationPath), "test-v0.0.1_orphan_global")
+	assertions.Nil(os.WriteFile(orphanedTestPluginPath, []byte("test"), 0755))
+
+	// The binaries of the test plugins which are not installed are not referenced
+	files, err := GarbageCollectPlugins(true)
+	assertions.Nil(err)
+	assertions.NotEmpty(files)
+	var size int64
+	for i := range files {
+		assertions.NotEqual(previous.InstallationPath, files[i].Path)
+		assertions.NotEqual(current.InstallationPath, files[i].Path)
+		assertions.NotEqual(testPluginPath, files[i].Path)
+		assertions.True(utils.PathExists(files[i].Path))
+		size += files[i].Size
+	}
+	assertions.Contains(files, UnreferencedPluginFile{Name: "login", Path: orphanedTestPluginPath, Size: 4})
+	assertions.Greater(size, int64(0))
+
+	removed, err := GarbageCollectPlugins(false)
+	assertions.Nil(err)
+	assertions.Equal(files, removed)
+	for i := range removed {
+		assertions.False(utils.PathExists(removed[i].Path))
+	}
+	assertions.True(utils.PathExists(previous.InstallationPath))
+	assertions.True(utils.PathExists(current.InstallationPath))
+	assertions.True(utils.PathExists(testPluginPath))
+
+	// Nothing is left to remove
+	files, err = GarbageCollectPlugins(true)
+	assertions.Nil(err)
+	assertions.Empty(files)
+
+	// The installation history is still usable
+	version, err := RollbackPlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", version)
+
+	// Once uninstalled, the binaries of a plugin are no longer referenced
+	assertions.Nil(DeletePlugin(DeletePluginOptions{PluginName: "login", Target: configtypes.TargetGlobal, ForceDelete: true}))
+	removed, err = GarbageCollectPlugins(false)
+	assertions.Nil(err)
+	assertions.Equal(3, len(removed))
+	assertions.False(utils.PathExists(filepath.Dir(current.InstallationPath)))
+}
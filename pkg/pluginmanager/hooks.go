@@ -0,0 +1,99 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// PluginHook is a lifecycle hook the CLI invokes as a command of the plugin binary
+type PluginHook string
+
+const (
+	// PreUninstallHook is invoked on the installed binary before the plugin is uninstalled
+	PreUninstallHook PluginHook = "pre-uninstall"
+	// PreUpgradeHook is invoked on the installed binary before it is replaced by another
+	// version of the plugin. The new version is provided using the --to-version flag.
+	PreUpgradeHook PluginHook = "pre-upgrade"
+	// PostUpgradeHook is invoked on the new binary after another version of the plugin
+	// was replaced. The previous version is provided using the --from-version flag.
+	PostUpgradeHook PluginHook = "post-upgrade"
+)
+
+// supportsHook returns true if the plugin declared the hook in its info descriptor.
+// Hooks are only invoked on plugins that declared them, as older plugins do not implement them.
+func supportsHook(plugin *cli.PluginInfo, hook PluginHook) bool {
+	for _, h := range plugin.SupportedHooks {
+		if h == string(hook) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPluginHookTimeout returns the maximum duration a lifecycle hook is allowed to run
+func getPluginHookTimeout() time.Duration {
+	timeout := constants.DefaultPluginHookTimeoutSeconds
+	if timeoutStr := os.Getenv(constants.PluginHookTimeout); timeoutStr != "" {
+		seconds, err := strconv.Atoi(timeoutStr)
+		if err != nil || seconds <= 0 {
+			log.Warningf("Invalid value '%s' for %s, using the default of %d seconds", timeoutStr, constants.PluginHookTimeout, timeout)
+		} else {
+			timeout = seconds
+		}
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// runPluginHook invokes the lifecycle hook on the binary of the plugin if the plugin supports it.
+// The hook is stopped if it does not complete within the hook timeout.
+func runPluginHook(plugin *cli.PluginInfo, hook PluginHook, args ...string) error {
+	if plugin == nil || !supportsHook(plugin, hook) {
+		return nil
+	}
+	if !utils.PathExists(plugin.InstallationPath) {
+		return errors.Errorf("unable to run the %s hook of plugin '%s': the plugin binary is missing", hook, plugin.Name)
+	}
+
+	log.V(6).Infof("Running the %s hook of plugin '%s:%s'", hook, plugin.Name, plugin.Version)
+	timeout := getPluginHookTimeout()
+	var output bytes.Buffer
+	cmd := execCommand(plugin.InstallationPath, append([]string{string(hook)}, args...)...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	// Don't wait for processes started by the hook that keep its output open once it is stopped
+	cmd.WaitDelay = time.Second
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "unable to run the %s hook of plugin '%s'", hook, plugin.Name)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return errors.Errorf("the %s hook of plugin '%s' failed: %v %s", hook, plugin.Name, err, strings.TrimSpace(output.String()))
+		}
+		return nil
+	case <-time.After(timeout):
+		_ = cmd.Process.Kill()
+		<-done
+		return errors.Errorf("the %s hook of plugin '%s' did not complete within %v", hook, plugin.Name, timeout)
+	}
+}
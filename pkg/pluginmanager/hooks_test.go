@@ -0,0 +1,257 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+// fakeHookExecCommand runs TestHookHelperProcess instead of the plugin binary.
+// The invocations are recorded in the file specified by HOOK_LOG and the
+// HOOK_BEHAVIOR variable makes the hooks "fail" or "hang".
+func fakeHookExecCommand(command string, args ...string) *exec.Cmd {
+	cs := []string{"-test.run=TestHookHelperProcess", "--", command}
+	cs = append(cs, args...)
+	cmd := exec.Command(os.Args[0], cs...) //nolint:gosec
+	cmd.Env = []string{
+		"GO_WANT_HOOK_HELPER_PROCESS=1",
+		"HOOK_LOG=" + os.Getenv("HOOK_LOG"),
+		"HOOK_BEHAVIOR=" + os.Getenv("HOOK_BEHAVIOR"),
+		"HOME=" + os.Getenv("HOME"),
+	}
+	return cmd
+}
+
+func TestHookHelperProcess(_ *testing.T) {
+	if os.Getenv("GO_WANT_HOOK_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for len(args) > 0 {
+		if args[0] == "--" {
+			args = args[1:]
+			break
+		}
+		args = args[1:]
+	}
+
+	f, err := os.OpenFile(os.Getenv("HOOK_LOG"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err == nil {
+		fmt.Fprintln(f, strings.Join(args, " "))
+		f.Close()
+	}
+
+	switch os.Getenv("HOOK_BEHAVIOR") {
+	case "fail":
+		fmt.Fprint(os.Stderr, "unable to clean up")
+		os.Exit(1)
+	case "hang":
+		time.Sleep(10 * time.Second)
+	}
+	os.Exit(0)
+}
+
+func setupHookTesting(t *testing.T) (hookLog string) {
+	execCommand = fakeHookExecCommand
+	t.Cleanup(func() { execCommand = exec.Command })
+
+	hookLog = filepath.Join(t.TempDir(), "hooks.log")
+	t.Setenv("HOOK_LOG", hookLog)
+	t.Setenv("HOOK_BEHAVIOR", "")
+	return hookLog
+}
+
+func readHookLog(t *testing.T, hookLog string) []string {
+	b, err := os.ReadFile(hookLog)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	assert.Nil(t, err)
+	return strings.Split(strings.TrimSpace(string(b)), "\n")
+}
+
+func newHookTestPlugin(t *testing.T, name, version string, hooks ...string) *cli.PluginInfo {
+	binary := filepath.Join(t.TempDir(), name+"_"+version)
+	assert.Nil(t, os.WriteFile(binary, []byte(name), 0755))
+	return &cli.PluginInfo{
+		Name:             name,
+		Version:          version,
+		Target:           configtypes.TargetGlobal,
+		InstallationPath: binary,
+		SupportedHooks:   hooks,
+	}
+}
+
+func TestRunPluginHook(t *testing.T) {
+	assertions := assert.New(t)
+	hookLog := setupHookTesting(t)
+
+	// A hook that is not declared by the plugin is not invoked
+	plugin := newHookTestPlugin(t, "foo", "v1.0.0", string(PreUninstallHook))
+	assertions.Nil(runPluginHook(plugin, PreUpgradeHook, "--to-version", "v2.0.0"))
+	assertions.Empty(readHookLog(t, hookLog))
+
+	assertions.Nil(runPluginHook(plugin, PreUninstallHook))
+	assertions.Equal([]string{plugin.InstallationPath + " pre-uninstall"}, readHookLog(t, hookLog))
+
+	t.Setenv("HOOK_BEHAVIOR", "fail")
+	err := runPluginHook(plugin, PreUninstallHook)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "the pre-uninstall hook of plugin 'foo' failed")
+	assertions.Contains(err.Error(), "unable to clean up")
+
+	t.Setenv("HOOK_BEHAVIOR", "hang")
+	t.Setenv(constants.PluginHookTimeout, "1")
+	start := time.Now()
+	err = runPluginHook(plugin, PreUninstallHook)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "the pre-uninstall hook of plugin 'foo' did not complete within 1s")
+	assertions.Less(time.Since(start), 5*time.Second)
+
+	assertions.Nil(os.Remove(plugin.InstallationPath))
+	err = runPluginHook(plugin, PreUninstallHook)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "the plugin binary is missing")
+}
+
+func TestGetPluginHookTimeout(t *testing.T) {
+	t.Setenv(constants.PluginHookTimeout, "")
+	assert.Equal(t, constants.DefaultPluginHookTimeoutSeconds*time.Second, getPluginHookTimeout())
+	t.Setenv(constants.PluginHookTimeout, "5")
+	assert.Equal(t, 5*time.Second, getPluginHookTimeout())
+	t.Setenv(constants.PluginHookTimeout, "invalid")
+	assert.Equal(t, constants.DefaultPluginHookTimeoutSeconds*time.Second, getPluginHookTimeout())
+}
+
+func TestRunPreUninstallHooks(t *testing.T) {
+	assertions := assert.New(t)
+	setupHookTesting(t)
+	t.Setenv("HOOK_BEHAVIOR", "fail")
+
+	plugins := []cli.PluginInfo{
+		*newHookTestPlugin(t, "foo", "v1.0.0", string(PreUninstallHook)),
+		*newHookTestPlugin(t, "bar", "v1.0.0"),
+	}
+
+	// The plugin which hook failed is not deleted
+	toDelete, errs := runPreUninstallHooks(plugins, false)
+	assertions.Len(toDelete, 1)
+	assertions.Equal("bar", toDelete[0].Name)
+	assertions.Len(errs, 1)
+	assertions.Contains(errs[0].Error(), "plugin 'foo' for target 'global' was not uninstalled. Use --force to uninstall it anyway")
+
+	// Hook failures do not prevent the deletion when they are ignored
+	toDelete, errs = runPreUninstallHooks(plugins, true)
+	assertions.Len(toDelete, 2)
+	assertions.Empty(errs)
+}
+
+func TestDeletePluginRunsPreUninstallHook(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	hookLog := setupHookTesting(t)
+
+	plugin := newHookTestPlugin(t, "foo", "v1.0.0", string(PreUninstallHook))
+	c, err := catalog.NewContextCatalogUpdater("")
+	assertions.Nil(err)
+	assertions.Nil(c.Upsert(plugin))
+	c.Unlock()
+
+	err = DeletePlugin(DeletePluginOptions{PluginName: "foo", Target: configtypes.TargetGlobal, ForceDelete: true})
+	assertions.Nil(err)
+	assertions.False(checkPluginIsInstalled("foo", configtypes.TargetGlobal))
+	assertions.Equal([]string{plugin.InstallationPath + " pre-uninstall"}, readHookLog(t, hookLog))
+}
+
+func TestDeletePluginWithFailingPreUninstallHook(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupHookTesting(t)
+	t.Setenv("HOOK_BEHAVIOR", "fail")
+
+	plugin := newHookTestPlugin(t, "foo", "v1.0.0", string(PreUninstallHook))
+	c, err := catalog.NewContextCatalogUpdater("")
+	assertions.Nil(err)
+	assertions.Nil(c.Upsert(plugin))
+	c.Unlock()
+
+	// Skipping the confirmation does not ignore the failure of the hook
+	err = DeletePlugin(DeletePluginOptions{PluginName: "foo", Target: configtypes.TargetGlobal, ForceDelete: true})
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "Use --force to uninstall it anyway")
+	assertions.True(checkPluginIsInstalled("foo", configtypes.TargetGlobal))
+
+	err = DeletePlugin(DeletePluginOptions{PluginName: "foo", Target: configtypes.TargetGlobal, ForceDelete: true, IgnoreHookFailure: true})
+	assertions.Nil(err)
+	assertions.False(checkPluginIsInstalled("foo", configtypes.TargetGlobal))
+}
+
+func TestCleanRunsPreUninstallHooks(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	hookLog := setupHookTesting(t)
+	// A failing hook does not prevent the plugins from being cleaned
+	t.Setenv("HOOK_BEHAVIOR", "fail")
+
+	plugin := newHookTestPlugin(t, "foo", "v1.0.0", string(PreUninstallHook))
+	c, err := catalog.NewContextCatalogUpdater("")
+	assertions.Nil(err)
+	assertions.Nil(c.Upsert(plugin))
+	c.Unlock()
+
+	assertions.Nil(Clean())
+	assertions.Equal([]string{plugin.InstallationPath + " pre-uninstall"}, readHookLog(t, hookLog))
+	assertions.False(checkPluginIsInstalled("foo", configtypes.TargetGlobal))
+}
+
+func TestUpgradeRunsUpgradeHooks(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	hookLog := setupHookTesting(t)
+
+	hooks := []string{string(PreUpgradeHook), string(PostUpgradeHook)}
+	p := &discovery.Discovered{Name: "foo", Target: configtypes.TargetGlobal}
+
+	oldPlugin := newHookTestPlugin(t, "foo", "v1.0.0", hooks...)
+	assertions.Nil(updatePluginInfoAndInitializePlugin(p, oldPlugin))
+	// Only the post-install command is invoked on the first installation
+	assertions.Equal([]string{oldPlugin.InstallationPath + " post-install"}, readHookLog(t, hookLog))
+	assertions.Nil(os.Remove(hookLog))
+
+	newPlugin := newHookTestPlugin(t, "foo", "v2.0.0", hooks...)
+	assertions.Nil(updatePluginInfoAndInitializePlugin(p, newPlugin))
+	assertions.Equal([]string{
+		oldPlugin.InstallationPath + " pre-upgrade --to-version v2.0.0",
+		newPlugin.InstallationPath + " post-install",
+		newPlugin.InstallationPath + " post-upgrade --from-version v1.0.0",
+	}, readHookLog(t, hookLog))
+	assertions.Nil(os.Remove(hookLog))
+
+	// A failing hook does not prevent the upgrade
+	t.Setenv("HOOK_BEHAVIOR", "fail")
+	newerPlugin := newHookTestPlugin(t, "foo", "v3.0.0", hooks...)
+	assertions.Nil(updatePluginInfoAndInitializePlugin(p, newerPlugin))
+	pd, err := DescribePlugin("foo", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v3.0.0", pd.Version)
+}
@@ -0,0 +1,343 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// isPluginArchive returns true if the local source is a plugin archive, i.e., a
+// .tar.gz, .tgz or .tar file, instead of a directory
+func isPluginArchive(localPath string) bool {
+	fi, err := os.Stat(localPath)
+	if err != nil || fi.IsDir() {
+		return false
+	}
+	name := strings.ToLower(localPath)
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, ".tar")
+}
+
+// extractPluginArchive extracts the plugins of a plugin archive that are built for the
+// current OS and architecture into a temporary directory, which is laid out like a local
+// source directory containing a plugin_manifest.yaml file. The caller must remove the directory.
+// The supported archives are:
+//   - the combined artifacts archive, e.g., plugin_bundle.tar.gz, containing the plugin_manifest.yaml
+//     file and the plugin binaries under <os>/<arch>/<target>/<plugin>/<version>/
+//   - an archive of the plugin packages generated by 'tanzu builder plugin build-package'
+//   - a single plugin package, i.e., the OCI image tarball of one plugin binary
+func extractPluginArchive(archivePath string) (string, error) {
+	dir, err := os.MkdirTemp("", "tanzu-plugin-archive-")
+	if err != nil {
+		return "", err
+	}
+
+	if isPluginPackage(archivePath) {
+		err = extractPluginPackage(archivePath, dir)
+	} else {
+		err = extractPluginArtifacts(archivePath, dir)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", errors.Wrapf(err, "unable to extract the plugins of archive %q", archivePath)
+	}
+	return dir, nil
+}
+
+// isPluginPackage returns true if the archive is the OCI image tarball of a single plugin package
+func isPluginPackage(archivePath string) bool {
+	manifest, err := tarball.LoadManifest(func() (io.ReadCloser, error) {
+		return os.Open(archivePath)
+	})
+	return err == nil && len(manifest) == 1
+}
+
+// extractPluginPackage installs the plugin binary of a plugin package in the directory along
+// with a plugin_manifest.yaml file describing it. As a plugin package does not contain a
+// manifest, the plugin binary is run to learn its name, version and target.
+func extractPluginPackage(archivePath, dir string) error {
+	binary, err := getPluginBinaryFromPackage(archivePath)
+	if err != nil {
+		return err
+	}
+	info, err := describeAdHocPluginBinary(archivePath, binary)
+	if err != nil {
+		return err
+	}
+
+	target := configtypes.StringToTarget(string(info.Target))
+	if target == configtypes.TargetUnknown {
+		target = configtypes.TargetGlobal
+	}
+	manifest := cli.Manifest{
+		Plugins: []cli.Plugin{{
+			Name:        info.Name,
+			Target:      string(target),
+			Description: info.Description,
+			Versions:    []string{info.Version},
+		}},
+	}
+	b, err := yaml.Marshal(&manifest)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, PluginManifestFileName), b, 0644); err != nil {
+		return err
+	}
+	return writePluginBinary(filepath.Join(dir, string(target), info.Name, info.Version, cli.MakeArtifactName(info.Name, cli.BuildArch())), binary)
+}
+
+// getPluginBinaryFromPackage returns the plugin binary contained in the OCI image tarball of a plugin package
+func getPluginBinaryFromPackage(packagePath string) ([]byte, error) {
+	img, err := tarball.ImageFromPath(packagePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+
+	var binary []byte
+	fileCount := 0
+	for _, layer := range layers {
+		rc, err := layer.Uncompressed()
+		if err != nil {
+			return nil, err
+		}
+		tr := tar.NewReader(rc)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				rc.Close()
+				return nil, err
+			}
+			// Skip any testing related directory paths if bundled
+			if hdr.Typeflag != tar.TypeReg || utils.ContainsString(strings.Split(hdr.Name, "/"), "test") {
+				continue
+			}
+			if binary, err = io.ReadAll(tr); err != nil {
+				rc.Close()
+				return nil, err
+			}
+			fileCount++
+		}
+		rc.Close()
+	}
+
+	if fileCount != 1 {
+		return nil, fmt.Errorf("the plugin package %q is required to have only 1 file, but found %v", filepath.Base(packagePath), fileCount)
+	}
+	return binary, nil
+}
+
+// openArchive returns a reader of the tar archive, which may be compressed with gzip.
+// The returned function must be called to close the archive.
+func openArchive(archivePath string) (*tar.Reader, func(), error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var r io.Reader = bufio.NewReader(f)
+	// Detect compressed archives using the gzip magic number
+	if magic, err := r.(*bufio.Reader).Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return tar.NewReader(gzr), func() { gzr.Close(); f.Close() }, nil
+	}
+	return tar.NewReader(r), func() { f.Close() }, nil
+}
+
+// cleanArchiveFilePath returns the path of a file of an archive relative to the root of the archive
+func cleanArchiveFilePath(name string) (string, error) {
+	cleanName := strings.TrimPrefix(path.Clean(strings.TrimPrefix(name, "./")), "/")
+	if cleanName == ".." || strings.HasPrefix(cleanName, "../") {
+		return "", errors.Errorf("invalid file path %q in archive", name)
+	}
+	return cleanName, nil
+}
+
+// getArchiveTopLevelDir returns the directory containing all the files of the archive,
+// e.g., 'artifacts' for an archive created with 'tar czf bundle.tar.gz artifacts/',
+// or an empty string if the files are not all under a single top-level directory.
+// A top-level directory named after an OS is part of the layout and is not returned.
+func getArchiveTopLevelDir(archivePath string, osNames map[string]bool) (string, error) {
+	tr, closeArchive, err := openArchive(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer closeArchive()
+
+	topLevelDir := ""
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name, err := cleanArchiveFilePath(hdr.Name)
+		if err != nil {
+			return "", err
+		}
+		dir, _, found := strings.Cut(name, "/")
+		if !found || (topLevelDir != "" && dir != topLevelDir) {
+			return "", nil
+		}
+		topLevelDir = dir
+	}
+	if osNames[topLevelDir] {
+		return "", nil
+	}
+	return topLevelDir, nil
+}
+
+// extractPluginArtifacts extracts the plugin manifests and the files of the current OS and
+// architecture found in a plugin artifacts archive into the directory. The plugin packages
+// found in the archive are replaced by the plugin binaries they contain.
+// If all the files of the archive are under a single top-level directory, that directory
+// is used as the root of the archive.
+func extractPluginArtifacts(archivePath, dir string) error {
+	osArchDir := path.Join(cli.GOOS, cli.GOARCH)
+	otherOS := map[string]bool{}
+	for _, osArch := range cli.AllOSArch {
+		otherOS[osArch.OS()] = true
+	}
+
+	topLevelDir, err := getArchiveTopLevelDir(archivePath, otherOS)
+	if err != nil {
+		return err
+	}
+
+	tr, closeArchive, err := openArchive(archivePath)
+	if err != nil {
+		return err
+	}
+	defer closeArchive()
+
+	// The manifest at the root of the archive describes the plugins of all OS and architectures
+	// and is only used if the archive does not provide one for the current OS and architecture
+	rootManifests := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name, err := cleanArchiveFilePath(hdr.Name)
+		if err != nil {
+			return err
+		}
+		if topLevelDir != "" {
+			name = strings.TrimPrefix(name, topLevelDir+"/")
+		}
+
+		var relPath string
+		switch {
+		case name == PluginManifestFileName || name == ManifestFileName:
+			if rootManifests[name], err = io.ReadAll(tr); err != nil {
+				return err
+			}
+			continue
+		case strings.HasPrefix(name, osArchDir+"/"):
+			relPath = strings.TrimPrefix(name, osArchDir+"/")
+		case otherOS[strings.Split(name, "/")[0]]:
+			// The file is for another OS
+			continue
+		default:
+			relPath = name
+		}
+
+		if err := writeFileFromReader(filepath.Join(dir, filepath.FromSlash(relPath)), tr, fs.FileMode(hdr.Mode)); err != nil {
+			return err
+		}
+	}
+
+	for name, b := range rootManifests {
+		if !utils.PathExists(filepath.Join(dir, name)) {
+			if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	if !utils.PathExists(filepath.Join(dir, PluginManifestFileName)) && !utils.PathExists(filepath.Join(dir, ManifestFileName)) {
+		return errors.Errorf("no %s file found. The archive must contain a %s file at its root, or under %s/, "+
+			"and the plugin binaries under <os>/<arch>/<target>/<plugin>/<version>/", PluginManifestFileName, PluginManifestFileName, osArchDir)
+	}
+	return replacePluginPackagesWithBinaries(dir)
+}
+
+// replacePluginPackagesWithBinaries replaces the plugin packages of the current OS and architecture,
+// e.g., <target>/<plugin>/<version>/<plugin>-<os>_<arch>.tar, by the plugin binaries they contain
+func replacePluginPackagesWithBinaries(dir string) error {
+	osArch := cli.BuildArch().String()
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), "-"+osArch+".tar") {
+			return err
+		}
+		pluginName := strings.TrimSuffix(d.Name(), "-"+osArch+".tar")
+		binary, err := getPluginBinaryFromPackage(p)
+		if err != nil {
+			return err
+		}
+		if err := writePluginBinary(filepath.Join(filepath.Dir(p), cli.MakeArtifactName(pluginName, cli.BuildArch())), binary); err != nil {
+			return err
+		}
+		return os.Remove(p)
+	})
+}
+
+func writePluginBinary(binaryPath string, binary []byte) error {
+	if err := os.MkdirAll(filepath.Dir(binaryPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(binaryPath, binary, 0755)
+}
+
+func writeFileFromReader(filePath string, r io.Reader, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r) //nolint:gosec
+	return err
+}
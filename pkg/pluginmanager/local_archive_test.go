@@ -0,0 +1,219 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+const (
+	// The fake exec command prints the content of the binary as the plugin information
+	fooPluginBinary = `{"name":"foo","description":"Foo plugin operations","version":"v0.12.0","target":"kubernetes"}`
+	barPluginBinary = `{"name":"bar","description":"Bar plugin operations","version":"v0.10.0","target":"global"}`
+
+	testPluginManifest = `plugins:
+  - name: foo
+    target: kubernetes
+    description: Foo plugin
+    versions:
+      - v0.12.0
+  - name: bar
+    target: global
+    description: Bar plugin
+    versions:
+      - v0.10.0
+`
+)
+
+// otherTestArch returns an OS/architecture different from the one of the current platform
+func otherTestArch() cli.Arch {
+	if cli.BuildArch() == cli.LinuxAMD64 {
+		return cli.DarwinARM64
+	}
+	return cli.LinuxAMD64
+}
+
+func writeTestArchive(t *testing.T, archivePath string, files map[string][]byte, compress bool) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		assert.Nil(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(content)
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, tw.Close())
+
+	b := buf.Bytes()
+	if compress {
+		var gzBuf bytes.Buffer
+		gzw := gzip.NewWriter(&gzBuf)
+		_, err := gzw.Write(b)
+		assert.Nil(t, err)
+		assert.Nil(t, gzw.Close())
+		b = gzBuf.Bytes()
+	}
+	assert.Nil(t, os.WriteFile(archivePath, b, 0644))
+}
+
+func writeTestPluginPackage(t *testing.T, packagePath, pluginName string, binary []byte) {
+	img, err := crane.Image(map[string][]byte{cli.MakeArtifactName(pluginName, cli.BuildArch()): binary})
+	assert.Nil(t, err)
+	tag, err := name.NewTag("localhost:5000/plugins/" + pluginName + ":latest")
+	assert.Nil(t, err)
+	assert.Nil(t, tarball.WriteToFile(packagePath, tag, img))
+}
+
+func installedPluginNames(t *testing.T) []string {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	assert.Nil(t, err)
+	var names []string
+	for i := range installedPlugins {
+		names = append(names, installedPlugins[i].Name+"@"+string(installedPlugins[i].Target)+":"+installedPlugins[i].Version)
+	}
+	return names
+}
+
+func TestInstallPluginsFromArtifactsArchive(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	osArchDir := path.Join(cli.GOOS, cli.GOARCH)
+	otherArch := otherTestArch()
+	archivePath := filepath.Join(t.TempDir(), "plugin_bundle.tar.gz")
+	writeTestArchive(t, archivePath, map[string][]byte{
+		"./" + PluginManifestFileName: []byte(testPluginManifest),
+		"./" + path.Join(osArchDir, "kubernetes/foo/v0.12.0", cli.MakeArtifactName("foo", cli.BuildArch())): []byte(fooPluginBinary),
+		"./" + path.Join(osArchDir, "global/bar/v0.10.0", cli.MakeArtifactName("bar", cli.BuildArch())):     []byte(barPluginBinary),
+		// Binaries for other platforms are ignored
+		"./" + path.Join(otherArch.OS(), otherArch.Arch(), "global/bar/v0.10.0", cli.MakeArtifactName("bar", otherArch)): []byte("invalid"),
+	}, true)
+
+	discovered, err := DiscoverPluginsFromLocalSource(archivePath)
+	assertions.Nil(err)
+	assertions.Len(discovered, 2)
+
+	err = InstallPluginsFromLocalSource("all", "", configtypes.TargetUnknown, archivePath, false)
+	assertions.Nil(err)
+	assertions.ElementsMatch([]string{"foo@kubernetes:v0.12.0", "bar@global:v0.10.0"}, installedPluginNames(t))
+}
+
+func TestInstallPluginsFromArtifactsArchiveWithTopLevelDir(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// An archive created with 'tar czf plugin_bundle.tar.gz artifacts/'
+	osArchDir := path.Join(cli.GOOS, cli.GOARCH)
+	archivePath := filepath.Join(t.TempDir(), "plugin_bundle.tar.gz")
+	writeTestArchive(t, archivePath, map[string][]byte{
+		"artifacts/" + PluginManifestFileName: []byte(testPluginManifest),
+		"artifacts/" + path.Join(osArchDir, "kubernetes/foo/v0.12.0", cli.MakeArtifactName("foo", cli.BuildArch())): []byte(fooPluginBinary),
+		"artifacts/" + path.Join(osArchDir, "global/bar/v0.10.0", cli.MakeArtifactName("bar", cli.BuildArch())):     []byte(barPluginBinary),
+	}, true)
+
+	err := InstallPluginsFromLocalSource("all", "", configtypes.TargetUnknown, archivePath, false)
+	assertions.Nil(err)
+	assertions.ElementsMatch([]string{"foo@kubernetes:v0.12.0", "bar@global:v0.10.0"}, installedPluginNames(t))
+}
+
+func TestInstallPluginsFromPluginPackages(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	tmpDir := t.TempDir()
+
+	// A single plugin package does not contain a manifest
+	fooPackage := filepath.Join(tmpDir, "foo-"+cli.BuildArch().String()+".tar")
+	writeTestPluginPackage(t, fooPackage, "foo", []byte(fooPluginBinary))
+
+	discovered, err := DiscoverPluginsFromLocalSource(fooPackage)
+	assertions.Nil(err)
+	assertions.Len(discovered, 1)
+	assertions.Equal("foo", discovered[0].Name)
+	assertions.Equal(configtypes.TargetK8s, discovered[0].Target)
+	assertions.Equal("v0.12.0", discovered[0].RecommendedVersion)
+
+	err = InstallPluginsFromLocalSource("foo", "", configtypes.TargetUnknown, fooPackage, false)
+	assertions.Nil(err)
+	assertions.ElementsMatch([]string{"foo@kubernetes:v0.12.0"}, installedPluginNames(t))
+
+	// An archive of the plugin packages generated by the builder plugin
+	barPackage := filepath.Join(tmpDir, "bar.tar")
+	writeTestPluginPackage(t, barPackage, "bar", []byte(barPluginBinary))
+	barPackageContent, err := os.ReadFile(barPackage)
+	assertions.Nil(err)
+
+	archivePath := filepath.Join(tmpDir, "plugin_packages.tar.gz")
+	barManifest := "plugins:\n  - name: bar\n    target: global\n    description: Bar plugin\n    versions:\n      - v0.10.0\n"
+	writeTestArchive(t, archivePath, map[string][]byte{
+		PluginManifestFileName: []byte(barManifest),
+		path.Join(cli.GOOS, cli.GOARCH, "global/bar/v0.10.0", "bar-"+cli.BuildArch().String()+".tar"): barPackageContent,
+	}, true)
+
+	err = InstallPluginsFromLocalSource("bar", "", configtypes.TargetGlobal, archivePath, false)
+	assertions.Nil(err)
+	assertions.ElementsMatch([]string{"foo@kubernetes:v0.12.0", "bar@global:v0.10.0"}, installedPluginNames(t))
+}
+
+func TestExtractPluginArchiveErrors(t *testing.T) {
+	assertions := assert.New(t)
+	tmpDir := t.TempDir()
+
+	archivePath := filepath.Join(tmpDir, "invalid.tar")
+	writeTestArchive(t, archivePath, map[string][]byte{"../outside": []byte("content")}, false)
+	_, err := extractPluginArchive(archivePath)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), `invalid file path "../outside" in archive`)
+
+	// The error names the expected layout when the archive does not contain a manifest
+	archivePath = filepath.Join(tmpDir, "nomanifest.tar.gz")
+	writeTestArchive(t, archivePath, map[string][]byte{
+		"artifacts/foo/" + cli.MakeArtifactName("foo", cli.BuildArch()): []byte(fooPluginBinary),
+		"other/bar/" + cli.MakeArtifactName("bar", cli.BuildArch()):     []byte(barPluginBinary),
+	}, true)
+	_, err = extractPluginArchive(archivePath)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "no plugin_manifest.yaml file found. The archive must contain a plugin_manifest.yaml file at its root")
+	assertions.Contains(err.Error(), "<os>/<arch>/<target>/<plugin>/<version>/")
+
+	// A package must contain a single plugin binary
+	img, err := crane.Image(map[string][]byte{"tanzu-foo": []byte("foo"), "tanzu-bar": []byte("bar")})
+	assertions.Nil(err)
+	tag, err := name.NewTag("localhost:5000/plugins/foo:latest")
+	assertions.Nil(err)
+	packagePath := filepath.Join(tmpDir, "foo.tar")
+	assertions.Nil(tarball.WriteToFile(packagePath, tag, img))
+	_, err = extractPluginArchive(packagePath)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "is required to have only 1 file, but found 2")
+
+	assertions.False(isPluginArchive(tmpDir))
+	assertions.False(isPluginArchive(filepath.Join(tmpDir, "missing.tar.gz")))
+	assertions.True(isPluginArchive(packagePath))
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+)
+
+// PluginLockFileVersion is the version of the plugin lockfile format
+const PluginLockFileVersion = "v1"
+
+// PluginLockFile records the exact set of installed plugins so that
+// the same set can be reproduced on a different machine.
+type PluginLockFile struct {
+	// Version is the version of the lockfile format
+	Version string `json:"version" yaml:"version"`
+	// Plugins is the list of locked plugins
+	Plugins []PluginLockEntry `json:"plugins" yaml:"plugins"`
+}
+
+// PluginLockEntry records a single installed plugin
+type PluginLockEntry struct {
+	// Name of the plugin
+	Name string `json:"name" yaml:"name"`
+	// Target of the plugin
+	Target configtypes.Target `json:"target" yaml:"target"`
+	// Version of the plugin
+	Version string `json:"version" yaml:"version"`
+	// Discovery is the name of the discovery source the plugin was installed from
+	Discovery string `json:"discovery,omitempty" yaml:"discovery,omitempty"`
+	// Digests are the SHA256 digests of the plugin binaries keyed by
+	// the os_arch they were published for (e.g. linux_amd64)
+	Digests map[string]string `json:"digests,omitempty" yaml:"digests,omitempty"`
+}
+
+// GeneratePluginLockFile builds a lockfile from the installed plugins
+func GeneratePluginLockFile() (*PluginLockFile, error) {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(cli.PluginInfoSorter(installedPlugins))
+
+	lockFile := &PluginLockFile{
+		Version: PluginLockFileVersion,
+		Plugins: []PluginLockEntry{},
+	}
+	for i := range installedPlugins {
+		lockFile.Plugins = append(lockFile.Plugins, PluginLockEntry{
+			Name:      installedPlugins[i].Name,
+			Target:    installedPlugins[i].Target,
+			Version:   installedPlugins[i].Version,
+			Discovery: installedPlugins[i].Discovery,
+			Digests:   getPublishedDigests(&installedPlugins[i]),
+		})
+	}
+	return lockFile, nil
+}
+
+// getPublishedDigests returns the digests of the artifacts published for the
+// installed plugin keyed by os_arch. If the plugin cannot be found in its discovery
+// source, only the digest of the installed binary is recorded for the current os_arch.
+func getPublishedDigests(installed *cli.PluginInfo) map[string]string {
+	digests := map[string]string{}
+	if installed.Digest != "" {
+		digests[cli.BuildArch().String()] = installed.Digest
+	}
+
+	discoveries, err := getLockedDiscoveries(installed.Name, installed.Version, installed.Discovery)
+	if err != nil {
+		return digests
+	}
+	criteria := &discovery.PluginDiscoveryCriteria{
+		Name:    installed.Name,
+		Target:  installed.Target,
+		Version: installed.Version,
+	}
+	plugins, err := discoverSpecificPlugins(discoveries, discovery.WithPluginDiscoveryCriteria(criteria))
+	if err != nil || len(plugins) == 0 {
+		return digests
+	}
+	artifacts, ok := plugins[0].Distribution.(distribution.Artifacts)
+	if !ok {
+		return digests
+	}
+	published := map[string]string{}
+	for _, a := range artifacts[installed.Version] {
+		if a.Digest != "" {
+			published[a.OS+"_"+a.Arch] = a.Digest
+		}
+	}
+	if len(published) == 0 {
+		return digests
+	}
+	return published
+}
+
+// ExportPluginLockFile writes the lockfile of the installed plugins to the given writer
+func ExportPluginLockFile(w io.Writer) error {
+	lockFile, err := GeneratePluginLockFile()
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(lockFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the plugin lockfile")
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// ReadPluginLockFile reads and validates the lockfile at the given path
+func ReadPluginLockFile(lockFilePath string) (*PluginLockFile, error) {
+	b, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read the plugin lockfile %q", lockFilePath)
+	}
+
+	var lockFile PluginLockFile
+	if err := yaml.Unmarshal(b, &lockFile); err != nil {
+		return nil, errors.Wrapf(err, "could not decode the plugin lockfile %q", lockFilePath)
+	}
+
+	if lockFile.Version != PluginLockFileVersion {
+		return nil, errors.Errorf("unsupported plugin lockfile version %q. Supported version is %q", lockFile.Version, PluginLockFileVersion)
+	}
+	for i := range lockFile.Plugins {
+		entry := &lockFile.Plugins[i]
+		if entry.Name == "" {
+			return nil, errors.Errorf("entry %d of the plugin lockfile is missing the plugin name", i+1)
+		}
+		if entry.Version == "" || entry.Version == cli.VersionLatest {
+			return nil, errors.Errorf("plugin %q in the plugin lockfile must specify an exact version", entry.Name)
+		}
+	}
+	return &lockFile, nil
+}
+
+// InstallPluginsFromLockFile installs the exact plugin versions recorded in the lockfile
+// from the discovery sources recorded in the lockfile. A plugin is not installed if the digest
+// of its binary does not match the recorded one.
+func InstallPluginsFromLockFile(lockFilePath string) error {
+	lockFile, err := ReadPluginLockFile(lockFilePath)
+	if err != nil {
+		return err
+	}
+
+	errList := make([]error, 0)
+	for i := range lockFile.Plugins {
+		entry := &lockFile.Plugins[i]
+		osArch, err := installLockedPlugin(entry)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		if err := verifyLockedPlugin(entry, osArch); err != nil {
+			errList = append(errList, err)
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// getLockedDiscoveries returns the configured discovery sources a locked plugin
+// can be installed from. If no discovery source is recorded, all the configured
+// discovery sources are returned.
+func getLockedDiscoveries(pluginName, version, discoveryName string) ([]configtypes.PluginDiscovery, error) {
+	discoveries, err := getPluginDiscoveries()
+	if err != nil {
+		return nil, err
+	}
+	if discoveryName == "" {
+		return discoveries, nil
+	}
+	var lockedDiscoveries []configtypes.PluginDiscovery
+	for i := range discoveries {
+		if discovery.CheckDiscoveryName(discoveries[i], discoveryName) {
+			lockedDiscoveries = append(lockedDiscoveries, discoveries[i])
+		}
+	}
+	if len(lockedDiscoveries) == 0 {
+		return nil, errors.Errorf("plugin '%s:%s' must be installed from discovery source '%s' which is not configured", pluginName, version, discoveryName)
+	}
+	return lockedDiscoveries, nil
+}
+
+// installLockedPlugin installs the plugin of the lockfile entry from the discovery
+// source recorded in the entry, after making sure the digest of the artifact to
+// install matches the digest recorded in the entry for the os_arch being installed.
+// It returns the os_arch of the installed artifact.
+func installLockedPlugin(entry *PluginLockEntry) (string, error) {
+	discoveries, err := getLockedDiscoveries(entry.Name, entry.Version, entry.Discovery)
+	if err != nil {
+		return "", err
+	}
+
+	p, restoreArch, err := findPluginToInstallFromDiscoveries(discoveries, entry.Name, entry.Version, entry.Target, "")
+	if restoreArch != nil {
+		defer restoreArch()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// cli.GOOS and cli.GOARCH reflect the architecture of the artifact to install,
+	// which may differ from the CLI's own architecture on ARM64 machines
+	osArch := cli.GOOS + "_" + cli.GOARCH
+	if lockedDigest, ok := entry.Digests[osArch]; ok {
+		digest, err := p.Distribution.GetDigest(p.RecommendedVersion, cli.GOOS, cli.GOARCH)
+		if err != nil {
+			return "", err
+		}
+		if digest != lockedDigest {
+			return "", errors.Errorf("plugin '%s:%s' with target '%s' has digest '%s' for '%s' but the lockfile requires digest '%s'", entry.Name, entry.Version, entry.Target, digest, osArch, lockedDigest)
+		}
+	} else if len(entry.Digests) > 0 {
+		log.Warningf("The plugin lockfile does not record a digest of plugin '%s:%s' for '%s'; its digest will not be verified", entry.Name, entry.Version, osArch)
+	}
+	if err := resolvePluginRequirements(p, nil); err != nil {
+		return "", err
+	}
+	warnIfDeprecatedVersion(p)
+	return osArch, installOrUpgradePlugin(p, p.RecommendedVersion, false)
+}
+
+// verifyLockedPlugin verifies that the installed plugin matches the lockfile entry
+// for the given os_arch
+func verifyLockedPlugin(entry *PluginLockEntry, osArch string) error {
+	c, err := catalog.NewContextCatalog("")
+	if err != nil {
+		return err
+	}
+	installed, exists := c.Get(catalog.PluginNameTarget(entry.Name, entry.Target))
+	if !exists {
+		return errors.Errorf("plugin '%s' with target '%s' is not installed", entry.Name, entry.Target)
+	}
+	if installed.Version != entry.Version {
+		return errors.Errorf("plugin '%s' with target '%s' was installed with version '%s' but the lockfile requires version '%s'", entry.Name, entry.Target, installed.Version, entry.Version)
+	}
+	if lockedDigest, ok := entry.Digests[osArch]; ok && installed.Digest != lockedDigest {
+		return errors.Errorf("plugin '%s:%s' with target '%s' has digest '%s' for '%s' but the lockfile requires digest '%s'", entry.Name, entry.Version, entry.Target, installed.Digest, osArch, lockedDigest)
+	}
+	if entry.Discovery != "" && installed.Discovery != entry.Discovery {
+		return errors.Errorf("plugin '%s:%s' was installed from discovery source '%s' but the lockfile requires discovery source '%s'", entry.Name, entry.Version, installed.Discovery, entry.Discovery)
+	}
+	return nil
+}
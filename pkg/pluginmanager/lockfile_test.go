@@ -0,0 +1,189 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+func TestExportAndReadPluginLockFile(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	err := InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	err = InstallStandalonePlugin("cluster", "v1.6.0", configtypes.TargetK8s)
+	assertions.Nil(err)
+
+	var b bytes.Buffer
+	err = ExportPluginLockFile(&b)
+	assertions.Nil(err)
+
+	lockFilePath := filepath.Join(t.TempDir(), "plugins.lock.yaml")
+	err = os.WriteFile(lockFilePath, b.Bytes(), 0600)
+	assertions.Nil(err)
+
+	// The digests of the artifacts published for every os_arch are recorded
+	publishedDigests := map[string]string{}
+	for _, osArch := range cli.AllOSArch {
+		digest := digestForAMD64
+		if osArch.Arch() == cli.DarwinARM64.Arch() {
+			digest = digestForARM64
+		}
+		publishedDigests[osArch.String()] = digest
+	}
+
+	lockFile, err := ReadPluginLockFile(lockFilePath)
+	assertions.Nil(err)
+	assertions.Equal(PluginLockFileVersion, lockFile.Version)
+	assertions.Equal([]PluginLockEntry{
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.6.0", Discovery: "default", Digests: publishedDigests},
+		{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.2.0", Discovery: "default", Digests: publishedDigests},
+	}, lockFile.Plugins)
+}
+
+func TestReadPluginLockFileErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name:        "unsupported version",
+			content:     "version: v2\nplugins: []\n",
+			expectedErr: `unsupported plugin lockfile version "v2"`,
+		},
+		{
+			name:        "missing plugin name",
+			content:     "version: v1\nplugins:\n- version: v1.0.0\n",
+			expectedErr: "entry 1 of the plugin lockfile is missing the plugin name",
+		},
+		{
+			name:        "latest version",
+			content:     "version: v1\nplugins:\n- name: cluster\n  version: latest\n",
+			expectedErr: `plugin "cluster" in the plugin lockfile must specify an exact version`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockFilePath := filepath.Join(t.TempDir(), "plugins.lock.yaml")
+			err := os.WriteFile(lockFilePath, []byte(tt.content), 0600)
+			assert.Nil(t, err)
+
+			_, err = ReadPluginLockFile(lockFilePath)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestInstallPluginsFromLockFile(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	lockFilePath := filepath.Join(t.TempDir(), "plugins.lock.yaml")
+
+	currentArch := cli.BuildArch()
+	currentDigest := digestForAMD64
+	otherArch := cli.DarwinARM64
+	if currentArch.Arch() == cli.DarwinARM64.Arch() {
+		currentDigest = digestForARM64
+		otherArch = cli.LinuxAMD64
+	}
+
+	// The digest of the installed binary is verified so it must match the published digest
+	loginDigest := setupPublishedPluginForTesting(t, "login", "v0.2.0", configtypes.TargetGlobal)
+
+	// Matching digest for the current os_arch and mismatched digest for another os_arch
+	err := os.WriteFile(lockFilePath, []byte(`version: v1
+plugins:
+- name: login
+  target: global
+  version: v0.2.0
+  discovery: default
+  digests:
+    `+currentArch.String()+`: "`+loginDigest+`"
+    `+otherArch.String()+`: "abcdef"
+`), 0600)
+	assertions.Nil(err)
+	err = InstallPluginsFromLockFile(lockFilePath)
+	assertions.Nil(err)
+	assertions.True(checkPluginIsInstalled("login", configtypes.TargetGlobal))
+
+	// Mismatched digest for the current os_arch
+	err = os.WriteFile(lockFilePath, []byte(`version: v1
+plugins:
+- name: cluster
+  target: kubernetes
+  version: v1.6.0
+  digests:
+    `+currentArch.String()+`: "abcdef"
+    `+otherArch.String()+`: "`+currentDigest+`"
+`), 0600)
+	assertions.Nil(err)
+	err = InstallPluginsFromLockFile(lockFilePath)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "plugin 'cluster:v1.6.0' with target 'kubernetes' has digest '"+currentDigest+"' for '"+currentArch.String()+"' but the lockfile requires digest 'abcdef'")
+	assertions.False(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+
+	// Lockfile generated on another os_arch, which does not record a digest for the current os_arch
+	err = os.WriteFile(lockFilePath, []byte(`version: v1
+plugins:
+- name: cluster
+  target: kubernetes
+  version: v1.6.0
+  digests:
+    `+otherArch.String()+`: "abcdef"
+`), 0600)
+	assertions.Nil(err)
+	err = InstallPluginsFromLockFile(lockFilePath)
+	assertions.Nil(err)
+	assertions.True(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+
+	err = DeletePlugin(DeletePluginOptions{PluginName: "cluster", Target: configtypes.TargetK8s, ForceDelete: true})
+	assertions.Nil(err)
+
+	// Discovery source which is not configured
+	err = os.WriteFile(lockFilePath, []byte(`version: v1
+plugins:
+- name: cluster
+  target: kubernetes
+  version: v1.6.0
+  discovery: other
+`), 0600)
+	assertions.Nil(err)
+	err = InstallPluginsFromLockFile(lockFilePath)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "plugin 'cluster:v1.6.0' must be installed from discovery source 'other' which is not configured")
+	assertions.False(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+
+	// Version not found
+	err = os.WriteFile(lockFilePath, []byte(`version: v1
+plugins:
+- name: login
+  target: global
+  version: v9.9.9
+`), 0600)
+	assertions.Nil(err)
+	err = InstallPluginsFromLockFile(lockFilePath)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to find plugin 'login' matching version 'v9.9.9'")
+}
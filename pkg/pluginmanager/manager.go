@@ -31,6 +31,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/downloadcache"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugincmdtree"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
@@ -62,6 +63,8 @@ type DeletePluginOptions struct {
 	Target      configtypes.Target
 	PluginName  string
 	ForceDelete bool
+	// IgnoreHookFailure uninstalls the plugins even if their pre-uninstall hook fails
+	IgnoreHookFailure bool
 }
 
 // discoverSpecificPlugins returns all plugins that match the specified criteria from all PluginDiscovery sources,
@@ -89,7 +92,7 @@ func discoverSpecificPlugins(pd []configtypes.PluginDiscovery, options ...discov
 
 // discoverSpecificPluginGroups returns all the plugin groups found in the discoveries
 func discoverSpecificPluginGroups(pd []configtypes.PluginDiscovery, options ...discovery.DiscoveryOptions) ([]*plugininventory.PluginGroup, error) {
-	var allGroups []*plugininventory.PluginGroup
+	var groupsOfSources []sourcedPluginGroups
 	for _, d := range pd {
 		groupDisc, err := discovery.CreateGroupDiscovery(d, options...)
 		if err != nil {
@@ -103,9 +106,13 @@ func discoverSpecificPluginGroups(pd []configtypes.PluginDiscovery, options ...d
 		}
 
 		if len(groups) > 0 {
-			allGroups = append(allGroups, groups...)
+			groupsOfSources = append(groupsOfSources, sourcedPluginGroups{source: groupDisc.Name(), groups: groups})
 		}
 	}
+	allGroups, err := applyGroupConflictPolicy(groupsOfSources)
+	if err != nil {
+		return nil, err
+	}
 	return mergeDuplicateGroups(allGroups), nil
 }
 
@@ -123,6 +130,10 @@ func DiscoverStandalonePlugins(options ...discovery.DiscoveryOptions) ([]discove
 		plugins[i].Scope = common.PluginScopeStandalone
 		plugins[i].Status = common.PluginStatusNotInstalled
 	}
+	plugins, policyErr := applyPluginConflictPolicy(plugins)
+	if policyErr != nil {
+		return nil, policyErr
+	}
 	return mergeDuplicatePlugins(plugins), err
 }
 
@@ -176,8 +187,9 @@ func DiscoverServerPlugins() ([]discovery.Discovered, error) {
 	return discoverServerPluginsForGivenContexts(contexts)
 }
 
-// discoverServerPluginsForGivenContexts returns the available discovered plugins associated with specific contexts
-func discoverServerPluginsForGivenContexts(contexts []*configtypes.Context) ([]discovery.Discovered, error) {
+// discoverServerPluginsForGivenContexts returns the available discovered plugins associated with specific contexts.
+// The kubernetes discoveries of each context are restricted to the discovery scope of the context, if any.
+func discoverServerPluginsForGivenContexts(contexts []*configtypes.Context, options ...discovery.DiscoveryOptions) ([]discovery.Discovered, error) {
 	var plugins []discovery.Discovered
 	var errList []error
 	if len(contexts) == 0 {
@@ -187,7 +199,8 @@ func discoverServerPluginsForGivenContexts(contexts []*configtypes.Context) ([]d
 		var discoverySources []configtypes.PluginDiscovery
 		discoverySources = append(discoverySources, context.DiscoverySources...)
 		discoverySources = append(discoverySources, defaultDiscoverySourceBasedOnContext(context)...)
-		discoveredPlugins, err := discoverSpecificPlugins(discoverySources)
+		contextOptions := append([]discovery.DiscoveryOptions{discovery.WithKubernetesDiscoveryScope(config.GetKubernetesDiscoveryScope(context.Name))}, options...)
+		discoveredPlugins, err := discoverSpecificPlugins(discoverySources, contextOptions...)
 
 		// If there is an error while discovering plugins from all of the given plugin sources,
 		// append the error to the error list and continue processing the discoveredPlugins,
@@ -270,6 +283,33 @@ func mergePluginEntries(plugin1, plugin2 *discovery.Discovered) *discovery.Disco
 		plugin1.DiscoveryType = ""
 	}
 
+	// Keep the best relevance found for the search keywords
+	if plugin2.Relevance > plugin1.Relevance {
+		plugin1.Relevance = plugin2.Relevance
+	}
+
+	// The plugin is deprecated if any source deprecates it; the details
+	// of the deprecation are taken from the first source providing them
+	if plugin2.Deprecated {
+		plugin1.Deprecated = true
+		if plugin1.DeprecationMessage == "" {
+			plugin1.DeprecationMessage = plugin2.DeprecationMessage
+		}
+		if plugin1.ReplacedBy == "" {
+			plugin1.ReplacedBy = plugin2.ReplacedBy
+		}
+	}
+
+	// Add the release notes of the versions that the first plugin has no release notes for
+	for version, notes := range plugin2.ReleaseNotes {
+		if _, exists := plugin1.ReleaseNotes[version]; !exists {
+			if plugin1.ReleaseNotes == nil {
+				plugin1.ReleaseNotes = make(map[string]string)
+			}
+			plugin1.ReleaseNotes[version] = notes
+		}
+	}
+
 	artifacts1, ok := plugin1.Distribution.(distribution.Artifacts)
 	if !ok {
 		// This should not happened
@@ -292,6 +332,18 @@ func mergePluginEntries(plugin1, plugin2 *discovery.Discovered) *discovery.Disco
 		if !exists {
 			artifacts1[version] = artifacts2[version]
 			plugin1.SupportedVersions = append(plugin1.SupportedVersions, version)
+			if requirements, ok := plugin2.Requirements[version]; ok {
+				if plugin1.Requirements == nil {
+					plugin1.Requirements = make(map[string]plugininventory.PluginRequirements)
+				}
+				plugin1.Requirements[version] = requirements
+			}
+			if deprecation, ok := plugin2.Deprecations[version]; ok {
+				if plugin1.Deprecations == nil {
+					plugin1.Deprecations = make(map[string]plugininventory.PluginDeprecation)
+				}
+				plugin1.Deprecations[version] = deprecation
+			}
 		}
 	}
 	plugin1.Distribution = artifacts1
@@ -472,21 +524,60 @@ func InitializePlugin(plugin *cli.PluginInfo) error {
 
 // InstallStandalonePlugin installs a plugin by name, version and target as a standalone plugin.
 func InstallStandalonePlugin(pluginName, version string, target configtypes.Target) error {
+	// A pinned plugin can only be installed again at the version it is pinned to
+	if pin := getInstalledPluginPin(pluginName, target); pin != nil && pin.Version != version {
+		return fmt.Errorf("plugin '%s' with target '%s' is pinned to version '%s'. Run 'tanzu plugin unpin %s@%s' to allow another version to be installed",
+			pin.Name, pin.Target, pin.Version, pin.Name, pin.Target)
+	}
 	return installPlugin(pluginName, version, target, "")
 }
 
 // installs a plugin by name, version and target.
 // If the contextName is not empty, it implies the plugin is a context-scope plugin, otherwise
 // we are installing a standalone plugin.
-//
-//nolint:gocyclo
 func installPlugin(pluginName, version string, target configtypes.Target, contextName string) error {
-	discoveries, err := getPluginDiscoveries()
+	return installPluginWithVersionConstraint(pluginName, version, target, contextName, "")
+}
+
+// installPluginWithVersionConstraint is like installPlugin but records the specified
+// version constraint for the installed plugin, if not empty.
+func installPluginWithVersionConstraint(pluginName, version string, target configtypes.Target, contextName, versionConstraint string) error {
+	p, restoreArch, err := findPluginToInstall(pluginName, version, target, contextName)
+	if restoreArch != nil {
+		defer restoreArch() // Go back to ARM64 once the plugin is installed
+	}
 	if err != nil {
 		return err
 	}
+	if versionConstraint != "" {
+		p.VersionConstraint = versionConstraint
+	}
+	if err := resolvePluginRequirements(p, nil); err != nil {
+		return err
+	}
+	warnIfDeprecatedVersion(p)
+	return installOrUpgradePlugin(p, p.RecommendedVersion, false)
+}
+
+// findPluginToInstall discovers the plugin matching the specified name, version and target.
+// If the plugin is only available for AMD64 on an ARM64 Darwin or Windows machine, the CLI
+// architecture is switched to AMD64 and the returned function must be called to switch it
+// back once the plugin is installed; otherwise the returned function is nil.
+func findPluginToInstall(pluginName, version string, target configtypes.Target, contextName string) (*discovery.Discovered, func(), error) {
+	discoveries, err := getPluginDiscoveries()
+	if err != nil {
+		return nil, nil, err
+	}
+	return findPluginToInstallFromDiscoveries(discoveries, pluginName, version, target, contextName)
+}
+
+// findPluginToInstallFromDiscoveries is like findPluginToInstall but only
+// looks for the plugin in the specified discovery sources.
+//
+//nolint:gocyclo
+func findPluginToInstallFromDiscoveries(discoveries []configtypes.PluginDiscovery, pluginName, version string, target configtypes.Target, contextName string) (*discovery.Discovered, func(), error) {
 	if len(discoveries) == 0 {
-		return errors.New(errorNoDiscoverySourcesFound)
+		return nil, nil, errors.New(errorNoDiscoverySourcesFound)
 	}
 	criteria := &discovery.PluginDiscoveryCriteria{
 		Name:    pluginName,
@@ -495,6 +586,12 @@ func installPlugin(pluginName, version string, target configtypes.Target, contex
 		OS:      cli.GOOS,
 		Arch:    cli.GOARCH,
 	}
+	versionConstraint := ""
+	if utils.IsVersionConstraint(version) {
+		// Discover all versions so that the version constraint can be resolved against them
+		versionConstraint = version
+		criteria.Version = ""
+	}
 	errorList := make([]error, 0)
 	availablePlugins, err := discoverSpecificPlugins(discoveries, discovery.WithPluginDiscoveryCriteria(criteria))
 	if err != nil {
@@ -505,6 +602,7 @@ func installPlugin(pluginName, version string, target configtypes.Target, contex
 	// This leverages Apples Rosetta emulator and Windows 11 emulator until plugins
 	// are all available for ARM64.  Note that this approach cannot be used on Linux since there
 	// is no such emulator.
+	var restoreArch func()
 	if len(availablePlugins) == 0 &&
 		(cli.BuildArch() == cli.DarwinARM64 || cli.BuildArch() == cli.WinARM64) {
 		// Pretend we are on a AMD64 machine so that we can find the plugin.
@@ -512,11 +610,11 @@ func installPlugin(pluginName, version string, target configtypes.Target, contex
 		case cli.DarwinARM64:
 			cli.SetArch(cli.DarwinAMD64)
 			criteria.Arch = cli.DarwinAMD64.Arch()
-			defer cli.SetArch(cli.DarwinARM64) // Go back to ARM64 once the plugin is installed
+			restoreArch = func() { cli.SetArch(cli.DarwinARM64) }
 		case cli.WinARM64:
 			cli.SetArch(cli.WinAMD64)
 			criteria.Arch = cli.WinAMD64.Arch()
-			defer cli.SetArch(cli.WinARM64) // Go back to ARM64 once the plugin is installed
+			restoreArch = func() { cli.SetArch(cli.WinARM64) }
 		}
 
 		availablePlugins, err = discoverSpecificPlugins(discoveries, discovery.WithPluginDiscoveryCriteria(criteria))
@@ -528,13 +626,17 @@ func installPlugin(pluginName, version string, target configtypes.Target, contex
 	if len(availablePlugins) == 0 {
 		if target != configtypes.TargetUnknown {
 			errorList = append(errorList, errors.Errorf("unable to find plugin '%v' matching version '%v' for target '%s'", pluginName, version, string(target)))
-			return kerrors.NewAggregate(errorList)
+			return nil, restoreArch, kerrors.NewAggregate(errorList)
 		}
 		errorList = append(errorList, errors.Errorf("unable to find plugin '%v' matching version '%v'", pluginName, version))
-		return kerrors.NewAggregate(errorList)
+		return nil, restoreArch, kerrors.NewAggregate(errorList)
 	}
 
 	// Deal with duplicates from different plugin discovery sources
+	availablePlugins, err = applyPluginConflictPolicy(availablePlugins)
+	if err != nil {
+		return nil, restoreArch, err
+	}
 	availablePlugins = mergeDuplicatePlugins(availablePlugins)
 
 	var matchedPlugins []discovery.Discovered
@@ -551,32 +653,90 @@ func installPlugin(pluginName, version string, target configtypes.Target, contex
 	if len(matchedPlugins) == 0 {
 		if target != configtypes.TargetUnknown {
 			errorList = append(errorList, errors.Errorf("unable to find plugin '%v' matching version '%v' for target '%s'", pluginName, version, string(target)))
-			return kerrors.NewAggregate(errorList)
+			return nil, restoreArch, kerrors.NewAggregate(errorList)
 		}
 		errorList = append(errorList, errors.Errorf("unable to find plugin '%v' matching version '%v'", pluginName, version))
-		return kerrors.NewAggregate(errorList)
+		return nil, restoreArch, kerrors.NewAggregate(errorList)
 	}
 
+	var matchedPlugin *discovery.Discovered
 	if len(matchedPlugins) == 1 {
-		return installOrUpgradePlugin(&matchedPlugins[0], matchedPlugins[0].RecommendedVersion, false)
+		matchedPlugin = &matchedPlugins[0]
+	} else {
+		for i := range matchedPlugins {
+			if matchedPlugins[i].Target == target {
+				matchedPlugin = &matchedPlugins[i]
+				break
+			}
+		}
+	}
+	if matchedPlugin == nil {
+		errorList = append(errorList, errors.Errorf(missingTargetStr, pluginName))
+		return nil, restoreArch, kerrors.NewAggregate(errorList)
 	}
 
-	for i := range matchedPlugins {
-		if matchedPlugins[i].Target == target {
-			return installOrUpgradePlugin(&matchedPlugins[i], matchedPlugins[i].RecommendedVersion, false)
+	if versionConstraint != "" {
+		if err := resolveVersionConstraint(matchedPlugin, versionConstraint); err != nil {
+			return nil, restoreArch, err
 		}
 	}
-	errorList = append(errorList, errors.Errorf(missingTargetStr, pluginName))
-	return kerrors.NewAggregate(errorList)
+	return matchedPlugin, restoreArch, nil
+}
+
+// resolveVersionConstraint selects the most recent supported version of the plugin
+// that satisfies the version constraint as the version to install.
+func resolveVersionConstraint(p *discovery.Discovered, constraint string) error {
+	version, err := utils.LatestVersionInConstraint(p.SupportedVersions, constraint)
+	if err != nil {
+		return errors.Wrapf(err, "invalid version constraint '%s'", constraint)
+	}
+	if version == "" {
+		return errors.Errorf("unable to find a version of plugin '%v' for target '%s' matching the version constraint '%v'. Available versions: %s",
+			p.Name, p.Target, constraint, strings.Join(p.SupportedVersions, ", "))
+	}
+	p.RecommendedVersion = version
+	p.VersionConstraint = constraint
+	return nil
 }
 
 // UpgradePlugin upgrades a plugin from the given repository.
+// When upgrading to the latest version, a plugin installed with a version constraint
+// is upgraded to the latest version satisfying that constraint.
 func UpgradePlugin(pluginName, version string, target configtypes.Target) error {
+	if pin := getInstalledPluginPin(pluginName, target); pin != nil {
+		return fmt.Errorf("plugin '%s' with target '%s' is pinned to version '%s'. Run 'tanzu plugin unpin %s@%s' to allow it to be upgraded",
+			pin.Name, pin.Target, pin.Version, pin.Name, pin.Target)
+	}
+	if version == cli.VersionLatest {
+		if constraint := getInstalledVersionConstraint(pluginName, target); constraint != "" {
+			log.Infof("Upgrading plugin '%s' within its version constraint '%s'", pluginName, constraint)
+			version = constraint
+		}
+	}
 	// Upgrade is only triggered from a manual user operation.
 	// This means a plugin is installed manually, which means it is installed as a standalone plugin.
 	return InstallStandalonePlugin(pluginName, version, target)
 }
 
+// getInstalledVersionConstraint returns the version constraint the specified plugin was installed with, if any.
+// If the target is not specified, the plugin name must match a single installed plugin.
+func getInstalledVersionConstraint(pluginName string, target configtypes.Target) string {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return ""
+	}
+	var matchedPlugins []cli.PluginInfo
+	for i := range installedPlugins {
+		if installedPlugins[i].Name == pluginName && (target == configtypes.TargetUnknown || installedPlugins[i].Target == target) {
+			matchedPlugins = append(matchedPlugins, installedPlugins[i])
+		}
+	}
+	if len(matchedPlugins) != 1 {
+		return ""
+	}
+	return matchedPlugins[0].VersionConstraint
+}
+
 // InstallPluginsFromGroup installs either the specified plugin or all plugins from the specified group version.
 // If the group version is not specified, the latest available version will be used.
 // The group identifier including the version used is returned.
@@ -598,26 +758,58 @@ func InstallPluginsFromGroup(pluginName, groupIDAndVersion string, options ...Pl
 
 // InstallPluginsFromGivenPluginGroup installs either the specified plugin or all plugins from given plugin group plugins.
 func InstallPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion string, pg *plugininventory.PluginGroup) (string, error) {
-	numErrors := 0
-	numInstalled := 0
 	mandatoryPluginsExist := false
 	pluginExist := false
+	var requests []PluginInstallRequest
 	for _, plugin := range pg.Versions[pg.RecommendedVersion] {
 		if pluginName == cli.AllPlugins || pluginName == plugin.Name {
 			pluginExist = true
 			if plugin.Mandatory {
 				mandatoryPluginsExist = true
-				err := InstallStandalonePlugin(plugin.Name, plugin.Version, plugin.Target)
-				if err != nil {
-					numErrors++
-					log.Warningf("unable to install plugin '%s': %v", plugin.Name, err.Error())
-				} else {
-					numInstalled++
-				}
+				requests = append(requests, PluginInstallRequest{Name: plugin.Name, Version: plugin.Version, Target: plugin.Target})
 			}
 		}
 	}
 
+	// The mandatory plugins of a group are installed all together or not at all,
+	// so record the installed plugins to be able to restore them on failure
+	snapshot, err := takePluginCatalogSnapshot()
+	if err != nil {
+		return groupIDAndVersion, err
+	}
+
+	// Pinned plugins are kept at the version they were pinned to
+	numPinned := len(requests)
+	requests = SkipPinnedPlugins(requests)
+	numPinned -= len(requests)
+
+	numErrors := 0
+	numInstalled := 0
+	results := InstallStandalonePlugins(requests)
+	for i := range results {
+		if results[i].Err != nil {
+			numErrors++
+		} else {
+			numInstalled++
+		}
+	}
+	rolledBack := false
+	if numErrors > 0 && numInstalled > 0 {
+		if err := snapshot.restore(results); err != nil {
+			log.Warningf("unable to restore the plugins installed before installing the plugin group '%s': %v", groupIDAndVersion, err.Error())
+		} else {
+			rolledBack = true
+		}
+	}
+	if len(results) > 0 {
+		DisplayPluginInstallResults(results, os.Stderr)
+	}
+	for i := range results {
+		if results[i].Err != nil {
+			log.Warningf("unable to install plugin '%s': %v", results[i].Name, results[i].Err.Error())
+		}
+	}
+
 	if !pluginExist {
 		return groupIDAndVersion, fmt.Errorf("plugin '%s' is not part of the group '%s'", pluginName, groupIDAndVersion)
 	}
@@ -630,10 +822,13 @@ func InstallPluginsFromGivenPluginGroup(pluginName, groupIDAndVersion string, pg
 	}
 
 	if numErrors > 0 {
+		if rolledBack {
+			return groupIDAndVersion, fmt.Errorf("could not install %d plugin(s) from group '%s'; the previously installed plugins were restored", numErrors, groupIDAndVersion)
+		}
 		return groupIDAndVersion, fmt.Errorf("could not install %d plugin(s) from group '%s'", numErrors, groupIDAndVersion)
 	}
 
-	if numInstalled == 0 {
+	if numInstalled == 0 && numPinned == 0 {
 		return groupIDAndVersion, fmt.Errorf("plugin '%s' is not part of the group '%s'", pluginName, groupIDAndVersion)
 	}
 
@@ -810,20 +1005,38 @@ func fetchAndVerifyPlugin(p *discovery.Discovered, version string) ([]byte, erro
 		return nil, errors.Wrapf(err, "%q plugin pre-download verification failed", p.Name)
 	}
 
+	d, err := p.Distribution.GetDigest(version, cli.GOOS, cli.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+
+	// The download cache verifies the content of the binary against the digest
+	if b, found := downloadcache.Get(d); found {
+		log.V(6).Infof("Using the binary of plugin %q version %q from the download cache", p.Name, version)
+		return b, nil
+	}
+	if config.IsOfflineMode() && p.DiscoveryType != common.DiscoveryTypeLocal {
+		return nil, discovery.NewOfflineError(fmt.Sprintf("downloading plugin %q version %q", p.Name, version))
+	}
+
 	b, err := p.Distribution.Fetch(version, cli.GOOS, cli.GOARCH)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to fetch the plugin metadata for plugin %q", p.Name)
 	}
 
 	// verify plugin after download but before installation
-	d, err := p.Distribution.GetDigest(version, cli.GOOS, cli.GOARCH)
-	if err != nil {
-		return nil, err
-	}
 	err = verifyPluginPostDownload(p, d, b)
 	if err != nil {
 		return nil, errors.Wrapf(err, "%q plugin post-download verification failed", p.Name)
 	}
+
+	if d != "" {
+		entry := &downloadcache.Entry{Digest: d, Name: p.Name, Target: p.Target, Version: version, OS: cli.GOOS, Arch: cli.GOARCH}
+		if err := downloadcache.Put(entry, b); err != nil {
+			// Failing to cache the binary should not prevent the installation
+			log.V(6).Infof("Unable to store plugin %q in the download cache: %v", p.Name, err)
+		}
+	}
 	return b, nil
 }
 
@@ -857,10 +1070,16 @@ func describePlugin(p *discovery.Discovered, pluginPath string) (*cli.PluginInfo
 		return nil, errors.Wrapf(err, "could not unmarshal plugin %q description", p.Name)
 	}
 	plugin.InstallationPath = pluginPath
+	// The digest is always computed from the installed binary as
+	// the digest reported by the plugin itself cannot be trusted
+	if plugin.Digest, err = utils.SHA256FromFile(pluginPath); err != nil {
+		return nil, errors.Wrapf(err, "could not compute the digest of plugin %q", p.Name)
+	}
 	plugin.Discovery = p.Source
 	plugin.DiscoveredRecommendedVersion = p.RecommendedVersion
 	plugin.Target = p.Target
 	plugin.Scope = p.Scope
+	plugin.VersionConstraint = p.VersionConstraint
 	if plugin.Version == p.RecommendedVersion {
 		plugin.Status = common.PluginStatusInstalled
 	} else {
@@ -889,6 +1108,14 @@ func doInstallTestPlugin(p *discovery.Discovered, pluginPath, version string) er
 }
 
 func updatePluginInfoAndInitializePlugin(p *discovery.Discovered, plugin *cli.PluginInfo) error {
+	// The hooks are run before the catalog is locked as they can take time to complete
+	previous := getReplacedPlugin(p.ContextName, plugin)
+	if previous != nil {
+		if err := runPluginHook(previous, PreUpgradeHook, "--to-version", plugin.Version); err != nil {
+			log.Warningf("%v", err)
+		}
+	}
+
 	c, err := catalog.NewContextCatalogUpdater(p.ContextName)
 	if err != nil {
 		return err
@@ -905,6 +1132,11 @@ func updatePluginInfoAndInitializePlugin(p *discovery.Discovered, plugin *cli.Pl
 	if err := InitializePlugin(plugin); err != nil {
 		log.Infof("could not initialize plugin after installing: %v", err.Error())
 	}
+	if previous != nil {
+		if err := runPluginHook(plugin, PostUpgradeHook, "--from-version", previous.Version); err != nil {
+			log.Warningf("%v", err)
+		}
+	}
 	if err := configlib.ConfigureFeatureFlags(plugin.DefaultFeatureFlags, configlib.SkipIfExists()); err != nil {
 		log.Infof("could not configure default featureflags for the plugin: %v", err.Error())
 	}
@@ -913,6 +1145,20 @@ func updatePluginInfoAndInitializePlugin(p *discovery.Discovered, plugin *cli.Pl
 	return nil
 }
 
+// getReplacedPlugin returns the installed plugin that the installation of the given plugin
+// replaces with a different version, if any
+func getReplacedPlugin(contextName string, plugin *cli.PluginInfo) *cli.PluginInfo {
+	c, err := catalog.NewContextCatalog(contextName)
+	if err != nil {
+		return nil
+	}
+	previous, found := c.Get(catalog.PluginNameTarget(plugin.Name, plugin.Target))
+	if !found || previous.Version == plugin.Version {
+		return nil
+	}
+	return &previous
+}
+
 // addPluginToCommandTreeCache would construct and add the plugin command tree to the command tree cache
 // which would be consumed by telemetry for plugin command chain parsing
 func addPluginToCommandTreeCache(plugin *cli.PluginInfo) {
@@ -1027,17 +1273,47 @@ func DeletePlugin(options DeletePluginOptions) error {
 		}
 	}
 
-	for i := range matchedPlugins {
+	pluginsToDelete, hookErrs := runPreUninstallHooks(matchedPlugins, options.IgnoreHookFailure)
+
+	for i := range pluginsToDelete {
 		// Delete the plugins from the command tree cache which would be consumed by telemetry
-		deletePluginFromCommandTreeCache(&matchedPlugins[i])
+		deletePluginFromCommandTreeCache(&pluginsToDelete[i])
 	}
 
 	// Delete the plugins that match from the catalog
-	return doDeletePluginsFromCatalog(matchedPlugins)
+	if err := doDeletePluginsFromCatalog(pluginsToDelete); err != nil {
+		hookErrs = append(hookErrs, err)
+	}
+	// A plugin that is installed again must not remain pinned to the version that was uninstalled
+	if err := deletePluginPins(pluginsToDelete); err != nil {
+		hookErrs = append(hookErrs, err)
+	}
+	return kerrors.NewAggregate(hookErrs)
 
 	// TODO: delete the plugin binary if it is not used by any server
 }
 
+// runPreUninstallHooks runs the pre-uninstall hook of the plugins to delete and returns the
+// plugins that can be deleted. A plugin which hook failed is not deleted unless hook failures
+// are ignored, in which case the failure is only reported.
+func runPreUninstallHooks(plugins []cli.PluginInfo, ignoreFailures bool) ([]cli.PluginInfo, []error) {
+	var errList []error
+	pluginsToDelete := make([]cli.PluginInfo, 0, len(plugins))
+	for i := range plugins {
+		err := runPluginHook(&plugins[i], PreUninstallHook)
+		switch {
+		case err == nil:
+			pluginsToDelete = append(pluginsToDelete, plugins[i])
+		case ignoreFailures:
+			log.Warningf("%v", err)
+			pluginsToDelete = append(pluginsToDelete, plugins[i])
+		default:
+			errList = append(errList, errors.Wrapf(err, "plugin '%s' for target '%s' was not uninstalled. Use --force to uninstall it anyway", plugins[i].Name, plugins[i].Target))
+		}
+	}
+	return pluginsToDelete, errList
+}
+
 func doDeletePluginsFromCatalog(plugins []cli.PluginInfo) error {
 	errList := make([]error, 0)
 
@@ -1073,7 +1349,7 @@ func doDeletePluginsFromCatalog(plugins []cli.PluginInfo) error {
 	return kerrors.NewAggregate(errList)
 }
 
-func DiscoverPluginsForContextType(contextType configtypes.ContextType) ([]discovery.Discovered, error) {
+func DiscoverPluginsForContextType(contextType configtypes.ContextType, options ...discovery.DiscoveryOptions) ([]discovery.Discovered, error) {
 	ctx, err := configlib.GetActiveContext(contextType)
 	if err != nil {
 		return nil, err
@@ -1082,7 +1358,7 @@ func DiscoverPluginsForContextType(contextType configtypes.ContextType) ([]disco
 		return nil, fmt.Errorf(errorNoActiveContexForGivenContextType, contextType)
 	}
 	log.Infof("Fetching recommended plugins for active context '%s'...", ctx.Name)
-	return discoverServerPluginsForGivenContexts([]*configtypes.Context{ctx})
+	return discoverServerPluginsForGivenContexts([]*configtypes.Context{ctx}, options...)
 }
 
 // UpdatePluginsInstallationStatus updates the installation status of the given plugins
@@ -1092,10 +1368,19 @@ func UpdatePluginsInstallationStatus(plugins []discovery.Discovered) {
 	}
 }
 
-// InstallPluginsFromLocalSource installs plugin from local source directory
+// InstallPluginsFromLocalSource installs plugin from local source directory or plugin archive
 //
 //nolint:gocyclo
 func InstallPluginsFromLocalSource(pluginName, version string, target configtypes.Target, localPath string, installTestPlugin bool) error {
+	if isPluginArchive(localPath) {
+		dir, err := extractPluginArchive(localPath)
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+		localPath = dir
+	}
+
 	// Set default local plugin distro to local-path as while installing the plugin
 	// from local source we should take t
 	common.DefaultLocalPluginDistroDir = localPath
@@ -1150,11 +1435,21 @@ func InstallPluginsFromLocalSource(pluginName, version string, target configtype
 	return nil
 }
 
-// DiscoverPluginsFromLocalSource returns the available plugins that are discovered from the provided local path
+// DiscoverPluginsFromLocalSource returns the available plugins that are discovered from the provided local path.
+// The local path can be a directory or a plugin archive, in which case the artifacts of the
+// discovered plugins are not available once the function returns.
 func DiscoverPluginsFromLocalSource(localPath string) ([]discovery.Discovered, error) {
 	if localPath == "" {
 		return nil, nil
 	}
+	if isPluginArchive(localPath) {
+		dir, err := extractPluginArchive(localPath)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		localPath = dir
+	}
 
 	plugins, err := discoverPluginsFromLocalSource(localPath)
 	// If no error then return the discovered plugins
@@ -1215,11 +1510,23 @@ func discoverPluginsFromLocalSource(localPath string) ([]discovery.Discovered, e
 func Clean() error {
 	errorList := make([]error, 0)
 
+	// Give the installed plugins a chance to clean up the state they own, as for an uninstallation.
+	// Cleaning is used to recover from a broken installation, so the plugins are removed even
+	// if their pre-uninstall hook fails.
+	if plugins, err := pluginsupplier.GetInstalledPlugins(); err == nil {
+		runPreUninstallHooks(plugins, true)
+	}
+
 	// Clean the plugin catalog
 	if err := catalog.CleanCatalogCache(); err != nil {
 		errorList = append(errorList, errors.Wrapf(err, "Failed to clean the catalog cache"))
 	}
 
+	// Remove the pins of the plugins
+	if err := savePinnedPlugins(nil); err != nil {
+		errorList = append(errorList, errors.Wrapf(err, "Failed to clean the pinned plugins"))
+	}
+
 	// Clean plugin inventory cache
 	pluginDataDir := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName)
 	if err := os.RemoveAll(pluginDataDir); err != nil {
@@ -1462,7 +1769,12 @@ func getPluginDiscoveries() ([]configtypes.PluginDiscovery, error) {
 	// may contain older versions of a plugin that is now published to the production
 	// central repo; we therefore need to search the test discoveries last.
 	discoverySources, _ := configlib.GetCLIDiscoverySources()
-	return append(discoverySources, testDiscoveries...), nil
+	discoverySources = append(discoverySources, testDiscoveries...)
+
+	// The discovery sources with a higher priority are searched first,
+	// which gives precedence to their plugins and plugin groups
+	config.SortDiscoverySourcesByPriority(discoverySources)
+	return discoverySources, nil
 }
 
 // IsPluginsFromPluginGroupInstalled checks if all plugins from a specific group are installed and if a new version is available.
@@ -1524,9 +1836,10 @@ func IsPluginsFromPluginGroupInstalled(name, version string, options ...PluginMa
 	group := groups[0]
 
 	// Create a list of mandatory plugins from the group.
+	// Pinned plugins are ignored as they are not installed from the group.
 	var pluginsOfGroup []*plugininventory.PluginGroupPluginEntry
 	for _, plugin := range group.Versions[group.RecommendedVersion] {
-		if plugin.Mandatory {
+		if plugin.Mandatory && GetPinnedPlugin(plugin.Name, plugin.Target) == nil {
 			pluginsOfGroup = append(pluginsOfGroup, plugin)
 		}
 	}
@@ -23,7 +23,6 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
-	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
@@ -160,15 +159,6 @@ const (
 	digestForARM64 = "1111111111"
 )
 
-func findDiscoveredPlugin(discovered []discovery.Discovered, pluginName string, target configtypes.Target) *discovery.Discovered {
-	for i := range discovered {
-		if pluginName == discovered[i].Name && target == discovered[i].Target {
-			return &discovered[i]
-		}
-	}
-	return nil
-}
-
 func findPluginInfo(pd []cli.PluginInfo, pluginName string, target configtypes.Target) *cli.PluginInfo {
 	for i := range pd {
 		if pluginName == pd[i].Name && target == pd[i].Target {
@@ -23,6 +23,7 @@ import (
 	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/distribution"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/downloadcache"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
 )
@@ -229,6 +230,24 @@ func Test_DiscoverPluginGroups(t *testing.T) {
 	}
 }
 
+func Test_InstallStandalonePluginOffline(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+	t.Setenv(constants.OfflineMode, "true")
+
+	// The plugin inventory is read from the cache and the plugin binary is already in the cache
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetUnknown))
+
+	// The plugin binary cannot be downloaded
+	assertions.Nil(os.RemoveAll(common.DefaultPluginRoot))
+	err := InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), `downloading plugin "login" version "v0.2.0" requires network`)
+}
+
 func Test_InstallStandalonePlugin(t *testing.T) {
 	assertions := assert.New(t)
 
@@ -420,12 +439,12 @@ func Test_InstallStandalonePlugin(t *testing.T) {
 
 		if strings.HasPrefix(pd.Name, "pluginnoarm") {
 			// Make sure these plugins are always installed as AMD64
-			assertions.Equal(digestForAMD64, pd.Digest)
+			assertions.Contains(filepath.Base(pd.InstallationPath), digestForAMD64)
 		}
 
 		if strings.HasPrefix(pd.Name, "pluginwitharm") {
 			// Make sure these plugins are always installed as ARM64
-			assertions.Equal(digestForARM64, pd.Digest)
+			assertions.Contains(filepath.Base(pd.InstallationPath), digestForARM64)
 		}
 	}
 }
@@ -1030,6 +1049,54 @@ func TestVerifyPluginPostDownload(t *testing.T) {
 	}
 }
 
+func TestFetchAndVerifyPluginUsesDownloadCache(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+
+	// Use a copy of the plugin binary so that it can be removed
+	b, err := os.ReadFile("test/local/distribution/v0.2.0/tanzu-login")
+	assertions.Nil(err)
+	binaryPath := filepath.Join(common.DefaultCacheDir, "tanzu-login")
+	assertions.Nil(os.WriteFile(binaryPath, b, 0755))
+
+	p := &discovery.Discovered{
+		Name:               "login",
+		Target:             configtypes.TargetGlobal,
+		RecommendedVersion: "v0.2.0",
+		Distribution: distribution.Artifacts{
+			"v0.2.0": []distribution.Artifact{{
+				URI:    "file://" + binaryPath,
+				Digest: "e109197e3e4ed9f13065596367f1fd0992df43717c7098324da4a00cb8b81c36",
+				OS:     cli.GOOS,
+				Arch:   cli.GOARCH,
+			}},
+		},
+	}
+
+	fetched, err := fetchAndVerifyPlugin(p, "v0.2.0")
+	assertions.Nil(err)
+	assertions.Equal(b, fetched)
+
+	// Once downloaded, the binary is served from the download cache
+	assertions.Nil(os.Remove(binaryPath))
+	fetched, err = fetchAndVerifyPlugin(p, "v0.2.0")
+	assertions.Nil(err)
+	assertions.Equal(b, fetched)
+
+	// Cleaning the plugins does not empty the download cache
+	assertions.Nil(Clean())
+	fetched, err = fetchAndVerifyPlugin(p, "v0.2.0")
+	assertions.Nil(err)
+	assertions.Equal(b, fetched)
+
+	entries, err := downloadcache.List()
+	assertions.Nil(err)
+	assertions.Equal(1, len(entries))
+	assertions.Equal("login", entries[0].Name)
+	assertions.Equal("v0.2.0", entries[0].Version)
+}
+
 func TestHelperProcess(_ *testing.T) {
 	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
 		return
@@ -1494,6 +1561,55 @@ func TestMergeDuplicatePluginsWithReplacedVersion(t *testing.T) {
 	assertions.Equal(expectedPlugin, mergedPlugins[0])
 }
 
+func TestMergeDuplicatePluginsWithDeprecationAndReleaseNotes(t *testing.T) {
+	assertions := assert.New(t)
+
+	newPlugin := func(source, version string) discovery.Discovered {
+		return discovery.Discovered{
+			Name:               "myplugin",
+			Target:             configtypes.TargetK8s,
+			RecommendedVersion: version,
+			SupportedVersions:  []string{version},
+			Distribution: distribution.Artifacts{
+				version: []distribution.Artifact{{Image: "localhost:9876/my/discovery/linux_amd64:" + version, OS: "linux", Arch: "amd64"}},
+			},
+			Source: source,
+		}
+	}
+
+	first := newPlugin("discovery1", "v1.0.0")
+	first.ReleaseNotes = map[string]string{"v1.0.0": "First notes"}
+	second := newPlugin("discovery2", "v2.0.0")
+	second.Deprecated = true
+	second.DeprecationMessage = "Use the other plugin"
+	second.ReplacedBy = "otherplugin"
+	second.ReleaseNotes = map[string]string{"v1.0.0": "Second notes", "v2.0.0": "Notes of v2.0.0"}
+
+	mergedPlugins := mergeDuplicatePlugins([]discovery.Discovered{first, second})
+	assertions.Equal(1, len(mergedPlugins))
+	assertions.True(mergedPlugins[0].Deprecated)
+	assertions.Equal("Use the other plugin", mergedPlugins[0].DeprecationMessage)
+	assertions.Equal("otherplugin", mergedPlugins[0].ReplacedBy)
+	// The release notes of the first plugin found are kept
+	assertions.Equal(map[string]string{"v1.0.0": "First notes", "v2.0.0": "Notes of v2.0.0"}, mergedPlugins[0].ReleaseNotes)
+
+	// The deprecation details of the first plugin found are kept
+	first = newPlugin("discovery1", "v1.0.0")
+	first.Deprecated = true
+	first.DeprecationMessage = "No longer maintained"
+	second = newPlugin("discovery2", "v2.0.0")
+	second.Deprecated = true
+	second.DeprecationMessage = "Use the other plugin"
+	second.ReplacedBy = "otherplugin"
+
+	mergedPlugins = mergeDuplicatePlugins([]discovery.Discovered{first, second})
+	assertions.Equal(1, len(mergedPlugins))
+	assertions.True(mergedPlugins[0].Deprecated)
+	assertions.Equal("No longer maintained", mergedPlugins[0].DeprecationMessage)
+	assertions.Equal("otherplugin", mergedPlugins[0].ReplacedBy)
+	assertions.Empty(mergedPlugins[0].ReleaseNotes)
+}
+
 func TestMergeDuplicateGroups(t *testing.T) {
 	assertions := assert.New(t)
 
@@ -0,0 +1,131 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"sort"
+
+	"github.com/Masterminds/semver"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// OutdatedPlugin describes an installed plugin for which a newer version is available
+type OutdatedPlugin struct {
+	Name      string
+	Target    configtypes.Target
+	Installed string
+	// Recommended is the recommended version of the plugin. The version recommended
+	// by an active context takes precedence over the one of the discovery sources.
+	Recommended string
+	// Latest is the most recent version among the supported versions of the plugin
+	Latest string
+	// NewerMajor is set if a version with a higher major version than the installed one is available
+	NewerMajor bool
+	// ContextName is the name of the context recommending the plugin, if any
+	ContextName string
+}
+
+// GetOutdatedPlugins compares the installed plugins with the plugins available from the discovery
+// sources and the plugins recommended by the active contexts, and returns the installed plugins for
+// which a newer version is available, sorted by name and target.
+// Outdated plugins found using the reachable discovery sources are returned along with any error.
+func GetOutdatedPlugins() ([]OutdatedPlugin, error) {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+	if len(installedPlugins) == 0 {
+		return nil, nil
+	}
+
+	errList := make([]error, 0)
+	standalonePlugins, err := DiscoverStandalonePlugins()
+	if err != nil {
+		errList = append(errList, err)
+	}
+	contextPlugins, err := DiscoverServerPlugins()
+	if err != nil {
+		errList = append(errList, err)
+	}
+
+	var outdated []OutdatedPlugin
+	for i := range installedPlugins {
+		if isAdHocPlugin(&installedPlugins[i]) {
+			// No discovery source provides the versions of the plugin
+			continue
+		}
+		p := OutdatedPlugin{
+			Name:      installedPlugins[i].Name,
+			Target:    installedPlugins[i].Target,
+			Installed: installedPlugins[i].Version,
+		}
+
+		var supportedVersions []string
+		if standalone := findDiscoveredPlugin(standalonePlugins, p.Name, p.Target); standalone != nil {
+			p.Recommended = standalone.RecommendedVersion
+			supportedVersions = append(supportedVersions, standalone.SupportedVersions...)
+		}
+		if recommended := findDiscoveredPlugin(contextPlugins, p.Name, p.Target); recommended != nil {
+			p.Recommended = recommended.RecommendedVersion
+			p.ContextName = recommended.ContextName
+			supportedVersions = append(supportedVersions, recommended.SupportedVersions...)
+		}
+		p.Latest = getLatestVersion(append(supportedVersions, p.Recommended))
+		p.NewerMajor = p.Latest != "" && !utils.IsSameMajor(p.Latest, p.Installed) && utils.IsNewVersion(p.Latest, p.Installed)
+
+		if utils.IsNewVersion(p.Recommended, p.Installed) || utils.IsNewVersion(p.Latest, p.Installed) {
+			outdated = append(outdated, p)
+		}
+	}
+
+	sort.Slice(outdated, func(i, j int) bool {
+		if outdated[i].Name != outdated[j].Name {
+			return outdated[i].Name < outdated[j].Name
+		}
+		return outdated[i].Target < outdated[j].Target
+	})
+	return outdated, kerrors.NewAggregate(errList)
+}
+
+// findDiscoveredPlugin returns the discovered plugin matching the name and target, if any
+func findDiscoveredPlugin(plugins []discovery.Discovered, name string, target configtypes.Target) *discovery.Discovered {
+	for i := range plugins {
+		if plugins[i].Name == name && plugins[i].Target == target {
+			return &plugins[i]
+		}
+	}
+	return nil
+}
+
+// getLatestVersion returns the most recent of the specified versions.
+// Pre-release versions are only considered if there are no other versions.
+func getLatestVersion(versions []string) string {
+	var latest, latestPreRelease *semver.Version
+	for _, v := range versions {
+		sv, err := semver.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		if sv.Prerelease() != "" {
+			if latestPreRelease == nil || sv.GreaterThan(latestPreRelease) {
+				latestPreRelease = sv
+			}
+		} else if latest == nil || sv.GreaterThan(latest) {
+			latest = sv
+		}
+	}
+	if latest == nil {
+		latest = latestPreRelease
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Original()
+}
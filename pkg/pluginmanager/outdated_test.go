@@ -0,0 +1,59 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestGetOutdatedPlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// No installed plugins
+	outdated, err := GetOutdatedPlugins()
+	assertions.Nil(err)
+	assertions.Empty(outdated)
+
+	err = InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	err = InstallStandalonePlugin("cluster", "v1.6.0", configtypes.TargetK8s)
+	assertions.Nil(err)
+
+	// The plugins recommended by the test contexts cannot be discovered as their
+	// clusters are not reachable, but the outdated plugins are still reported
+	outdated, err = GetOutdatedPlugins()
+	assertions.NotNil(err)
+	assertions.Equal([]OutdatedPlugin{
+		{Name: "login", Target: configtypes.TargetGlobal, Installed: "v0.2.0", Recommended: "v0.20.0", Latest: "v0.20.0"},
+	}, outdated)
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		expected string
+	}{
+		{name: "no versions", versions: nil, expected: ""},
+		{name: "unsorted versions", versions: []string{"v1.2.0", "v2.0.1", "v1.10.0"}, expected: "v2.0.1"},
+		{name: "pre-release ignored", versions: []string{"v1.2.0", "v2.0.0-beta.1"}, expected: "v1.2.0"},
+		{name: "only pre-releases", versions: []string{"v2.0.0-alpha.1", "v2.0.0-beta.1"}, expected: "v2.0.0-beta.1"},
+		{name: "invalid versions ignored", versions: []string{"invalid", "v0.1.0", ""}, expected: "v0.1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getLatestVersion(tt.versions))
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/component"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+)
+
+const (
+	pluginInstallStatusInstalled = "installed"
+	pluginInstallStatusFailed    = "failed"
+	pluginInstallStatusReverted  = "rolled back"
+)
+
+// PluginInstallRequest describes a plugin to install as part of a batch of plugins.
+type PluginInstallRequest struct {
+	Name    string
+	Version string
+	Target  configtypes.Target
+	// VersionConstraint is the semver range to record for the installed plugin, if any.
+	// It is used to keep the version constraint of a plugin that is upgraded to a version within it.
+	VersionConstraint string
+}
+
+// PluginInstallResult is the outcome of the installation of a single plugin of a batch of plugins.
+type PluginInstallResult struct {
+	Name    string
+	Version string
+	Target  configtypes.Target
+	// Err is nil if the plugin was installed successfully
+	Err error
+	// RolledBack is set if the plugin was installed successfully but its
+	// installation was reverted because another plugin could not be installed
+	RolledBack bool
+}
+
+// pluginInstallJob tracks the progress of the installation of a single plugin
+type pluginInstallJob struct {
+	request PluginInstallRequest
+	plugin  *discovery.Discovered
+	// fallbackArch is set when the plugin is only available for AMD64 on an ARM64 machine.
+	// Such plugins depend on the global CLI architecture so they are not fetched in parallel.
+	fallbackArch bool
+	// cached is set when the plugin binary is already available locally
+	cached *cli.PluginInfo
+	binary []byte
+	err    error
+}
+
+// InstallStandalonePlugins installs the specified plugins as standalone plugins.
+// The plugin binaries are downloaded and verified by a bounded pool of workers, while the
+// updates to the plugin catalog and command tree cache are done sequentially.
+// One result is returned for each request, in the order of the requests.
+func InstallStandalonePlugins(requests []PluginInstallRequest) []PluginInstallResult {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	var spinner component.OutputWriterSpinner
+	installingMsg := fmt.Sprintf("Installing %d plugin(s)", len(requests))
+	if component.IsTTYEnabled() {
+		spinner = component.NewOutputWriterSpinner(component.WithOutputStream(os.Stderr),
+			component.WithSpinnerText(installingMsg),
+			component.WithSpinnerStarted())
+		defer spinner.StopSpinner()
+	} else {
+		log.Info(installingMsg)
+	}
+
+	jobs := make([]*pluginInstallJob, len(requests))
+	for i := range requests {
+		jobs[i] = &pluginInstallJob{request: requests[i]}
+	}
+
+	// Plugin discovery uses the shared inventory cache so it is done sequentially
+	for _, job := range jobs {
+		var restoreArch func()
+		job.plugin, restoreArch, job.err = findPluginToInstall(job.request.Name, job.request.Version, job.request.Target, "")
+		if job.err == nil {
+			job.err = resolvePluginRequirements(job.plugin, nil)
+		}
+		if restoreArch != nil {
+			restoreArch()
+			job.fallbackArch = true
+		}
+		if job.err == nil && job.request.VersionConstraint != "" {
+			job.plugin.VersionConstraint = job.request.VersionConstraint
+		}
+	}
+
+	fetchPlugins(jobs, getPluginInstallConcurrency())
+
+	results := make([]PluginInstallResult, len(jobs))
+	for i, job := range jobs {
+		results[i] = PluginInstallResult{
+			Name:    job.request.Name,
+			Version: job.request.Version,
+			Target:  job.request.Target,
+			Err:     installFetchedPlugin(job),
+		}
+		if job.plugin != nil {
+			results[i].Version = job.plugin.RecommendedVersion
+			results[i].Target = job.plugin.Target
+		}
+	}
+
+	// The warnings are printed once the spinner is stopped so they are not overwritten.
+	// Plugins installed through the fallback architecture already printed their warning.
+	if spinner != nil {
+		spinner.StopSpinner()
+	}
+	for i, job := range jobs {
+		if results[i].Err == nil && !job.fallbackArch {
+			warnIfDeprecatedVersion(job.plugin)
+		}
+	}
+	return results
+}
+
+// fetchPlugins downloads and verifies the binaries of the plugins to install
+// using at most the specified number of concurrent workers.
+func fetchPlugins(jobs []*pluginInstallJob, concurrency int) {
+	queue := make(chan *pluginInstallJob)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				if job.cached = getPluginFromCache(job.plugin, job.plugin.RecommendedVersion); job.cached == nil {
+					job.binary, job.err = fetchAndVerifyPlugin(job.plugin, job.plugin.RecommendedVersion)
+				}
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		if job.err == nil && !job.fallbackArch {
+			queue <- job
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// installFetchedPlugin installs a plugin which binary was fetched and
+// registers it in the plugin catalog and the command tree cache.
+func installFetchedPlugin(job *pluginInstallJob) error {
+	if job.err != nil {
+		return job.err
+	}
+	if job.fallbackArch {
+		return installPluginWithVersionConstraint(job.request.Name, job.request.Version, job.request.Target, "", job.request.VersionConstraint)
+	}
+
+	plugin := job.cached
+	if plugin == nil {
+		var err error
+		if plugin, err = installAndDescribePlugin(job.plugin, job.plugin.RecommendedVersion, job.binary); err != nil {
+			return err
+		}
+	}
+	return updatePluginInfoAndInitializePlugin(job.plugin, plugin)
+}
+
+// getPluginInstallConcurrency returns the maximum number of plugins to fetch in parallel
+func getPluginInstallConcurrency() int {
+	if concurrency, err := strconv.Atoi(os.Getenv(constants.PluginInstallConcurrency)); err == nil && concurrency > 0 {
+		return concurrency
+	}
+	return constants.DefaultPluginInstallConcurrency
+}
+
+// DisplayPluginInstallResults displays the outcome of the installation of each plugin as a table
+func DisplayPluginInstallResults(results []PluginInstallResult, writer io.Writer) {
+	output := component.NewOutputWriterWithOptions(writer, "", []component.OutputWriterOption{}, "Name", "Target", "Version", "Status")
+	for i := range results {
+		status := pluginInstallStatusInstalled
+		if results[i].Err != nil {
+			status = pluginInstallStatusFailed
+		} else if results[i].RolledBack {
+			status = pluginInstallStatusReverted
+		}
+		output.AddRow(results[i].Name, results[i].Target, results[i].Version, status)
+	}
+	output.Render()
+}
+
+// PluginInstallResultsError aggregates the errors of the plugins that could not be installed
+func PluginInstallResultsError(results []PluginInstallResult) error {
+	var errList []error
+	for i := range results {
+		if results[i].Err != nil {
+			errList = append(errList, errors.Wrapf(results[i].Err, "unable to install plugin '%s'", results[i].Name))
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/constants"
+)
+
+func TestInstallStandalonePlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	os.Setenv(constants.PluginInstallConcurrency, "2")
+	defer os.Unsetenv(constants.PluginInstallConcurrency)
+
+	results := InstallStandalonePlugins([]PluginInstallRequest{
+		{Name: "login", Version: "v0.2.0", Target: configtypes.TargetGlobal},
+		{Name: "invalid", Version: "v1.0.0", Target: configtypes.TargetGlobal},
+		{Name: "cluster", Version: "v1.6.0", Target: configtypes.TargetK8s},
+		{Name: "myplugin", Version: "v1.6.0", Target: configtypes.TargetK8s},
+	})
+
+	// Results are returned in the order of the requests
+	assertions.Equal(4, len(results))
+	assertions.Equal("login", results[0].Name)
+	assertions.Nil(results[0].Err)
+	assertions.Equal("invalid", results[1].Name)
+	assertions.NotNil(results[1].Err)
+	assertions.Contains(results[1].Err.Error(), "unable to find plugin 'invalid' matching version 'v1.0.0' for target 'global'")
+	assertions.Equal("cluster", results[2].Name)
+	assertions.Nil(results[2].Err)
+	assertions.Equal("myplugin", results[3].Name)
+	assertions.Nil(results[3].Err)
+
+	assertions.True(checkPluginIsInstalled("login", configtypes.TargetGlobal))
+	assertions.True(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+	assertions.True(checkPluginIsInstalled("myplugin", configtypes.TargetK8s))
+
+	err := PluginInstallResultsError(results)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to install plugin 'invalid'")
+	assertions.NotContains(err.Error(), "unable to install plugin 'login'")
+
+	var b bytes.Buffer
+	DisplayPluginInstallResults(results, &b)
+	assertions.Contains(b.String(), "login")
+	assertions.Contains(b.String(), pluginInstallStatusInstalled)
+	assertions.Contains(b.String(), pluginInstallStatusFailed)
+
+	// Installing again uses the plugin binaries that are already available locally
+	results = InstallStandalonePlugins([]PluginInstallRequest{
+		{Name: "login", Version: "v0.2.0", Target: configtypes.TargetGlobal},
+		{Name: "cluster", Version: "v1.6.0", Target: configtypes.TargetK8s},
+	})
+	assertions.Nil(PluginInstallResultsError(results))
+}
+
+func TestGetPluginInstallConcurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		expected int
+	}{
+		{name: "not set", envValue: "", expected: constants.DefaultPluginInstallConcurrency},
+		{name: "valid value", envValue: "8", expected: 8},
+		{name: "zero", envValue: "0", expected: constants.DefaultPluginInstallConcurrency},
+		{name: "invalid value", envValue: "many", expected: constants.DefaultPluginInstallConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(constants.PluginInstallConcurrency, tt.envValue)
+			defer os.Unsetenv(constants.PluginInstallConcurrency)
+
+			assert.Equal(t, tt.expected, getPluginInstallConcurrency())
+		})
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/datastore"
+)
+
+// dataStorePinnedPluginsKey is the key used to store the pinned plugins in the datastore.
+const dataStorePinnedPluginsKey = "pinnedPlugins"
+
+// PinnedPlugin is a plugin held at the version it was pinned to.
+// Pinned plugins are not upgraded by 'plugin upgrade', 'plugin sync',
+// context creation or the installation of plugin groups.
+type PinnedPlugin struct {
+	Name    string             `json:"name" yaml:"name"`
+	Target  configtypes.Target `json:"target" yaml:"target"`
+	Version string             `json:"version" yaml:"version"`
+}
+
+// GetPinnedPlugins returns the pinned plugins sorted by name and target
+func GetPinnedPlugins() []PinnedPlugin {
+	var pinnedPlugins []PinnedPlugin
+	// The key is missing until the first plugin is pinned
+	_ = datastore.GetDataStoreValue(dataStorePinnedPluginsKey, &pinnedPlugins)
+	return pinnedPlugins
+}
+
+// GetPinnedPlugin returns the pin of the plugin matching the name and target, or nil if the plugin is not pinned
+func GetPinnedPlugin(pluginName string, target configtypes.Target) *PinnedPlugin {
+	pinnedPlugins := GetPinnedPlugins()
+	for i := range pinnedPlugins {
+		if pinnedPlugins[i].Name == pluginName && pinnedPlugins[i].Target == target {
+			return &pinnedPlugins[i]
+		}
+	}
+	return nil
+}
+
+// PinPlugin pins an installed plugin at its installed version
+func PinPlugin(pluginName string, target configtypes.Target) (*PinnedPlugin, error) {
+	plugin, err := DescribePlugin(pluginName, target)
+	if err != nil {
+		return nil, err
+	}
+
+	pin := PinnedPlugin{Name: plugin.Name, Target: plugin.Target, Version: plugin.Version}
+	pinnedPlugins := GetPinnedPlugins()
+	found := false
+	for i := range pinnedPlugins {
+		if pinnedPlugins[i].Name == pin.Name && pinnedPlugins[i].Target == pin.Target {
+			pinnedPlugins[i] = pin
+			found = true
+			break
+		}
+	}
+	if !found {
+		pinnedPlugins = append(pinnedPlugins, pin)
+	}
+	if err := savePinnedPlugins(pinnedPlugins); err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// UnpinPlugin removes the pin of the plugin matching the name and target.
+// If the target is unknown, the plugin name must identify a single pinned plugin.
+func UnpinPlugin(pluginName string, target configtypes.Target) (*PinnedPlugin, error) {
+	pinnedPlugins := GetPinnedPlugins()
+	var matches []int
+	for i := range pinnedPlugins {
+		if pinnedPlugins[i].Name == pluginName && (target == configtypes.TargetUnknown || pinnedPlugins[i].Target == target) {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		if target == configtypes.TargetUnknown {
+			return nil, fmt.Errorf("plugin '%s' is not pinned", pluginName)
+		}
+		return nil, fmt.Errorf("plugin '%s' with target '%s' is not pinned", pluginName, target)
+	}
+	if len(matches) > 1 {
+		return nil, errors.Errorf(missingTargetStr, pluginName)
+	}
+
+	pin := pinnedPlugins[matches[0]]
+	pinnedPlugins = append(pinnedPlugins[:matches[0]], pinnedPlugins[matches[0]+1:]...)
+	if err := savePinnedPlugins(pinnedPlugins); err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// deletePluginPins removes the pins of the given plugins, if any
+func deletePluginPins(plugins []cli.PluginInfo) error {
+	pinnedPlugins := GetPinnedPlugins()
+	remaining := make([]PinnedPlugin, 0, len(pinnedPlugins))
+	for _, pin := range pinnedPlugins {
+		pinned := false
+		for i := range plugins {
+			if plugins[i].Name == pin.Name && plugins[i].Target == pin.Target {
+				pinned = true
+				break
+			}
+		}
+		if !pinned {
+			remaining = append(remaining, pin)
+		}
+	}
+	if len(remaining) == len(pinnedPlugins) {
+		return nil
+	}
+	return savePinnedPlugins(remaining)
+}
+
+// getInstalledPluginPin returns the pin of the installed plugin matching the name and target, if any
+func getInstalledPluginPin(pluginName string, target configtypes.Target) *PinnedPlugin {
+	plugin, err := DescribePlugin(pluginName, target)
+	if err != nil {
+		return nil
+	}
+	return GetPinnedPlugin(plugin.Name, plugin.Target)
+}
+
+// SkipPinnedPlugins removes the requests for pinned plugins, logging a message for each of them
+func SkipPinnedPlugins(requests []PluginInstallRequest) []PluginInstallRequest {
+	var result []PluginInstallRequest
+	for _, request := range requests {
+		if pin := GetPinnedPlugin(request.Name, request.Target); pin != nil {
+			LogSkippedPinnedPlugin(pin)
+			continue
+		}
+		result = append(result, request)
+	}
+	return result
+}
+
+// LogSkippedPinnedPlugin informs the user that a plugin is not installed because it is pinned
+func LogSkippedPinnedPlugin(pin *PinnedPlugin) {
+	log.Infof("Skipping plugin '%s' with target '%s' as it is pinned to version '%s'. Run 'tanzu plugin unpin %s@%s' to allow it to be updated",
+		pin.Name, pin.Target, pin.Version, pin.Name, pin.Target)
+}
+
+func savePinnedPlugins(pinnedPlugins []PinnedPlugin) error {
+	sort.Slice(pinnedPlugins, func(i, j int) bool {
+		if pinnedPlugins[i].Name != pinnedPlugins[j].Name {
+			return pinnedPlugins[i].Name < pinnedPlugins[j].Name
+		}
+		return pinnedPlugins[i].Target < pinnedPlugins[j].Target
+	})
+	if len(pinnedPlugins) == 0 {
+		_ = datastore.DeleteDataStoreValue(dataStorePinnedPluginsKey)
+		return nil
+	}
+	return errors.Wrap(datastore.SetDataStoreValue(dataStorePinnedPluginsKey, pinnedPlugins), "unable to save the pinned plugins")
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+func setupDataStoreForTesting(t *testing.T) {
+	t.Setenv("TEST_CUSTOM_DATA_STORE_FILE", filepath.Join(t.TempDir(), ".data-store.yaml"))
+}
+
+func TestPinAndUnpinPlugin(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// A plugin that is not installed cannot be pinned
+	_, err := PinPlugin("login", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Empty(GetPinnedPlugins())
+
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal))
+	assertions.Nil(InstallStandalonePlugin("cluster", "v1.6.0", configtypes.TargetK8s))
+	assertions.Nil(InstallStandalonePlugin("cluster", "v0.2.0", configtypes.TargetTMC))
+
+	// The target is needed when the plugin name is not unique
+	_, err = PinPlugin("cluster", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+
+	pin, err := PinPlugin("login", configtypes.TargetUnknown)
+	assertions.Nil(err)
+	assertions.Equal(PinnedPlugin{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.2.0"}, *pin)
+	_, err = PinPlugin("cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+	_, err = PinPlugin("cluster", configtypes.TargetTMC)
+	assertions.Nil(err)
+
+	assertions.Equal([]PinnedPlugin{
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.6.0"},
+		{Name: "cluster", Target: configtypes.TargetTMC, Version: "v0.2.0"},
+		{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.2.0"},
+	}, GetPinnedPlugins())
+	assertions.NotNil(GetPinnedPlugin("login", configtypes.TargetGlobal))
+	assertions.Nil(GetPinnedPlugin("login", configtypes.TargetK8s))
+
+	// A pinned plugin cannot be upgraded
+	err = UpgradePlugin("login", cli.VersionLatest, configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "plugin 'login' with target 'global' is pinned to version 'v0.2.0'")
+
+	// Pinned plugins are not part of the upgrade plan
+	plan, err := GetPluginUpgradePlan()
+	assertions.Nil(err)
+	assertions.Empty(plan)
+
+	// Unpinning requires the target when the plugin name is not unique among the pinned plugins
+	_, err = UnpinPlugin("cluster", configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	pin, err = UnpinPlugin("cluster", configtypes.TargetTMC)
+	assertions.Nil(err)
+	assertions.Equal(configtypes.TargetTMC, pin.Target)
+	_, err = UnpinPlugin("cluster", configtypes.TargetTMC)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "plugin 'cluster' with target 'mission-control' is not pinned")
+
+	_, err = UnpinPlugin("login", configtypes.TargetUnknown)
+	assertions.Nil(err)
+	assertions.Nil(UpgradePlugin("login", cli.VersionLatest, configtypes.TargetGlobal))
+
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.20.0", pd.Version)
+}
+
+func TestInstallPinnedPlugin(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal))
+	_, err := PinPlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+
+	// Another version of a pinned plugin cannot be installed
+	err = InstallStandalonePlugin("login", "v0.20.0", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "plugin 'login' with target 'global' is pinned to version 'v0.2.0'. Run 'tanzu plugin unpin login@global' to allow another version to be installed")
+	err = InstallStandalonePlugin("login", cli.VersionLatest, configtypes.TargetUnknown)
+	assertions.NotNil(err)
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+
+	// The pinned version can be installed again
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal))
+	assertions.Equal([]PinnedPlugin{{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.2.0"}}, GetPinnedPlugins())
+
+	// Once unpinned, another version can be installed
+	_, err = UnpinPlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Nil(InstallStandalonePlugin("login", "v0.20.0", configtypes.TargetGlobal))
+	pd, err = DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.20.0", pd.Version)
+}
+
+func TestUninstallPinnedPlugin(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal))
+	assertions.Nil(InstallStandalonePlugin("cluster", "v1.6.0", configtypes.TargetK8s))
+	_, err := PinPlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	_, err = PinPlugin("cluster", configtypes.TargetK8s)
+	assertions.Nil(err)
+
+	// Uninstalling a plugin removes its pin but not the pins of the other plugins
+	assertions.Nil(DeletePlugin(DeletePluginOptions{PluginName: "login", Target: configtypes.TargetGlobal, ForceDelete: true}))
+	assertions.Nil(GetPinnedPlugin("login", configtypes.TargetGlobal))
+	assertions.Equal([]PinnedPlugin{{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.6.0"}}, GetPinnedPlugins())
+
+	// Once installed again, the plugin can be upgraded
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal))
+	assertions.Nil(UpgradePlugin("login", "v0.20.0", configtypes.TargetGlobal))
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.20.0", pd.Version)
+
+	// Cleaning the plugins removes all the pins
+	assertions.Nil(Clean())
+	assertions.Empty(GetPinnedPlugins())
+}
+
+func TestInstallPluginsFromGivenPluginGroupSkipsPinnedPlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	assertions.Nil(InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal))
+	_, err := PinPlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+
+	pg := &plugininventory.PluginGroup{
+		Vendor:             "vmware",
+		Publisher:          "test",
+		Name:               "pinned",
+		RecommendedVersion: "v1.0.0",
+		Versions: map[string][]*plugininventory.PluginGroupPluginEntry{
+			"v1.0.0": {
+				{PluginIdentifier: plugininventory.PluginIdentifier{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.20.0"}, Mandatory: true},
+				{PluginIdentifier: plugininventory.PluginIdentifier{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.6.0"}, Mandatory: true},
+			},
+		},
+	}
+
+	_, err = InstallPluginsFromGivenPluginGroup(cli.AllPlugins, "vmware-test/pinned:v1.0.0", pg)
+	assertions.Nil(err)
+
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+	assertions.True(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+
+	// Installing only the pinned plugin of the group is not an error
+	_, err = InstallPluginsFromGivenPluginGroup("login", "vmware-test/pinned:v1.0.0", pg)
+	assertions.Nil(err)
+}
+
+func TestSkipPinnedPlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	setupDataStoreForTesting(t)
+	assertions.Nil(savePinnedPlugins([]PinnedPlugin{{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.2.0"}}))
+
+	requests := []PluginInstallRequest{
+		{Name: "login", Target: configtypes.TargetGlobal, Version: "v0.20.0"},
+		{Name: "login", Target: configtypes.TargetK8s, Version: "v0.20.0"},
+		{Name: "cluster", Target: configtypes.TargetK8s, Version: "v1.6.0"},
+	}
+	assertions.Equal(requests[1:], SkipPinnedPlugins(requests))
+
+	assertions.Nil(savePinnedPlugins(nil))
+	assertions.Equal(requests, SkipPinnedPlugins(requests))
+}
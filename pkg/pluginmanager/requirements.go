@@ -0,0 +1,108 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/discovery"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// resolvePluginRequirements makes sure the requirements of the version of the plugin
+// about to be installed are met. Missing or outdated plugin dependencies are installed,
+// while an error explaining how to fix the problem is returned if a requirement cannot be met.
+// The resolving parameter holds the plugins being installed, which are considered available,
+// so that circular dependencies do not cause an endless loop.
+func resolvePluginRequirements(p *discovery.Discovered, resolving []string) error {
+	requirements, ok := p.Requirements[p.RecommendedVersion]
+	if !ok {
+		return nil
+	}
+
+	if err := checkMinCLIVersion(p, requirements.MinCLIVersion); err != nil {
+		return err
+	}
+
+	resolving = append(resolving, catalog.PluginNameTarget(p.Name, p.Target))
+	for _, dep := range requirements.Dependencies {
+		if err := resolvePluginDependency(p, dep, resolving); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMinCLIVersion returns an error if the version of the CLI is older than the minimum version
+func checkMinCLIVersion(p *discovery.Discovered, minCLIVersion string) error {
+	if minCLIVersion == "" || !utils.IsNewVersion(minCLIVersion, buildinfo.Version) {
+		return nil
+	}
+	return errors.Errorf("plugin '%s' version '%s' requires version '%s' or later of the Tanzu CLI but the current version is '%s'. "+
+		"Please upgrade the Tanzu CLI or install an older version of the plugin", p.Name, p.RecommendedVersion, minCLIVersion, buildinfo.Version)
+}
+
+// CheckMinCLIVersion returns an error if the recommended version of the plugin
+// requires a newer version of the Tanzu CLI
+func CheckMinCLIVersion(p *discovery.Discovered) error {
+	return checkMinCLIVersion(p, p.Requirements[p.RecommendedVersion].MinCLIVersion)
+}
+
+// resolvePluginDependency installs the plugin dependency unless an installed plugin already satisfies it
+func resolvePluginDependency(p *discovery.Discovered, dep plugininventory.PluginDependency, resolving []string) error {
+	for _, id := range resolving {
+		if id == catalog.PluginNameTarget(dep.Name, dep.Target) {
+			return nil
+		}
+	}
+
+	if installed, err := DescribePlugin(dep.Name, dep.Target); err == nil {
+		if dep.VersionConstraint == "" || utils.IsVersionInConstraint(installed.Version, dep.VersionConstraint) {
+			return nil
+		}
+		if pin := GetPinnedPlugin(installed.Name, installed.Target); pin != nil {
+			return errors.Errorf("plugin '%s' version '%s' requires plugin '%s' for target '%s' matching version '%s' but the installed plugin is pinned to version '%s'. "+
+				"Please run 'tanzu plugin unpin %s@%s' and try again", p.Name, p.RecommendedVersion, dep.Name, dep.Target, dep.VersionConstraint, pin.Version, pin.Name, pin.Target)
+		}
+	}
+
+	version := cli.VersionLatest
+	if dep.VersionConstraint != "" {
+		version = dep.VersionConstraint
+	}
+	log.Infof("Installing plugin '%s' for target '%s' required by plugin '%s' version '%s'", dep.Name, dep.Target, p.Name, p.RecommendedVersion)
+	if err := installPluginDependency(dep, version, resolving); err != nil {
+		installCmd := fmt.Sprintf("tanzu plugin install %s --target %s", dep.Name, dep.Target)
+		if dep.VersionConstraint != "" {
+			installCmd += fmt.Sprintf(" --version '%s'", dep.VersionConstraint)
+		}
+		return errors.Wrapf(err, "plugin '%s' version '%s' requires plugin '%s' for target '%s' which could not be installed. "+
+			"Please install it using '%s' and try again", p.Name, p.RecommendedVersion, dep.Name, dep.Target, installCmd)
+	}
+	return nil
+}
+
+// installPluginDependency installs a plugin along with its own requirements
+func installPluginDependency(dep plugininventory.PluginDependency, version string, resolving []string) error {
+	p, restoreArch, err := findPluginToInstall(dep.Name, version, dep.Target, "")
+	if restoreArch != nil {
+		defer restoreArch()
+	}
+	if err != nil {
+		return err
+	}
+	if err := resolvePluginRequirements(p, resolving); err != nil {
+		return err
+	}
+	warnIfDeprecatedVersion(p)
+	return installOrUpgradePlugin(p, p.RecommendedVersion, false)
+}
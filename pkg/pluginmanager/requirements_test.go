@@ -0,0 +1,117 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"database/sql"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/buildinfo"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+const createRequirementsStmt = `
+INSERT INTO PluginRequirements VALUES('login','global','v0.20.0','v9.0.0');
+INSERT INTO PluginDependencies VALUES('isolated-cluster','global','v1.3.0','feature','kubernetes','>=v0.2.0');
+INSERT INTO PluginDependencies VALUES('myplugin','kubernetes','v1.6.0','invalid','global','');
+INSERT INTO PluginDependencies VALUES('cluster','kubernetes','v1.6.0','management-cluster','kubernetes','v1.6.0');
+INSERT INTO PluginDependencies VALUES('management-cluster','kubernetes','v1.6.0','cluster','kubernetes','');
+INSERT INTO PluginDependencies VALUES('management-cluster','mission-control','v0.2.0','isolated-cluster','global','<v1.3.0');
+`
+
+func setupPluginRequirementsForTesting(t *testing.T) {
+	dbFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, config.DefaultStandaloneDiscoveryName, plugininventory.SQliteDBFileName)
+	db, err := sql.Open("sqlite", dbFile)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(createRequirementsStmt)
+	assert.Nil(t, err)
+}
+
+func TestInstallPluginWithRequirements(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	setupPluginRequirementsForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	currentVersion := buildinfo.Version
+	buildinfo.Version = "v1.0.0"
+	defer func() { buildinfo.Version = currentVersion }()
+
+	// The CLI is too old for this plugin version
+	err := InstallStandalonePlugin("login", "v0.20.0", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "plugin 'login' version 'v0.20.0' requires version 'v9.0.0' or later of the Tanzu CLI but the current version is 'v1.0.0'")
+	assertions.False(checkPluginIsInstalled("login", configtypes.TargetGlobal))
+
+	// Other versions of the plugin can still be installed
+	err = InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+
+	// A missing dependency is installed along with the plugin
+	err = InstallStandalonePlugin("isolated-cluster", "v1.3.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.True(checkPluginIsInstalled("feature", configtypes.TargetK8s))
+
+	// A dependency that cannot be installed prevents the installation of the plugin
+	err = InstallStandalonePlugin("myplugin", "v1.6.0", configtypes.TargetK8s)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "plugin 'myplugin' version 'v1.6.0' requires plugin 'invalid' for target 'global' which could not be installed. "+
+		"Please install it using 'tanzu plugin install invalid --target global' and try again")
+	assertions.False(checkPluginIsInstalled("myplugin", configtypes.TargetK8s))
+
+	// Circular dependencies are installed together
+	err = InstallStandalonePlugin("cluster", "v1.6.0", configtypes.TargetK8s)
+	assertions.Nil(err)
+	assertions.True(checkPluginIsInstalled("cluster", configtypes.TargetK8s))
+	assertions.True(checkPluginIsInstalled("management-cluster", configtypes.TargetK8s))
+
+	// An installed dependency that is pinned to an incompatible version is not changed
+	_, err = PinPlugin("isolated-cluster", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	err = InstallStandalonePlugin("management-cluster", "v0.2.0", configtypes.TargetTMC)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "Please run 'tanzu plugin unpin isolated-cluster@global' and try again")
+
+	// An installed dependency with an incompatible version is installed at a compatible version
+	_, err = UnpinPlugin("isolated-cluster", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	err = InstallStandalonePlugin("management-cluster", "v0.2.0", configtypes.TargetTMC)
+	assertions.Nil(err)
+	pd, err := DescribePlugin("isolated-cluster", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v1.2.3", pd.Version)
+}
+
+func TestInstallStandalonePluginsWithRequirements(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	setupDataStoreForTesting(t)
+	setupPluginRequirementsForTesting(t)
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	results := InstallStandalonePlugins([]PluginInstallRequest{
+		{Name: "isolated-cluster", Version: "v1.3.0", Target: configtypes.TargetGlobal},
+		{Name: "myplugin", Version: "v1.6.0", Target: configtypes.TargetK8s},
+	})
+	assertions.Equal(2, len(results))
+	assertions.Nil(results[0].Err)
+	assertions.NotNil(results[1].Err)
+	assertions.True(checkPluginIsInstalled("feature", configtypes.TargetK8s))
+	assertions.False(checkPluginIsInstalled("myplugin", configtypes.TargetK8s))
+}
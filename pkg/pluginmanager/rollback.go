@@ -0,0 +1,84 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// RollbackPlugin restores the previously installed version of a plugin.
+// The previous installation is reused if its binary is still present on the machine,
+// otherwise the previous version is installed again.
+// The installations more recent than the previous one are removed from the history
+// of the plugin, so that rolling back again restores an even older version.
+// The version of the plugin that was restored is returned.
+func RollbackPlugin(pluginName string, target configtypes.Target) (string, error) {
+	current, err := DescribePlugin(pluginName, target)
+	if err != nil {
+		return "", err
+	}
+
+	c, err := catalog.NewContextCatalogUpdater("")
+	if err != nil {
+		return "", err
+	}
+
+	var previous *cli.PluginInfo
+	var rolledBackPaths []string
+	pluginNameTarget := catalog.PluginNameTarget(current.Name, current.Target)
+	history := c.GetHistory(pluginNameTarget)
+	for i := range history {
+		if history[i].Version != current.Version {
+			previous = &history[i]
+			break
+		}
+		rolledBackPaths = append(rolledBackPaths, history[i].InstallationPath)
+	}
+	if previous == nil {
+		c.Unlock()
+		return "", errors.Errorf("unable to find a previous version of plugin '%s' for target '%s' to roll back to", current.Name, current.Target)
+	}
+
+	if !utils.PathExists(previous.InstallationPath) {
+		// The previous installation was cleaned up, so it needs to be installed again
+		c.Unlock()
+		log.Infof("Plugin '%s:%s' is no longer available locally and will be installed again", previous.Name, previous.Version)
+		if err := installPluginWithVersionConstraint(previous.Name, previous.Version, previous.Target, "", previous.VersionConstraint); err != nil {
+			return "", err
+		}
+		return previous.Version, deleteFromInstallHistory(pluginNameTarget, rolledBackPaths)
+	}
+
+	log.Infof("Rolling back plugin '%s' with target '%s' from '%s' to '%s'", current.Name, current.Target, current.Version, previous.Version)
+	err = c.Upsert(previous)
+	if err == nil {
+		err = c.DeleteFromHistory(pluginNameTarget, rolledBackPaths)
+	}
+	// Release the lock as soon as the catalog is updated
+	c.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	// The command tree of the plugin can differ between versions
+	addPluginToCommandTreeCache(previous)
+	return previous.Version, nil
+}
+
+// deleteFromInstallHistory removes the given installations from the installation history of the plugin
+func deleteFromInstallHistory(pluginNameTarget string, installationPaths []string) error {
+	c, err := catalog.NewContextCatalogUpdater("")
+	if err != nil {
+		return err
+	}
+	defer c.Unlock()
+	return c.DeleteFromHistory(pluginNameTarget, installationPaths)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestRollbackPlugin(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// Rolling back a plugin that is not installed
+	_, err := RollbackPlugin("login", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to find plugin 'login' for target 'global'")
+
+	// Rolling back a plugin that was only installed once
+	err = InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	_, err = RollbackPlugin("login", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to find a previous version of plugin 'login' for target 'global' to roll back to")
+
+	// Upgrade and then roll back
+	err = UpgradePlugin("login", "v0.20.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.20.0", pd.Version)
+
+	version, err := RollbackPlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", version)
+	pd, err = DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+
+	// Rolling back again does not restore the version that was rolled back
+	_, err = RollbackPlugin("login", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to find a previous version of plugin 'login' for target 'global' to roll back to")
+	pd, err = DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+}
+
+func TestRollbackPluginTwiceInARow(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	for _, version := range []string{"v0.2.0-beta.1", "v0.2.0", "v0.20.0"} {
+		assertions.Nil(InstallStandalonePlugin("login", version, configtypes.TargetGlobal))
+	}
+
+	// Each rollback restores the version installed before the current one
+	for _, expectedVersion := range []string{"v0.2.0", "v0.2.0-beta.1"} {
+		version, err := RollbackPlugin("login", configtypes.TargetGlobal)
+		assertions.Nil(err)
+		assertions.Equal(expectedVersion, version)
+		pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+		assertions.Nil(err)
+		assertions.Equal(expectedVersion, pd.Version)
+	}
+
+	// There is no older version to roll back to
+	_, err := RollbackPlugin("login", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to find a previous version of plugin 'login' for target 'global' to roll back to")
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+// InstallStandalonePluginSideBySide installs a version of a plugin alongside its installed default version.
+// The default version of the plugin is not modified and the new version can be invoked explicitly,
+// e.g., using 'tanzu --plugin-version NAME=VERSION'.
+// If the plugin is not installed yet, the version is installed as the default version.
+func InstallStandalonePluginSideBySide(pluginName, version string, target configtypes.Target) error {
+	defaultPlugin, err := DescribePlugin(pluginName, target)
+	if err != nil {
+		log.Infof("Plugin '%s' is not installed yet, installing it as the default version", pluginName)
+		return InstallStandalonePlugin(pluginName, version, target)
+	}
+
+	p, restoreArch, err := findPluginToInstall(defaultPlugin.Name, version, defaultPlugin.Target, "")
+	if restoreArch != nil {
+		defer restoreArch()
+	}
+	if err != nil {
+		return err
+	}
+	version = p.RecommendedVersion
+	if version == defaultPlugin.Version {
+		log.Infof("Plugin '%s:%s' with target '%s' is already installed as the default version", p.Name, version, p.Target)
+		return nil
+	}
+
+	log.Infof("Installing plugin '%s:%s' with target '%s' side by side with the default version '%s'", p.Name, version, p.Target, defaultPlugin.Version)
+	plugin := getPluginFromCache(p, version)
+	if plugin == nil {
+		binary, err := fetchAndVerifyPlugin(p, version)
+		if err != nil {
+			return err
+		}
+		if plugin, err = installAndDescribePlugin(p, version, binary); err != nil {
+			return err
+		}
+	}
+
+	c, err := catalog.NewContextCatalogUpdater("")
+	if err != nil {
+		return err
+	}
+	err = c.UpsertSideBySideVersion(plugin)
+	c.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := InitializePlugin(plugin); err != nil {
+		log.Infof("could not initialize plugin after installing: %v", err.Error())
+	}
+	return nil
+}
+
+// GetSideBySideVersions returns the versions of an installed plugin that are installed
+// alongside its default version
+func GetSideBySideVersions(pluginName string, target configtypes.Target) ([]cli.PluginInfo, error) {
+	defaultPlugin, err := DescribePlugin(pluginName, target)
+	if err != nil {
+		return nil, err
+	}
+	c, err := catalog.NewContextCatalog("")
+	if err != nil {
+		return nil, err
+	}
+	return c.GetSideBySideVersions(catalog.PluginNameTarget(defaultPlugin.Name, defaultPlugin.Target)), nil
+}
+
+// SetDefaultPluginVersion makes a version installed side by side the default version of the plugin.
+// The previous default version remains installed side by side.
+func SetDefaultPluginVersion(pluginName, version string, target configtypes.Target) error {
+	defaultPlugin, err := DescribePlugin(pluginName, target)
+	if err != nil {
+		return err
+	}
+	if defaultPlugin.Version == version {
+		log.Infof("Version '%s' is already the default version of plugin '%s'", version, defaultPlugin.Name)
+		return nil
+	}
+
+	c, err := catalog.NewContextCatalogUpdater("")
+	if err != nil {
+		return err
+	}
+	defer c.Unlock()
+
+	var newDefault *cli.PluginInfo
+	pluginNameTarget := catalog.PluginNameTarget(defaultPlugin.Name, defaultPlugin.Target)
+	sideBySideVersions := c.GetSideBySideVersions(pluginNameTarget)
+	for i := range sideBySideVersions {
+		if sideBySideVersions[i].Version == version {
+			newDefault = &sideBySideVersions[i]
+			break
+		}
+	}
+	if newDefault == nil {
+		return errors.Errorf("version '%s' of plugin '%s' with target '%s' is not installed side by side", version, defaultPlugin.Name, defaultPlugin.Target)
+	}
+
+	if err := c.UpsertSideBySideVersion(defaultPlugin); err != nil {
+		return err
+	}
+	// Switching the default version is not an installation so it is not added to the history
+	if err := c.UpsertWithoutHistory(newDefault); err != nil {
+		return err
+	}
+	// The command tree of the plugin can differ between versions
+	addPluginToCommandTreeCache(newDefault)
+	return nil
+}
+
+// DeleteSideBySideVersion uninstalls a version of a plugin installed alongside its default version.
+// The default version of a plugin cannot be uninstalled this way.
+func DeleteSideBySideVersion(pluginName, version string, target configtypes.Target) error {
+	defaultPlugin, err := DescribePlugin(pluginName, target)
+	if err != nil {
+		return err
+	}
+	if defaultPlugin.Version == version {
+		return errors.Errorf("version '%s' is the default version of plugin '%s'. Use 'tanzu plugin set-default' to change the default version or uninstall the plugin", version, defaultPlugin.Name)
+	}
+
+	sideBySideVersions, err := GetSideBySideVersions(defaultPlugin.Name, defaultPlugin.Target)
+	if err != nil {
+		return err
+	}
+	for i := range sideBySideVersions {
+		if sideBySideVersions[i].Version != version {
+			continue
+		}
+		// The hook is run before the catalog is locked as it can take time to complete
+		if err := runPluginHook(&sideBySideVersions[i], PreUninstallHook); err != nil {
+			log.Warningf("%v", err)
+		}
+
+		c, err := catalog.NewContextCatalogUpdater("")
+		if err != nil {
+			return err
+		}
+		defer c.Unlock()
+		return c.DeleteSideBySideVersion(catalog.PluginNameTarget(defaultPlugin.Name, defaultPlugin.Target), version)
+	}
+	return errors.Errorf("version '%s' of plugin '%s' with target '%s' is not installed side by side", version, defaultPlugin.Name, defaultPlugin.Target)
+}
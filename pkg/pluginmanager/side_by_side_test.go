@@ -0,0 +1,85 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
+)
+
+func TestSideBySidePluginVersions(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// A plugin that is not installed is installed as the default version
+	err := InstallStandalonePluginSideBySide("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+
+	// The default version is not installed again side by side
+	err = InstallStandalonePluginSideBySide("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	versions, err := GetSideBySideVersions("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Empty(versions)
+
+	err = InstallStandalonePluginSideBySide("login", "v0.20.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	pd, err = DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+	versions, err = GetSideBySideVersions("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Len(versions, 1)
+	assertions.Equal("v0.20.0", versions[0].Version)
+
+	// Switch the default version
+	err = SetDefaultPluginVersion("login", "v0.3.0", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "version 'v0.3.0' of plugin 'login' with target 'global' is not installed side by side")
+
+	err = SetDefaultPluginVersion("login", "v0.20.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	pd, err = DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.20.0", pd.Version)
+	versions, err = GetSideBySideVersions("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Len(versions, 1)
+	assertions.Equal("v0.2.0", versions[0].Version)
+
+	// Switching the default version does not add it to the installation history
+	c, err := catalog.NewContextCatalog("")
+	assertions.Nil(err)
+	history := c.GetHistory(catalog.PluginNameTarget("login", configtypes.TargetGlobal))
+	assertions.Len(history, 1)
+	assertions.Equal("v0.2.0", history[0].Version)
+
+	// Uninstall the version installed side by side
+	err = DeleteSideBySideVersion("login", "v0.20.0", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "version 'v0.20.0' is the default version of plugin 'login'")
+
+	err = DeleteSideBySideVersion("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	versions, err = GetSideBySideVersions("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Empty(versions)
+	assertions.True(checkPluginIsInstalled("login", configtypes.TargetGlobal))
+
+	err = DeleteSideBySideVersion("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "version 'v0.2.0' of plugin 'login' with target 'global' is not installed side by side")
+}
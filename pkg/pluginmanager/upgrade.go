@@ -0,0 +1,135 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"sort"
+	"strings"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// PluginUpgrade describes the upgrade of an installed plugin to its recommended version
+type PluginUpgrade struct {
+	Name           string
+	Target         configtypes.Target
+	CurrentVersion string
+	TargetVersion  string
+	// Source is the discovery source providing the recommended version
+	Source string
+	// VersionConstraint is the semver range the plugin was installed with, if any
+	VersionConstraint string
+}
+
+// GetPluginUpgradePlan compares every installed plugin against the recommended version
+// discovered for it and returns the plugins that can be upgraded, sorted by name and target.
+// Plugins installed with a version constraint are compared against the most recent
+// supported version satisfying the constraint. Pinned plugins are not upgraded.
+func GetPluginUpgradePlan() ([]PluginUpgrade, error) {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+	if len(installedPlugins) == 0 {
+		return nil, nil
+	}
+
+	availablePlugins, err := DiscoverStandalonePlugins()
+	if err != nil {
+		if len(availablePlugins) == 0 {
+			return nil, err
+		}
+		// Some discovery sources may still be usable
+		log.Warningf("there was an error while discovering plugins, error information: '%v'", err)
+	}
+
+	var plan []PluginUpgrade
+	for i := range installedPlugins {
+		if isAdHocPlugin(&installedPlugins[i]) {
+			log.Infof("Skipping plugin '%s' with target '%s' which was installed from '%s' without a discovery source",
+				installedPlugins[i].Name, installedPlugins[i].Target, strings.TrimPrefix(installedPlugins[i].Discovery, common.AdHocPluginSourcePrefix))
+			continue
+		}
+		for j := range availablePlugins {
+			if installedPlugins[i].Name != availablePlugins[j].Name || installedPlugins[i].Target != availablePlugins[j].Target {
+				continue
+			}
+			if pin := GetPinnedPlugin(installedPlugins[i].Name, installedPlugins[i].Target); pin != nil {
+				LogSkippedPinnedPlugin(pin)
+				break
+			}
+			targetVersion := availablePlugins[j].RecommendedVersion
+			if installedPlugins[i].VersionConstraint != "" {
+				targetVersion, _ = utils.LatestVersionInConstraint(availablePlugins[j].SupportedVersions, installedPlugins[i].VersionConstraint)
+			}
+			if utils.IsNewVersion(targetVersion, installedPlugins[i].Version) {
+				plan = append(plan, PluginUpgrade{
+					Name:              installedPlugins[i].Name,
+					Target:            installedPlugins[i].Target,
+					CurrentVersion:    installedPlugins[i].Version,
+					TargetVersion:     targetVersion,
+					Source:            availablePlugins[j].Source,
+					VersionConstraint: installedPlugins[i].VersionConstraint,
+				})
+			}
+			break
+		}
+	}
+
+	sort.Slice(plan, func(i, j int) bool {
+		if plan[i].Name != plan[j].Name {
+			return plan[i].Name < plan[j].Name
+		}
+		return plan[i].Target < plan[j].Target
+	})
+	return plan, nil
+}
+
+// UpgradePlugins performs the specified plugin upgrades.
+// One result is returned for each upgrade, in the order of the upgrades.
+func UpgradePlugins(plan []PluginUpgrade) []PluginInstallResult {
+	requests := make([]PluginInstallRequest, len(plan))
+	for i := range plan {
+		requests[i] = PluginInstallRequest{
+			Name:              plan[i].Name,
+			Version:           plan[i].TargetVersion,
+			Target:            plan[i].Target,
+			VersionConstraint: plan[i].VersionConstraint,
+		}
+	}
+	return InstallStandalonePlugins(requests)
+}
+
+// ApplyVersionConstraints keeps the version constraints of the installed plugins when installing
+// the specified plugin versions. Plugins for which the requested version does not satisfy the version
+// constraint the plugin was installed with are skipped.
+func ApplyVersionConstraints(requests []PluginInstallRequest) []PluginInstallRequest {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return requests
+	}
+
+	var result []PluginInstallRequest
+	for _, request := range requests {
+		for i := range installedPlugins {
+			if installedPlugins[i].Name != request.Name || installedPlugins[i].Target != request.Target || installedPlugins[i].VersionConstraint == "" {
+				continue
+			}
+			request.VersionConstraint = installedPlugins[i].VersionConstraint
+			break
+		}
+		if request.VersionConstraint != "" && !utils.IsVersionInConstraint(request.Version, request.VersionConstraint) {
+			log.Warningf("Skipping plugin '%s' with target '%s': version '%s' does not satisfy the version constraint '%s' the plugin was installed with",
+				request.Name, request.Target, request.Version, request.VersionConstraint)
+			continue
+		}
+		result = append(result, request)
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestGetPluginUpgradePlanAndUpgradePlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// No installed plugins
+	plan, err := GetPluginUpgradePlan()
+	assertions.Nil(err)
+	assertions.Empty(plan)
+
+	err = InstallStandalonePlugin("login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	err = InstallStandalonePlugin("cluster", "v1.6.0", configtypes.TargetK8s)
+	assertions.Nil(err)
+
+	plan, err = GetPluginUpgradePlan()
+	assertions.Nil(err)
+	assertions.Equal([]PluginUpgrade{
+		{Name: "login", Target: configtypes.TargetGlobal, CurrentVersion: "v0.2.0", TargetVersion: "v0.20.0", Source: "default"},
+	}, plan)
+
+	results := UpgradePlugins(plan)
+	assertions.Nil(PluginInstallResultsError(results))
+	pd, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.20.0", pd.Version)
+
+	// Everything is up-to-date
+	plan, err = GetPluginUpgradePlan()
+	assertions.Nil(err)
+	assertions.Empty(plan)
+}
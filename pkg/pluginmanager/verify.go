@@ -0,0 +1,188 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/pluginsupplier"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/utils"
+)
+
+// PluginVerificationStatus is the outcome of the verification of an installed plugin binary
+type PluginVerificationStatus string
+
+const (
+	// PluginVerificationOK means the plugin binary matches the expected digests
+	PluginVerificationOK PluginVerificationStatus = "ok"
+	// PluginVerificationMissing means the plugin binary is no longer present
+	PluginVerificationMissing PluginVerificationStatus = "missing"
+	// PluginVerificationModified means the plugin binary does not match one of the expected digests
+	PluginVerificationModified PluginVerificationStatus = "modified"
+)
+
+// PluginVerification is the result of the verification of an installed plugin binary
+type PluginVerification struct {
+	Name             string                   `json:"name" yaml:"name"`
+	Target           configtypes.Target       `json:"target" yaml:"target"`
+	Version          string                   `json:"version" yaml:"version"`
+	InstallationPath string                   `json:"installationPath" yaml:"installationPath"`
+	Status           PluginVerificationStatus `json:"status" yaml:"status"`
+	// Digest is the digest of the plugin binary on the machine
+	Digest string `json:"digest" yaml:"digest"`
+	// Details explains why the verification failed or which digests could not be checked
+	Details string `json:"details" yaml:"details"`
+}
+
+// VerifyInstalledPlugins computes the digest of the binary of every installed plugin and
+// compares it with the digest recorded in the plugin catalog at installation time and with the
+// digest published for that version of the plugin by the discovery sources.
+// Plugins installed from an image or a URL are only compared with the recorded digest.
+// The results are sorted by name and target.
+func VerifyInstalledPlugins() ([]PluginVerification, error) {
+	installedPlugins, err := pluginsupplier.GetInstalledPlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	verifications := make([]PluginVerification, 0, len(installedPlugins))
+	for i := range installedPlugins {
+		verifications = append(verifications, verifyInstalledPlugin(&installedPlugins[i]))
+	}
+
+	sort.Slice(verifications, func(i, j int) bool {
+		if verifications[i].Name != verifications[j].Name {
+			return verifications[i].Name < verifications[j].Name
+		}
+		return verifications[i].Target < verifications[j].Target
+	})
+	return verifications, nil
+}
+
+func verifyInstalledPlugin(p *cli.PluginInfo) PluginVerification {
+	v := PluginVerification{
+		Name:             p.Name,
+		Target:           p.Target,
+		Version:          p.Version,
+		InstallationPath: p.InstallationPath,
+		Status:           PluginVerificationOK,
+	}
+
+	if !utils.PathExists(p.InstallationPath) {
+		v.Status = PluginVerificationMissing
+		v.Details = "the plugin binary is missing"
+		return v
+	}
+
+	var err error
+	if v.Digest, err = utils.SHA256FromFile(p.InstallationPath); err != nil {
+		v.Status = PluginVerificationMissing
+		v.Details = fmt.Sprintf("the plugin binary cannot be read: %v", err)
+		return v
+	}
+
+	var details []string
+	if p.Digest != "" && p.Digest != v.Digest {
+		v.Status = PluginVerificationModified
+		details = append(details, fmt.Sprintf("the digest does not match the digest '%s' recorded at installation", p.Digest))
+	}
+
+	if isAdHocPlugin(p) {
+		// No discovery source publishes the digest of the plugin
+		v.Details = strings.Join(details, "; ")
+		return v
+	}
+
+	publishedDigest, err := getPublishedDigest(p)
+	switch {
+	case err != nil:
+		details = append(details, "the published digest could not be checked: "+err.Error())
+	case publishedDigest != "" && publishedDigest != v.Digest:
+		v.Status = PluginVerificationModified
+		details = append(details, fmt.Sprintf("the digest does not match the published digest '%s'", publishedDigest))
+	}
+	v.Details = strings.Join(details, "; ")
+	return v
+}
+
+// getPublishedDigest returns the digest published by the discovery sources for
+// the binary of the installed version of the plugin
+func getPublishedDigest(p *cli.PluginInfo) (string, error) {
+	discovered, restoreArch, err := findPluginToInstall(p.Name, p.Version, p.Target, "")
+	if restoreArch != nil {
+		defer restoreArch()
+	}
+	if err != nil {
+		return "", errors.Errorf("unable to find plugin '%s' version '%s' in the discovery sources", p.Name, p.Version)
+	}
+	return discovered.Distribution.GetDigest(p.Version, cli.GOOS, cli.GOARCH)
+}
+
+// RepairPlugins removes the binaries of the plugins that failed the verification
+// and installs the same versions of these plugins again. Plugins installed from an
+// image or a URL are installed again from the same image or URL.
+// One result is returned for each plugin to repair.
+func RepairPlugins(verifications []PluginVerification) []PluginInstallResult {
+	var requests []PluginInstallRequest
+	var results []PluginInstallResult
+	installedPlugins, _ := pluginsupplier.GetInstalledPlugins()
+	for i := range verifications {
+		if verifications[i].Status == PluginVerificationOK {
+			continue
+		}
+		// Remove the binary so that it is not reused as an already installed binary
+		if err := os.Remove(verifications[i].InstallationPath); err != nil && !os.IsNotExist(err) {
+			results = append(results, PluginInstallResult{
+				Name:    verifications[i].Name,
+				Version: verifications[i].Version,
+				Target:  verifications[i].Target,
+				Err:     errors.Wrapf(err, "unable to remove the binary of plugin '%s'", verifications[i].Name),
+			})
+			continue
+		}
+		p := findPluginInfoByInstallationPath(installedPlugins, verifications[i].InstallationPath)
+		if p != nil && isAdHocPlugin(p) {
+			_, err := reinstallAdHocPlugin(p)
+			results = append(results, PluginInstallResult{
+				Name:    verifications[i].Name,
+				Version: verifications[i].Version,
+				Target:  verifications[i].Target,
+				Err:     err,
+			})
+			continue
+		}
+		request := PluginInstallRequest{
+			Name:    verifications[i].Name,
+			Version: verifications[i].Version,
+			Target:  verifications[i].Target,
+		}
+		if p != nil {
+			// Keep the version constraint the plugin was installed with
+			request.VersionConstraint = p.VersionConstraint
+		}
+		requests = append(requests, request)
+	}
+	if len(requests) == 0 {
+		return results
+	}
+	return append(results, InstallStandalonePlugins(requests)...)
+}
+
+// findPluginInfoByInstallationPath returns the plugin with the specified installation path, or nil if there is none
+func findPluginInfoByInstallationPath(plugins []cli.PluginInfo, installationPath string) *cli.PluginInfo {
+	for i := range plugins {
+		if plugins[i].InstallationPath == installationPath {
+			return &plugins[i]
+		}
+	}
+	return nil
+}
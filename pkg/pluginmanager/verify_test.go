@@ -0,0 +1,114 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/common"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/config"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/downloadcache"
+	"github.com/vmware-tanzu/tanzu-cli/pkg/plugininventory"
+)
+
+// setupPublishedPluginForTesting publishes a plugin binary which digest is the real digest of its content
+// and makes it available in the download cache. The digest of the binary is returned.
+func setupPublishedPluginForTesting(t *testing.T, name, version string, target configtypes.Target) string {
+	// The binary does not report a digest so that the digest of the installed binary is recorded in the catalog
+	binary := []byte(fmt.Sprintf(`{"name":"%s","description":"Test plugin","target":"%s","version":"%s","group":"Run"}`, name, target, version))
+	digest := fmt.Sprintf("%x", sha256.Sum256(binary))
+
+	dbFile := filepath.Join(common.DefaultCacheDir, common.PluginInventoryDirName, config.DefaultStandaloneDiscoveryName, plugininventory.SQliteDBFileName)
+	db, err := sql.Open("sqlite", dbFile)
+	assert.Nil(t, err)
+	defer db.Close()
+	_, err = db.Exec("UPDATE PluginBinaries SET Digest=? WHERE PluginName=? AND Target=? AND Version=? AND OS=? AND Architecture=?",
+		digest, name, target, version, cli.GOOS, cli.GOARCH)
+	assert.Nil(t, err)
+
+	assert.Nil(t, downloadcache.Put(&downloadcache.Entry{Digest: digest, Name: name, Target: target, Version: version}, binary))
+	return digest
+}
+
+func TestVerifyAndRepairPlugins(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	digest := setupPublishedPluginForTesting(t, "login", "v0.2.0", configtypes.TargetGlobal)
+	assertions.Nil(InstallStandalonePlugin("login", "~0.2.0", configtypes.TargetGlobal))
+	installed, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal(digest, installed.Digest)
+	assertions.Equal("~0.2.0", installed.VersionConstraint)
+
+	verifications, err := VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(1, len(verifications))
+	assertions.Equal(PluginVerificationOK, verifications[0].Status)
+	assertions.Equal(digest, verifications[0].Digest)
+	assertions.Empty(verifications[0].Details)
+
+	// A modified binary matches neither the recorded nor the published digest
+	assertions.Nil(os.WriteFile(installed.InstallationPath, []byte(`{"name":"login","target":"global","version":"v0.2.0"}`), 0755))
+	verifications, err = VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(PluginVerificationModified, verifications[0].Status)
+	assertions.Contains(verifications[0].Details, "the digest does not match the digest '"+digest+"' recorded at installation")
+	assertions.Contains(verifications[0].Details, "the digest does not match the published digest '"+digest+"'")
+
+	results := RepairPlugins(verifications)
+	assertions.Equal(1, len(results))
+	assertions.Nil(results[0].Err)
+	verifications, err = VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(PluginVerificationOK, verifications[0].Status)
+
+	// The version constraint of the repaired plugin is kept
+	repaired, err := DescribePlugin("login", configtypes.TargetGlobal)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", repaired.Version)
+	assertions.Equal("~0.2.0", repaired.VersionConstraint)
+
+	// A missing binary is reported and installed again
+	assertions.Nil(os.Remove(installed.InstallationPath))
+	verifications, err = VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(PluginVerificationMissing, verifications[0].Status)
+
+	results = RepairPlugins(verifications)
+	assertions.Nil(PluginInstallResultsError(results))
+	verifications, err = VerifyInstalledPlugins()
+	assertions.Nil(err)
+	assertions.Equal(PluginVerificationOK, verifications[0].Status)
+}
+
+func TestVerifyPluginNotInDiscoverySources(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	binaryPath := filepath.Join(common.DefaultPluginRoot, "local", "v1.0.0_local_global")
+	assertions.Nil(os.MkdirAll(filepath.Dir(binaryPath), 0755))
+	assertions.Nil(os.WriteFile(binaryPath, []byte("local plugin"), 0755))
+
+	v := verifyInstalledPlugin(&cli.PluginInfo{Name: "local", Target: configtypes.TargetGlobal, Version: "v1.0.0", InstallationPath: binaryPath})
+	assertions.Equal(PluginVerificationOK, v.Status)
+	assertions.Contains(v.Details, "the published digest could not be checked")
+}
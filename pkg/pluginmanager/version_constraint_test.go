@@ -0,0 +1,72 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginmanager
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+
+	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
+)
+
+func TestInstallPluginWithVersionConstraint(t *testing.T) {
+	assertions := assert.New(t)
+
+	defer setupPluginSourceForTesting()()
+	execCommand = fakeInfoExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	// No version satisfies the constraint
+	err := InstallStandalonePlugin("management-cluster", ">=1.0", configtypes.TargetTMC)
+	assertions.NotNil(err)
+	assertions.Contains(err.Error(), "unable to find a version of plugin 'management-cluster' for target 'mission-control' matching the version constraint '>=1.0'")
+
+	// The latest version satisfying the constraint is installed and the constraint is recorded
+	err = InstallStandalonePlugin("management-cluster", "~0.0.1", configtypes.TargetTMC)
+	assertions.Nil(err)
+	pd, err := DescribePlugin("management-cluster", configtypes.TargetTMC)
+	assertions.Nil(err)
+	assertions.Equal("v0.0.3", pd.Version)
+	assertions.Equal("~0.0.1", pd.VersionConstraint)
+
+	// Upgrades stay within the version constraint
+	plan, err := GetPluginUpgradePlan()
+	assertions.Nil(err)
+	assertions.Empty(plan)
+
+	err = UpgradePlugin("management-cluster", cli.VersionLatest, configtypes.TargetTMC)
+	assertions.Nil(err)
+	pd, err = DescribePlugin("management-cluster", configtypes.TargetTMC)
+	assertions.Nil(err)
+	assertions.Equal("v0.0.3", pd.Version)
+	assertions.Equal("~0.0.1", pd.VersionConstraint)
+
+	// Versions outside of the version constraint are skipped when syncing
+	requests := ApplyVersionConstraints([]PluginInstallRequest{
+		{Name: "management-cluster", Version: "v0.2.0", Target: configtypes.TargetTMC},
+		{Name: "cluster", Version: "v0.2.0", Target: configtypes.TargetTMC},
+	})
+	assertions.Equal([]PluginInstallRequest{{Name: "cluster", Version: "v0.2.0", Target: configtypes.TargetTMC}}, requests)
+
+	requests = ApplyVersionConstraints([]PluginInstallRequest{{Name: "management-cluster", Version: "v0.0.2", Target: configtypes.TargetTMC}})
+	assertions.Equal([]PluginInstallRequest{{Name: "management-cluster", Version: "v0.0.2", Target: configtypes.TargetTMC, VersionConstraint: "~0.0.1"}}, requests)
+	results := InstallStandalonePlugins(requests)
+	assertions.Nil(PluginInstallResultsError(results))
+	pd, err = DescribePlugin("management-cluster", configtypes.TargetTMC)
+	assertions.Nil(err)
+	assertions.Equal("v0.0.2", pd.Version)
+	assertions.Equal("~0.0.1", pd.VersionConstraint)
+
+	// Installing an exact version removes the version constraint
+	err = InstallStandalonePlugin("management-cluster", "v0.2.0", configtypes.TargetTMC)
+	assertions.Nil(err)
+	pd, err = DescribePlugin("management-cluster", configtypes.TargetTMC)
+	assertions.Nil(err)
+	assertions.Equal("v0.2.0", pd.Version)
+	assertions.Empty(pd.VersionConstraint)
+}
@@ -6,11 +6,15 @@ package pluginsupplier
 
 import (
 	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
 
 	"github.com/vmware-tanzu/tanzu-cli/pkg/catalog"
 	"github.com/vmware-tanzu/tanzu-cli/pkg/cli"
 	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
 // GetInstalledPlugins return the installed plugins
@@ -28,6 +32,88 @@ func GetInstalledPlugins() ([]cli.PluginInfo, error) {
 	return standAloneCatalog.List(), nil
 }
 
+// GetSideBySidePlugins returns the versions of the installed plugins that are installed
+// alongside their default version
+func GetSideBySidePlugins() ([]cli.PluginInfo, error) {
+	standAloneCatalog, err := catalog.NewContextCatalog("")
+	if err != nil {
+		return nil, err
+	}
+	installedPlugins := standAloneCatalog.List()
+
+	var plugins []cli.PluginInfo
+	for i := range installedPlugins {
+		plugins = append(plugins, standAloneCatalog.GetSideBySideVersions(catalog.PluginNameTarget(installedPlugins[i].Name, installedPlugins[i].Target))...)
+	}
+	return plugins, nil
+}
+
+// reportedUnavailableSelections keeps track of the selections of versions that are not installed
+// which were already reported, as the plugins are selected several times when building the commands
+var reportedUnavailableSelections = map[string]bool{}
+
+// SelectPluginVersions replaces the default version of the installed plugins with the
+// versions installed side by side that are selected. The selections map a plugin name,
+// optionally followed by '@' and a target, to a version.
+// A selected version that is not installed is skipped with a warning, so that the other
+// commands remain usable. The plugins which keep their default version because of such a
+// selection are returned, keyed by installation path, with the error to report when the
+// plugin is invoked.
+func SelectPluginVersions(plugins []cli.PluginInfo, selections map[string]string) ([]cli.PluginInfo, map[string]error, error) {
+	if len(selections) == 0 {
+		return plugins, nil, nil
+	}
+	sideBySidePlugins, err := GetSideBySidePlugins()
+	if err != nil {
+		return nil, nil, err
+	}
+	unavailable := map[string]error{}
+
+	selected := make([]cli.PluginInfo, len(plugins))
+	copy(selected, plugins)
+	for nameTarget, version := range selections {
+		name, targetStr, _ := strings.Cut(nameTarget, "@")
+		target := configtypes.StringToTarget(strings.ToLower(targetStr))
+		found := false
+		var unselected []string
+		for i := range selected {
+			if selected[i].Name != name || (targetStr != "" && selected[i].Target != target) {
+				continue
+			}
+			if selected[i].Version == version {
+				found = true
+				continue
+			}
+			selectedVersion := false
+			for j := range sideBySidePlugins {
+				if sideBySidePlugins[j].Name == selected[i].Name && sideBySidePlugins[j].Target == selected[i].Target && sideBySidePlugins[j].Version == version {
+					selected[i] = sideBySidePlugins[j]
+					selectedVersion = true
+					break
+				}
+			}
+			if selectedVersion {
+				found = true
+			} else {
+				unselected = append(unselected, selected[i].InstallationPath)
+			}
+		}
+		if found {
+			continue
+		}
+
+		err := errors.Errorf("version '%s' of plugin '%s' is not installed. Use 'tanzu plugin install %s --version %s --side-by-side' to install it", version, nameTarget, name, version)
+		if !reportedUnavailableSelections[nameTarget+"="+version] {
+			reportedUnavailableSelections[nameTarget+"="+version] = true
+			log.Warningf("Ignoring the selection of a plugin version: %v", err)
+		}
+		for _, path := range unselected {
+			unavailable[path] = err
+		}
+	}
+	return selected, unavailable, nil
+}
+
 // FilterPluginsByActiveContextType will exclude any plugin with an explicit
 // setting of supportedContextType that does not match the type of any active CLI context
 // Separating this conditional check so GetInstalledPlugins can
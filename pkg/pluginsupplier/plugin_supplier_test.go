@@ -359,6 +359,87 @@ var _ = Describe("GetInstalledPlugins (standalone plugins)", func() {
 	})
 })
 
+var _ = Describe("SelectPluginVersions", func() {
+	var (
+		cdir string
+		err  error
+		pd1  *cli.PluginInfo
+		pd2  *cli.PluginInfo
+		pd3  *cli.PluginInfo
+	)
+	BeforeEach(func() {
+		cdir, err = os.MkdirTemp("", "test-catalog-cache")
+		Expect(err).ToNot(HaveOccurred())
+		common.DefaultCacheDir = cdir
+
+		pd1, err = fakeInstallPlugin("", "fake-plugin1", types.TargetK8s, "v2.0.0")
+		Expect(err).ToNot(HaveOccurred())
+		pd2, err = fakeInstallPlugin("", "fake-plugin2", types.TargetGlobal, "v1.0.0")
+		Expect(err).ToNot(HaveOccurred())
+
+		cc, err := catalog.NewContextCatalogUpdater("")
+		Expect(err).ToNot(HaveOccurred())
+		pd3 = &cli.PluginInfo{
+			Name:             "fake-plugin1",
+			InstallationPath: "/path/to/plugin/fake-plugin1/v1.0.0",
+			Version:          "v1.0.0",
+			Target:           types.TargetK8s,
+			DefaultFeatureFlags: map[string]bool{
+				"test-feature": true,
+			},
+		}
+		Expect(cc.UpsertSideBySideVersion(pd3)).To(Succeed())
+		cc.Unlock()
+	})
+	AfterEach(func() {
+		os.RemoveAll(cdir)
+	})
+
+	It("should return the versions installed side by side", func() {
+		plugins, err := GetSideBySidePlugins()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plugins).To(ConsistOf(*pd3))
+
+		installedPlugins, err := GetInstalledPlugins()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(installedPlugins).To(ConsistOf(*pd1, *pd2))
+	})
+	It("should replace the default version with the selected version", func() {
+		installedPlugins, err := GetInstalledPlugins()
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, selection := range []string{"fake-plugin1", "fake-plugin1@kubernetes", "fake-plugin1@k8s"} {
+			plugins, unavailable, err := SelectPluginVersions(installedPlugins, map[string]string{selection: "v1.0.0"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unavailable).To(BeEmpty())
+			Expect(plugins).To(ConsistOf(*pd3, *pd2))
+		}
+
+		// Selecting the default version does not change anything
+		plugins, unavailable, err := SelectPluginVersions(installedPlugins, map[string]string{"fake-plugin2": "v1.0.0"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(unavailable).To(BeEmpty())
+		Expect(plugins).To(ConsistOf(*pd1, *pd2))
+	})
+	It("should skip the selected versions which are not installed", func() {
+		installedPlugins, err := GetInstalledPlugins()
+		Expect(err).ToNot(HaveOccurred())
+
+		plugins, unavailable, err := SelectPluginVersions(installedPlugins, map[string]string{"fake-plugin1": "v3.0.0", "fake-plugin2": "v1.0.0"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plugins).To(ConsistOf(*pd1, *pd2))
+		Expect(unavailable).To(HaveLen(1))
+		Expect(unavailable[pd1.InstallationPath]).To(HaveOccurred())
+		Expect(unavailable[pd1.InstallationPath].Error()).To(ContainSubstring("version 'v3.0.0' of plugin 'fake-plugin1' is not installed"))
+
+		// The selections of plugins that are not installed only cause a warning
+		plugins, unavailable, err = SelectPluginVersions(installedPlugins, map[string]string{"fake-plugin1@global": "v1.0.0"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plugins).To(ConsistOf(*pd1, *pd2))
+		Expect(unavailable).To(BeEmpty())
+	})
+})
+
 func fakeInstallPlugin(contextName, pluginName string, target types.Target, version string) (*cli.PluginInfo, error) {
 	cc, err := catalog.NewContextCatalogUpdater(contextName)
 	if err != nil {
@@ -4,6 +4,9 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -85,3 +88,18 @@ func AppendFile(filePath string, data []byte) error {
 	}
 	return nil
 }
+
+// SHA256FromFile returns the hex encoded SHA256 digest of the file content
+func SHA256FromFile(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Wrapf(err, "unable to read file '%s'", filePath)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
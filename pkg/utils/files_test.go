@@ -39,4 +39,22 @@ var _ = Describe("Unit tests for the files utils", func() {
 			Expect(err).To(BeNil())
 		})
 	})
+
+	Context("Unit tests for computing the SHA256 of a file", func() {
+		It("returns the digest of the file content", func() {
+			filePath := "/tmp/testfile-sha256"
+			err := SaveFile(filePath, []byte("Test Content"))
+			Expect(err).To(BeNil())
+			defer os.Remove(filePath)
+
+			digest, err := SHA256FromFile(filePath)
+			Expect(err).To(BeNil())
+			Expect(digest).To(Equal("60c9b75f15144a088fd7800e1049c6c80a92e76de588c2b21b30ff42f6694ce2"))
+		})
+
+		It("returns an error if the file does not exist", func() {
+			_, err := SHA256FromFile("/tmp/does-not-exist-sha256")
+			Expect(err).ToNot(BeNil())
+		})
+	})
 })
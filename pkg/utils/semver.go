@@ -6,6 +6,7 @@ package utils
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/Masterminds/semver"
 )
@@ -78,3 +79,70 @@ func IsSameMinor(v1str, v2str string) bool {
 	}
 	return v1.Major() == v2.Major() && v1.Minor() == v2.Minor()
 }
+
+// IsVersionConstraint returns true if the version is a semver range such as "~1.4", "^2.0.0"
+// or ">=1.2 <2.0", as opposed to an exact version or a vMAJOR or vMAJOR.MINOR version prefix.
+func IsVersionConstraint(versionStr string) bool {
+	if _, err := semver.NewVersion(versionStr); err == nil {
+		return false
+	}
+	_, err := ParseVersionConstraint(versionStr)
+	return err == nil
+}
+
+// ParseVersionConstraint parses a semver range. Besides the comma, whitespace
+// can be used to separate the constraints that must all be satisfied, e.g., ">=1.2 <2.0".
+func ParseVersionConstraint(constraintStr string) (*semver.Constraints, error) {
+	orGroups := strings.Split(constraintStr, "||")
+	for i, group := range orGroups {
+		var constraints []string
+		operator := ""
+		for _, field := range strings.Fields(strings.ReplaceAll(group, ",", " ")) {
+			// Allow a space between the operator and the version, e.g., ">= 1.2"
+			if strings.Trim(field, "<>=!~^") == "" {
+				operator += field
+				continue
+			}
+			constraints = append(constraints, operator+field)
+			operator = ""
+		}
+		orGroups[i] = strings.Join(constraints, ", ")
+	}
+	return semver.NewConstraint(strings.Join(orGroups, " || "))
+}
+
+// IsVersionInConstraint returns true if the version satisfies the semver range.
+func IsVersionInConstraint(versionStr, constraintStr string) bool {
+	constraint, err := ParseVersionConstraint(constraintStr)
+	if err != nil {
+		return false
+	}
+	version, err := semver.NewVersion(versionStr)
+	if err != nil {
+		return false
+	}
+	return constraint.Check(version)
+}
+
+// LatestVersionInConstraint returns the most recent of the versions that satisfies
+// the semver range, or an empty string if no version satisfies it.
+func LatestVersionInConstraint(versions []string, constraintStr string) (string, error) {
+	constraint, err := ParseVersionConstraint(constraintStr)
+	if err != nil {
+		return "", err
+	}
+	var latest *semver.Version
+	for _, v := range versions {
+		version, err := semver.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		if constraint.Check(version) && (latest == nil || version.GreaterThan(latest)) {
+			latest = version
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return latest.Original(), nil
+}
@@ -360,3 +360,61 @@ func TestIsSameMinor(t *testing.T) {
 		})
 	}
 }
+
+// TestIsVersionConstraint tests the IsVersionConstraint function.
+func TestIsVersionConstraint(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "~1.4", want: true},
+		{version: "^2.0.0", want: true},
+		{version: ">=1.2 <2.0", want: true},
+		{version: ">= 1.2, < 2.0", want: true},
+		{version: "<1.0 || >=2.0", want: true},
+		{version: "v1.2.3", want: false},
+		{version: "v1.2", want: false},
+		{version: "v1", want: false},
+		{version: "latest", want: false},
+		{version: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsVersionConstraint(tt.version))
+		})
+	}
+}
+
+// TestLatestVersionInConstraint tests the LatestVersionInConstraint and IsVersionInConstraint functions.
+func TestLatestVersionInConstraint(t *testing.T) {
+	versions := []string{"v1.2.0", "v1.4.0", "v1.4.3", "v1.5.0", "v2.0.0", "v2.1.0", "v3.0.0-beta.1"}
+
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{constraint: "~1.4", want: "v1.4.3"},
+		{constraint: "^2.0.0", want: "v2.1.0"},
+		{constraint: ">=1.2 <2.0", want: "v1.5.0"},
+		{constraint: ">= 1.2, < 1.4", want: "v1.2.0"},
+		{constraint: "<1.3 || >=2.0 <2.1", want: "v2.0.0"},
+		{constraint: ">=4.0", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			latest, err := LatestVersionInConstraint(versions, tt.constraint)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, latest)
+			if tt.want != "" {
+				assert.True(t, IsVersionInConstraint(tt.want, tt.constraint))
+			}
+		})
+	}
+
+	_, err := LatestVersionInConstraint(versions, "invalid")
+	assert.NotNil(t, err)
+	assert.False(t, IsVersionInConstraint("v1.0.0", "invalid"))
+	assert.False(t, IsVersionInConstraint("v2.0.0", "~1.4"))
+}